@@ -23,15 +23,10 @@ func NewAuthMiddleware(uc *usecase.AuthUseCase) *AuthMiddleware {
 func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 	logger := log.New()
 	return func(c echo.Context) error {
-		authHeader := c.Request().Header.Get(echo.HeaderAuthorization)
-		authScheme := "Bearer"
-
-		// Tokenから"Bearer "を取り除く
-		l := len(authScheme)
-		if len(authHeader) <= l+1 || authHeader[:l] != authScheme {
+		token, ok := bearerToken(c)
+		if !ok {
 			return echo.NewHTTPError(http.StatusBadRequest, "Invalid token")
 		}
-		token := authHeader[l+1:]
 
 		userID, err := m.uc.Authenticate(c.Request().Context(), token)
 		if err != nil {
@@ -43,3 +38,44 @@ func (m *AuthMiddleware) Authenticate(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// OptionalAuthenticate は有効なAuthorizationヘッダーがあればuserIDをcontextにセットし，
+// ヘッダーがない場合や認証に失敗した場合は未ログインとしてそのまま次の処理に進む
+// ログインしていなくても閲覧できるが，ログインしていれば閲覧者に応じた内容を返すエンドポイントで使う
+func (m *AuthMiddleware) OptionalAuthenticate(next echo.HandlerFunc) echo.HandlerFunc {
+	logger := log.New()
+	return func(c echo.Context) error {
+		token, ok := bearerToken(c)
+		if !ok {
+			return next(c)
+		}
+
+		userID, err := m.uc.Authenticate(c.Request().Context(), token)
+		if err != nil {
+			logger.Infof("proceed without authentication: %v", err)
+			return next(c)
+		}
+
+		c.Set("userID", userID)
+		return next(c)
+	}
+}
+
+// bearerToken はAuthorizationヘッダーから"Bearer "を取り除いたTokenを取り出す
+func bearerToken(c echo.Context) (string, bool) {
+	authHeader := c.Request().Header.Get(echo.HeaderAuthorization)
+	authScheme := "Bearer"
+
+	l := len(authScheme)
+	if len(authHeader) <= l+1 || authHeader[:l] != authScheme {
+		return "", false
+	}
+	return authHeader[l+1:], true
+}
+
+// viewerID はOptionalAuthenticateを通ったリクエストの閲覧者のuserIDを返す
+// 未ログインの場合は空文字列を返す
+func viewerID(c echo.Context) string {
+	userID, _ := c.Get("userID").(string)
+	return userID
+}
@@ -103,3 +103,70 @@ func TestAuthMiddleware_Authenticate(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthMiddleware_OptionalAuthenticate(t *testing.T) {
+	tests := []struct {
+		name            string
+		prepareRequest  func(req *http.Request)
+		prepareMockAuth func(f *mock.MockAuth)
+		wantUserID      interface{}
+	}{
+		{
+			name: "正しいTokenならuserIDをセットする",
+			prepareRequest: func(req *http.Request) {
+				req.Header.Set("Authorization", "Bearer token")
+			},
+			prepareMockAuth: func(f *mock.MockAuth) {
+				f.EXPECT().Authenticate(gomock.Any(), "token").Return("currentUserID", nil)
+			},
+			wantUserID: "currentUserID",
+		},
+		{
+			name:            "Headerがなければ未ログインとして進む",
+			prepareRequest:  func(req *http.Request) {},
+			prepareMockAuth: func(f *mock.MockAuth) {},
+			wantUserID:      nil,
+		},
+		{
+			name: "認証されていないTokenなら未ログインとして進む",
+			prepareRequest: func(req *http.Request) {
+				req.Header.Set("Authorization", "Bearer invalidToken")
+			},
+			prepareMockAuth: func(f *mock.MockAuth) {
+				f.EXPECT().Authenticate(gomock.Any(), "invalidToken").Return("", errors.New("error verifying ID token"))
+			},
+			wantUserID: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/", nil)
+			tt.prepareRequest(req)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			authRepo := mock.NewMockAuth(ctrl)
+			tt.prepareMockAuth(authRepo)
+
+			called := false
+			next := func(c echo.Context) error {
+				called = true
+				if diff := cmp.Diff(tt.wantUserID, c.Get("userID")); diff != "" {
+					t.Errorf("userID (-want +got) =\n%s\n", diff)
+				}
+				return nil
+			}
+
+			m := NewAuthMiddleware(usecase.NewAuthUseCase(authRepo))
+			if err := m.OptionalAuthenticate(next)(c); err != nil {
+				t.Errorf("error = %v", err)
+			}
+			if !called {
+				t.Error("次の処理が呼ばれなかった")
+			}
+		})
+	}
+}
@@ -1,9 +1,13 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
@@ -34,7 +38,7 @@ func (ctrl *CommentController) Get(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest)
 	}
 
-	comment, err := ctrl.uc.Get(c.Request().Context(), postID, commentID)
+	comment, err := ctrl.uc.Get(c.Request().Context(), viewerID(c), postID, commentID)
 
 	if err != nil {
 		errNF := &entity.ErrNotFound{}
@@ -50,14 +54,28 @@ func (ctrl *CommentController) Get(c echo.Context) error {
 }
 
 // GetByPostID は GET /post/{postID}/comment のHandler
+// highlightコメントにはクエリパラメータrevisionで指定したリビジョン(省略すると最新)への行範囲の投影が含まれます
+// クエリパラメータresolvedを指定すると，スレッドが解決済みかどうかで絞り込みます
 func (ctrl *CommentController) GetByPostID(c echo.Context) error {
 	logger := log.New()
 	postID, err := strconv.Atoi(c.Param("postID"))
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest)
 	}
+	revision, err := bindRevisionParam(c, "revision")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	var resolved *bool
+	if resolvedStr := c.QueryParam("resolved"); len(resolvedStr) > 0 {
+		r, err := strconv.ParseBool(resolvedStr)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "resolved must be true or false")
+		}
+		resolved = &r
+	}
 
-	comments, err := ctrl.uc.GetByPostID(c.Request().Context(), postID)
+	comments, err := ctrl.uc.GetByPostID(c.Request().Context(), viewerID(c), postID, revision, resolved)
 
 	if err != nil {
 		errNF := &entity.ErrNotFound{}
@@ -72,6 +90,34 @@ func (ctrl *CommentController) GetByPostID(c echo.Context) error {
 	return c.JSON(http.StatusOK, comments)
 }
 
+// GetTreeByPostID は GET /post/{postID}/comment/tree のHandler
+// highlightコメントにはクエリパラメータrevisionで指定したリビジョン(省略すると最新)への行範囲の投影が含まれます
+func (ctrl *CommentController) GetTreeByPostID(c echo.Context) error {
+	logger := log.New()
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	revision, err := bindRevisionParam(c, "revision")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	tree, err := ctrl.uc.GetTreeByPostID(c.Request().Context(), viewerID(c), postID, revision)
+
+	if err != nil {
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("Unexpected error GET /post/{postID}/comment/tree: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, tree)
+}
+
 // Create は POST /post/{postID}/comment のHandler
 func (ctrl *CommentController) Create(c echo.Context) error {
 	logger := log.New()
@@ -100,6 +146,16 @@ func (ctrl *CommentController) Create(c echo.Context) error {
 		if errors.Is(err, entity.ErrCannotCommit) {
 			return echo.NewHTTPError(http.StatusForbidden, entity.ErrCannotCommit.Error())
 		}
+		if errors.Is(err, entity.ErrPostArchived) {
+			return echo.NewHTTPError(http.StatusForbidden, entity.ErrPostArchived.Error())
+		}
+		if errors.Is(err, entity.ErrInvalidParentComment) {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrInvalidParentComment.Error())
+		}
+		errOOR := &entity.ErrOutOfRange{}
+		if errors.As(err, errOOR) {
+			return echo.NewHTTPError(http.StatusBadRequest, errOOR.Error())
+		}
 		errNF := &entity.ErrNotFound{}
 		if errors.As(err, errNF) {
 			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
@@ -142,6 +198,16 @@ func (ctrl *CommentController) Update(c echo.Context) error {
 			// コミットできない場合は、StatusForbidden
 			return echo.NewHTTPError(http.StatusForbidden, entity.ErrCannotCommit.Error())
 		}
+		if errors.Is(err, entity.ErrCommitImmutable) {
+			return echo.NewHTTPError(http.StatusConflict, entity.ErrCommitImmutable.Error())
+		}
+		if errors.Is(err, entity.ErrAppliedSuggestionImmutable) {
+			return echo.NewHTTPError(http.StatusConflict, entity.ErrAppliedSuggestionImmutable.Error())
+		}
+		errOOR := &entity.ErrOutOfRange{}
+		if errors.As(err, errOOR) {
+			return echo.NewHTTPError(http.StatusBadRequest, errOOR.Error())
+		}
 		errNF := &entity.ErrNotFound{}
 		if errors.As(err, errNF) {
 			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
@@ -188,3 +254,250 @@ func (ctrl *CommentController) Delete(c echo.Context) error {
 	}
 	return c.NoContent(http.StatusOK)
 }
+
+// React は PUT /post/{postID}/comment/{commentID}/reaction/{emoji} のHandler
+func (ctrl *CommentController) React(c echo.Context) error {
+	return ctrl.updateReaction(c, ctrl.uc.React)
+}
+
+// Unreact は DELETE /post/{postID}/comment/{commentID}/reaction/{emoji} のHandler
+func (ctrl *CommentController) Unreact(c echo.Context) error {
+	return ctrl.updateReaction(c, ctrl.uc.Unreact)
+}
+
+// updateReaction はリアクションをつける，外すハンドラに共通の処理です
+func (ctrl *CommentController) updateReaction(c echo.Context, update func(context.Context, *entity.Reaction) error) error {
+	logger := log.New()
+
+	reaction := &entity.Reaction{Emoji: c.Param("emoji")}
+	var ok bool
+	if reaction.UserID, ok = c.Get("userID").(string); !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	var err error
+	if reaction.PostID, err = strconv.Atoi(c.Param("postID")); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	if reaction.CommentID, err = strconv.Atoi(c.Param("commentID")); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if err := update(c.Request().Context(), reaction); err != nil {
+		if errors.Is(err, entity.ErrUnknownReaction) {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrUnknownReaction.Error())
+		}
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error %s /post/{postID}/comment/{commentID}/reaction/{emoji}: %s", c.Request().Method, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// Resolve は PUT /post/{postID}/comment/{commentID}/resolve のHandler
+func (ctrl *CommentController) Resolve(c echo.Context) error {
+	return ctrl.updateResolution(c, ctrl.uc.Resolve)
+}
+
+// Reopen は DELETE /post/{postID}/comment/{commentID}/resolve のHandler
+func (ctrl *CommentController) Reopen(c echo.Context) error {
+	return ctrl.updateResolution(c, ctrl.uc.Reopen)
+}
+
+// updateResolution はスレッドを解決済みにする，未解決に戻すハンドラに共通の処理です
+func (ctrl *CommentController) updateResolution(c echo.Context, update func(ctx context.Context, postID, commentID int, userID string) error) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	commentID, err := strconv.Atoi(c.Param("commentID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if err := update(c.Request().Context(), postID, commentID, userID); err != nil {
+		if errors.Is(err, entity.ErrCannotResolve) {
+			return echo.NewHTTPError(http.StatusForbidden, entity.ErrCannotResolve.Error())
+		}
+		if errors.Is(err, entity.ErrCannotResolveReply) {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrCannotResolveReply.Error())
+		}
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error %s /post/{postID}/comment/{commentID}/resolve: %s", c.Request().Method, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// ApplySuggestion は POST /post/{postID}/comment/{commentID}/apply のHandler
+// suggestionコメントを適用して作られたcommitコメントを返します
+func (ctrl *CommentController) ApplySuggestion(c echo.Context) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	commentID, err := strconv.Atoi(c.Param("commentID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	commit, err := ctrl.uc.ApplySuggestion(c.Request().Context(), postID, commentID, userID)
+	if err != nil {
+		if errors.Is(err, entity.ErrCannotApplySuggestion) {
+			return echo.NewHTTPError(http.StatusForbidden, entity.ErrCannotApplySuggestion.Error())
+		}
+		if errors.Is(err, entity.ErrPostArchived) {
+			return echo.NewHTTPError(http.StatusForbidden, entity.ErrPostArchived.Error())
+		}
+		if errors.Is(err, entity.ErrNotSuggestion) {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrNotSuggestion.Error())
+		}
+		if errors.Is(err, entity.ErrSuggestionApplied) {
+			return echo.NewHTTPError(http.StatusConflict, entity.ErrSuggestionApplied.Error())
+		}
+		if errors.Is(err, entity.ErrSuggestionOutdated) {
+			return echo.NewHTTPError(http.StatusConflict, entity.ErrSuggestionOutdated.Error())
+		}
+		if errors.Is(err, entity.ErrSuggestionEmptiesCode) {
+			return echo.NewHTTPError(http.StatusConflict, entity.ErrSuggestionEmptiesCode.Error())
+		}
+		if errors.Is(err, entity.ErrDiffTooLarge) {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrDiffTooLarge.Error())
+		}
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+		logger.Errorf("error POST /post/{postID}/comment/{commentID}/apply: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	return c.JSON(http.StatusCreated, commit)
+}
+
+// Accept は PUT /post/{postID}/comment/{commentID}/accept のHandler
+func (ctrl *CommentController) Accept(c echo.Context) error {
+	return ctrl.updateAcceptance(c, ctrl.uc.Accept)
+}
+
+// Unaccept は DELETE /post/{postID}/comment/{commentID}/accept のHandler
+func (ctrl *CommentController) Unaccept(c echo.Context) error {
+	return ctrl.updateAcceptance(c, ctrl.uc.Unaccept)
+}
+
+// updateAcceptance はコメントを回答として採用する，採用を取り消すハンドラに共通の処理です
+func (ctrl *CommentController) updateAcceptance(c echo.Context, update func(ctx context.Context, postID, commentID int, userID string) error) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	commentID, err := strconv.Atoi(c.Param("commentID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if err := update(c.Request().Context(), postID, commentID, userID); err != nil {
+		if errors.Is(err, entity.ErrIsNotAuthor) {
+			return echo.NewHTTPError(http.StatusForbidden, entity.ErrIsNotAuthor.Error())
+		}
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error %s /post/{postID}/comment/{commentID}/accept: %s", c.Request().Method, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// streamHeartbeatInterval はコメントのストリームで接続を維持するためにコメント行を送る間隔です
+const streamHeartbeatInterval = 30 * time.Second
+
+// Stream は GET /post/{postID}/comment/stream のHandler
+// 投稿のコメントの作成，更新，削除をServer-Sent Eventsで配信します
+func (ctrl *CommentController) Stream(c echo.Context) error {
+	logger := log.New()
+
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	ctx := c.Request().Context()
+	events, err := ctrl.uc.Subscribe(ctx, viewerID(c), postID)
+	if err != nil {
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+		logger.Errorf("error GET /post/{postID}/comment/stream: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(event.Comment)
+			if err != nil {
+				logger.Errorf("failed to marshal comment event: %s", err.Error())
+				continue
+			}
+			if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
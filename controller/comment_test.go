@@ -21,7 +21,9 @@ func TestCommentController_Get(t *testing.T) {
 		name               string
 		postID             string
 		commentID          string
+		viewerID           string
 		prepareMockComment func(comment *mock.MockComment)
+		prepareMockPost    func(post *mock.MockPost)
 		wantErr            bool
 		wantCode           int
 		wantBody           string
@@ -44,6 +46,9 @@ func TestCommentController_Get(t *testing.T) {
 						UpdatedAt: "1970-01-01T09:01:40+09:00",
 					}, nil)
 			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner"}, nil)
+			},
 			wantErr:  false,
 			wantCode: 200,
 			wantBody: `{
@@ -65,9 +70,10 @@ func TestCommentController_Get(t *testing.T) {
 			commentID: "1",
 			prepareMockComment: func(comment *mock.MockComment) {
 			},
-			wantErr:  true,
-			wantCode: 400,
-			wantBody: "",
+			prepareMockPost: func(post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        400,
+			wantBody:        "",
 		},
 		{
 			name:      "postIDが数値でないならBadRequest",
@@ -75,9 +81,10 @@ func TestCommentController_Get(t *testing.T) {
 			commentID: "1",
 			prepareMockComment: func(comment *mock.MockComment) {
 			},
-			wantErr:  true,
-			wantCode: 400,
-			wantBody: "",
+			prepareMockPost: func(post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        400,
+			wantBody:        "",
 		},
 		{
 			name:      "commentIDが空ならBadRequest",
@@ -85,9 +92,10 @@ func TestCommentController_Get(t *testing.T) {
 			commentID: "",
 			prepareMockComment: func(comment *mock.MockComment) {
 			},
-			wantErr:  true,
-			wantCode: 400,
-			wantBody: "",
+			prepareMockPost: func(post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        400,
+			wantBody:        "",
 		},
 		{
 			name:      "commentIDが数値でないならBadRequest",
@@ -95,9 +103,10 @@ func TestCommentController_Get(t *testing.T) {
 			commentID: "a",
 			prepareMockComment: func(comment *mock.MockComment) {
 			},
-			wantErr:  true,
-			wantCode: 400,
-			wantBody: "",
+			prepareMockPost: func(post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        400,
+			wantBody:        "",
 		},
 		{
 			name:      "commentが存在しないならNotFound",
@@ -107,10 +116,64 @@ func TestCommentController_Get(t *testing.T) {
 				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(
 					nil, entity.NewErrorNotFound("comment"))
 			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner"}, nil)
+			},
+			wantErr:  true,
+			wantCode: 404,
+			wantBody: "",
+		},
+		{
+			name:               "招待されていなければ非公開の投稿のコメントはNotFound",
+			postID:             "1",
+			commentID:          "1",
+			viewerID:           "other",
+			prepareMockComment: func(comment *mock.MockComment) {},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner", Visibility: entity.PostVisibilityPrivate}, nil)
+				post.EXPECT().IsInvited(gomock.Any(), 1, "other").Return(false, nil)
+			},
 			wantErr:  true,
 			wantCode: 404,
 			wantBody: "",
 		},
+		{
+			name:      "招待されていれば非公開の投稿のコメントを取得できる",
+			postID:    "1",
+			commentID: "1",
+			viewerID:  "invitee",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(
+					&entity.Comment{
+						ID:        1,
+						UserID:    "invitee",
+						PostID:    1,
+						Type:      "none",
+						Content:   "content1",
+						CreatedAt: "1970-01-01T09:01:40+09:00",
+						UpdatedAt: "1970-01-01T09:01:40+09:00",
+					}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner", Visibility: entity.PostVisibilityPrivate}, nil)
+				post.EXPECT().IsInvited(gomock.Any(), 1, "invitee").Return(true, nil)
+			},
+			wantErr:  false,
+			wantCode: 200,
+			wantBody: `{
+				"id": 1,
+				"user_id": "invitee",
+				"post_id": 1,
+				"type": "none",
+				"content": "content1",
+				"editable": true,
+				"first_line": 0,
+				"last_line": 0,
+				"code": "",
+				"created_at": "1970-01-01T09:01:40+09:00",
+				"updated_at": "1970-01-01T09:01:40+09:00"
+			}`,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -120,15 +183,22 @@ func TestCommentController_Get(t *testing.T) {
 			c := e.NewContext(req, rec)
 			c.SetParamNames("postID", "commentID")
 			c.SetParamValues(tt.postID, tt.commentID)
+			if len(tt.viewerID) > 0 {
+				c.Set("userID", tt.viewerID)
+			}
 
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			commentRepo := mock.NewMockComment(ctrl)
 			tt.prepareMockComment(commentRepo)
 			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(postRepo)
 			userRepo := mock.NewMockUser(ctrl)
 
-			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo))
+			notificationRepo := mock.NewMockNotification(ctrl)
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
 			err := con.Get(c)
 
 			if (err != nil) != tt.wantErr {
@@ -166,7 +236,9 @@ func TestCommentController_GetByPostID(t *testing.T) {
 	tests := []struct {
 		name               string
 		postID             string
+		query              string
 		prepareMockComment func(comment *mock.MockComment)
+		prepareMockPost    func(post *mock.MockPost)
 		wantErr            bool
 		wantCode           int
 		wantBody           string
@@ -198,6 +270,10 @@ func TestCommentController_GetByPostID(t *testing.T) {
 							FirstLine: 0,
 							LastLine:  0,
 							Code:      "code2",
+							Reactions: []*entity.ReactionCount{
+								{Emoji: "thumbsup", Count: 2},
+								{Emoji: "eyes", Count: 1},
+							},
 							CreatedAt: "1970-01-01T09:01:40+09:00",
 							UpdatedAt: "1970-01-01T09:01:40+09:00",
 						},
@@ -205,6 +281,9 @@ func TestCommentController_GetByPostID(t *testing.T) {
 					nil,
 				)
 			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "userid1", Code: "code1"}, nil)
+			},
 			wantErr:  false,
 			wantCode: 200,
 			wantBody: `[
@@ -217,6 +296,7 @@ func TestCommentController_GetByPostID(t *testing.T) {
 					"first_line": 10,
 					"last_line": 12,
 					"code": "",
+					"projection": {"revision": 1, "first_line": 10, "last_line": 12, "outdated": false},
 					"created_at": "1970-01-01T09:01:40+09:00",
 					"updated_at": "1970-01-01T09:01:40+09:00"
 				},
@@ -229,28 +309,286 @@ func TestCommentController_GetByPostID(t *testing.T) {
 					"first_line": 0,
 					"last_line": 0,
 					"code": "code2",
+					"reactions": [{"emoji": "thumbsup", "count": 2}, {"emoji": "eyes", "count": 1}],
 					"created_at": "1970-01-01T09:01:40+09:00",
 					"updated_at": "1970-01-01T09:01:40+09:00"
 				}
 			]`,
 		},
 		{
-			name:   "postIDが空ならBadRequest",
-			postID: "",
+			name:   "回答として採用されたコメントが先頭になる",
+			postID: "1",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(
+					[]*entity.Comment{
+						{
+							ID:        1,
+							UserID:    "userid1",
+							PostID:    1,
+							Type:      "none",
+							Content:   "question",
+							CreatedAt: "1970-01-01T09:01:40+09:00",
+							UpdatedAt: "1970-01-01T09:01:40+09:00",
+						},
+						{
+							ID:        2,
+							UserID:    "userid2",
+							PostID:    1,
+							Type:      "none",
+							Content:   "answer",
+							CreatedAt: "1970-01-01T09:01:41+09:00",
+							UpdatedAt: "1970-01-01T09:01:41+09:00",
+						},
+					},
+					nil,
+				)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "userid1", Code: "code1", AcceptedCommentID: 2}, nil)
+			},
+			wantErr:  false,
+			wantCode: 200,
+			wantBody: `[
+				{
+					"id": 2,
+					"user_id": "userid2",
+					"post_id": 1,
+					"type": "none",
+					"content": "answer",
+					"first_line": 0,
+					"last_line": 0,
+					"code": "",
+					"accepted": true,
+					"created_at": "1970-01-01T09:01:41+09:00",
+					"updated_at": "1970-01-01T09:01:41+09:00"
+				},
+				{
+					"id": 1,
+					"user_id": "userid1",
+					"post_id": 1,
+					"type": "none",
+					"content": "question",
+					"first_line": 0,
+					"last_line": 0,
+					"code": "",
+					"created_at": "1970-01-01T09:01:40+09:00",
+					"updated_at": "1970-01-01T09:01:40+09:00"
+				}
+			]`,
+		},
+		{
+			name:   "highlightの行範囲を最新のリビジョンに投影できる",
+			postID: "1",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(
+					[]*entity.Comment{
+						{
+							ID:           1,
+							UserID:       "userid1",
+							PostID:       1,
+							Type:         "highlight",
+							FirstLine:    2,
+							LastLine:     3,
+							BaseRevision: 1,
+							CreatedAt:    "1970-01-01T09:01:40+09:00",
+							UpdatedAt:    "1970-01-01T09:01:40+09:00",
+						},
+						{
+							ID:           2,
+							UserID:       "userid2",
+							PostID:       1,
+							Type:         "highlight",
+							FirstLine:    4,
+							LastLine:     4,
+							BaseRevision: 1,
+							CreatedAt:    "1970-01-01T09:01:41+09:00",
+							UpdatedAt:    "1970-01-01T09:01:41+09:00",
+						},
+						{
+							ID:        3,
+							UserID:    "userid1",
+							PostID:    1,
+							Type:      "commit",
+							Code:      "a\nadded\nb\nc",
+							Revision:  2,
+							CreatedAt: "1970-01-01T09:01:42+09:00",
+							UpdatedAt: "1970-01-01T09:01:42+09:00",
+						},
+					},
+					nil,
+				)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "userid1", Code: "a\nb\nc\nd"}, nil)
+			},
+			wantErr:  false,
+			wantCode: 200,
+			wantBody: `[
+				{
+					"id": 1,
+					"user_id": "userid1",
+					"post_id": 1,
+					"type": "highlight",
+					"content": "",
+					"first_line": 2,
+					"last_line": 3,
+					"code": "",
+					"base_revision": 1,
+					"projection": {"revision": 2, "first_line": 3, "last_line": 4, "outdated": false},
+					"created_at": "1970-01-01T09:01:40+09:00",
+					"updated_at": "1970-01-01T09:01:40+09:00"
+				},
+				{
+					"id": 2,
+					"user_id": "userid2",
+					"post_id": 1,
+					"type": "highlight",
+					"content": "",
+					"first_line": 4,
+					"last_line": 4,
+					"code": "",
+					"base_revision": 1,
+					"projection": {"revision": 2, "outdated": true},
+					"created_at": "1970-01-01T09:01:41+09:00",
+					"updated_at": "1970-01-01T09:01:41+09:00"
+				},
+				{
+					"id": 3,
+					"user_id": "userid1",
+					"post_id": 1,
+					"type": "commit",
+					"content": "",
+					"first_line": 0,
+					"last_line": 0,
+					"code": "a\nadded\nb\nc",
+					"revision": 2,
+					"created_at": "1970-01-01T09:01:42+09:00",
+					"updated_at": "1970-01-01T09:01:42+09:00"
+				}
+			]`,
+		},
+		{
+			name:   "存在しないリビジョンを指定するとErrNotFound",
+			postID: "1",
+			query:  "revision=2",
 			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(
+					[]*entity.Comment{
+						{ID: 1, UserID: "userid1", PostID: 1, Type: "none", Content: "content1"},
+					},
+					nil,
+				)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "userid1", Code: "code1"}, nil)
 			},
 			wantErr:  true,
-			wantCode: 400,
+			wantCode: 404,
 			wantBody: "",
 		},
+		{
+			name:   "resolved=falseなら未解決のスレッドのコメントだけを返す",
+			postID: "1",
+			query:  "resolved=false",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(
+					[]*entity.Comment{
+						{
+							ID:         1,
+							UserID:     "userid1",
+							PostID:     1,
+							Type:       "none",
+							Content:    "resolved",
+							Resolved:   true,
+							ResolvedBy: "userid1",
+							ResolvedAt: "1970-01-01T09:01:41+09:00",
+							CreatedAt:  "1970-01-01T09:01:40+09:00",
+							UpdatedAt:  "1970-01-01T09:01:40+09:00",
+						},
+						{
+							ID:        2,
+							UserID:    "userid2",
+							PostID:    1,
+							ParentID:  1,
+							Type:      "none",
+							Content:   "reply to resolved",
+							CreatedAt: "1970-01-01T09:01:40+09:00",
+							UpdatedAt: "1970-01-01T09:01:40+09:00",
+						},
+						{
+							ID:        3,
+							UserID:    "userid2",
+							PostID:    1,
+							Type:      "none",
+							Content:   "open",
+							CreatedAt: "1970-01-01T09:01:40+09:00",
+							UpdatedAt: "1970-01-01T09:01:40+09:00",
+						},
+					},
+					nil,
+				)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "userid1", Code: "code1"}, nil)
+			},
+			wantErr:  false,
+			wantCode: 200,
+			wantBody: `[
+				{
+					"id": 3,
+					"user_id": "userid2",
+					"post_id": 1,
+					"type": "none",
+					"content": "open",
+					"first_line": 0,
+					"last_line": 0,
+					"code": "",
+					"created_at": "1970-01-01T09:01:40+09:00",
+					"updated_at": "1970-01-01T09:01:40+09:00"
+				}
+			]`,
+		},
+		{
+			name:   "resolvedが真偽値でないならBadRequest",
+			postID: "1",
+			query:  "resolved=maybe",
+			prepareMockComment: func(comment *mock.MockComment) {
+			},
+			prepareMockPost: func(post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        400,
+			wantBody:        "",
+		},
+		{
+			name:   "revisionが正の整数でないならBadRequest",
+			postID: "1",
+			query:  "revision=0",
+			prepareMockComment: func(comment *mock.MockComment) {
+			},
+			prepareMockPost: func(post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        400,
+			wantBody:        "",
+		},
+		{
+			name:   "postIDが空ならBadRequest",
+			postID: "",
+			prepareMockComment: func(comment *mock.MockComment) {
+			},
+			prepareMockPost: func(post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        400,
+			wantBody:        "",
+		},
 		{
 			name:   "postIDが数値でないならBadRequest",
 			postID: "a",
 			prepareMockComment: func(comment *mock.MockComment) {
 			},
-			wantErr:  true,
-			wantCode: 400,
-			wantBody: "",
+			prepareMockPost: func(post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        400,
+			wantBody:        "",
 		},
 		{
 			name:   "取得したコメント数が0ならErrNotFound",
@@ -260,6 +598,9 @@ func TestCommentController_GetByPostID(t *testing.T) {
 					nil, entity.NewErrorNotFound("comment"),
 				)
 			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 100).Return(&entity.Post{ID: 100, UserID: "owner"}, nil)
+			},
 			wantErr:  true,
 			wantCode: 404,
 			wantBody: "",
@@ -268,7 +609,7 @@ func TestCommentController_GetByPostID(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest("GET", "/", nil)
+			req := httptest.NewRequest("GET", "/?"+tt.query, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("postID")
@@ -279,9 +620,13 @@ func TestCommentController_GetByPostID(t *testing.T) {
 			commentRepo := mock.NewMockComment(ctrl)
 			tt.prepareMockComment(commentRepo)
 			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(postRepo)
 			userRepo := mock.NewMockUser(ctrl)
 
-			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo))
+			notificationRepo := mock.NewMockNotification(ctrl)
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
 			err := con.GetByPostID(c)
 
 			if (err != nil) != tt.wantErr {
@@ -315,12 +660,11 @@ func TestCommentController_GetByPostID(t *testing.T) {
 	}
 }
 
-func TestCommentController_Create(t *testing.T) {
+func TestCommentController_GetTreeByPostID(t *testing.T) {
 	tests := []struct {
 		name               string
 		postID             string
-		userID             string
-		body               string
+		query              string
 		prepareMockComment func(comment *mock.MockComment)
 		prepareMockPost    func(post *mock.MockPost)
 		wantErr            bool
@@ -328,35 +672,221 @@ func TestCommentController_Create(t *testing.T) {
 		wantBody           string
 	}{
 		{
-			name:   "正しくコメントを作成できる",
+			name:   "返信をツリーにして取得できる",
 			postID: "1",
-			userID: "user-id",
-			body: `{
-				"type": "highlight",
-				"content": "content1",
-				"first_line": 10,
-				"last_line": 12,
-				"created_at":"2021-03-23T11:42:56+09:00",
-				"updated_at":"2021-03-23T11:42:56+09:00"
-			}`,
 			prepareMockComment: func(comment *mock.MockComment) {
-				comment.EXPECT().Insert(
-					gomock.Any(),
-					&entity.Comment{
-						UserID:    "user-id",
-						PostID:    1,
-						Type:      "highlight",
-						Content:   "content1",
-						FirstLine: 10,
-						LastLine:  12,
-						CreatedAt: "2021-03-23T11:42:56+09:00",
-						UpdatedAt: "2021-03-23T11:42:56+09:00",
-					}).DoAndReturn(func(ctx context.Context, comment *entity.Comment) error {
-					comment.ID = 1
-					return nil
-				})
-			},
-			prepareMockPost: func(post *mock.MockPost) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(
+					[]*entity.Comment{
+						{
+							ID:        1,
+							UserID:    "userid1",
+							PostID:    1,
+							Type:      "highlight",
+							FirstLine: 10,
+							LastLine:  12,
+							Deleted:   true,
+							CreatedAt: "1970-01-01T09:01:40+09:00",
+							UpdatedAt: "1970-01-01T09:01:40+09:00",
+						},
+						{
+							ID:        2,
+							UserID:    "userid2",
+							PostID:    1,
+							ParentID:  1,
+							Type:      "none",
+							Content:   "content2",
+							CreatedAt: "1970-01-01T09:01:40+09:00",
+							UpdatedAt: "1970-01-01T09:01:40+09:00",
+						},
+						{
+							ID:        3,
+							UserID:    "userid1",
+							PostID:    1,
+							Type:      "none",
+							Content:   "content3",
+							CreatedAt: "1970-01-01T09:01:40+09:00",
+							UpdatedAt: "1970-01-01T09:01:40+09:00",
+						},
+					},
+					nil,
+				)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "userid1", Code: "code1"}, nil)
+			},
+			wantErr:  false,
+			wantCode: 200,
+			wantBody: `[
+				{
+					"id": 1,
+					"user_id": "userid1",
+					"post_id": 1,
+					"type": "highlight",
+					"content": "",
+					"first_line": 10,
+					"last_line": 12,
+					"code": "",
+					"deleted": true,
+					"created_at": "1970-01-01T09:01:40+09:00",
+					"updated_at": "1970-01-01T09:01:40+09:00",
+					"replies": [
+						{
+							"id": 2,
+							"user_id": "userid2",
+							"post_id": 1,
+							"parent_id": 1,
+							"type": "none",
+							"content": "content2",
+							"first_line": 0,
+							"last_line": 0,
+							"code": "",
+							"created_at": "1970-01-01T09:01:40+09:00",
+							"updated_at": "1970-01-01T09:01:40+09:00",
+							"replies": []
+						}
+					]
+				},
+				{
+					"id": 3,
+					"user_id": "userid1",
+					"post_id": 1,
+					"type": "none",
+					"content": "content3",
+					"first_line": 0,
+					"last_line": 0,
+					"code": "",
+					"created_at": "1970-01-01T09:01:40+09:00",
+					"updated_at": "1970-01-01T09:01:40+09:00",
+					"replies": []
+				}
+			]`,
+		},
+		{
+			name:   "postIDが数値でないならBadRequest",
+			postID: "a",
+			prepareMockComment: func(comment *mock.MockComment) {
+			},
+			prepareMockPost: func(post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        400,
+			wantBody:        "",
+		},
+		{
+			name:   "取得したコメント数が0ならErrNotFound",
+			postID: "100",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 100).Return(
+					nil, entity.NewErrorNotFound("comment"),
+				)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 100).Return(&entity.Post{ID: 100, UserID: "owner"}, nil)
+			},
+			wantErr:  true,
+			wantCode: 404,
+			wantBody: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			commentRepo := mock.NewMockComment(ctrl)
+			tt.prepareMockComment(commentRepo)
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+
+			notificationRepo := mock.NewMockNotification(ctrl)
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
+			err := con.GetTreeByPostID(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if !tt.wantErr {
+				var gotBody, wantBody []map[string]interface{}
+				if err = json.Unmarshal(rec.Body.Bytes(), &gotBody); err != nil {
+					t.Fatal(err)
+				}
+				if err = json.Unmarshal([]byte(tt.wantBody), &wantBody); err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+					t.Errorf("body (-want +got) =\n%s\n", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentController_Create(t *testing.T) {
+	tests := []struct {
+		name                    string
+		postID                  string
+		userID                  string
+		body                    string
+		prepareMockComment      func(comment *mock.MockComment)
+		prepareMockPost         func(post *mock.MockPost)
+		prepareMockUser         func(user *mock.MockUser)
+		prepareMockNotification func(notification *mock.MockNotification)
+		wantErr                 bool
+		wantCode                int
+		wantBody                string
+	}{
+		{
+			name:   "正しくコメントを作成できる",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"type": "highlight",
+				"content": "content1",
+				"first_line": 3,
+				"last_line": 5,
+				"created_at":"2021-03-23T11:42:56+09:00",
+				"updated_at":"2021-03-23T11:42:56+09:00"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(nil, entity.NewErrorNotFound("comment"))
+				comment.EXPECT().Insert(
+					gomock.Any(),
+					&entity.Comment{
+						UserID:       "user-id",
+						PostID:       1,
+						Type:         "highlight",
+						Content:      "content1",
+						FirstLine:    3,
+						LastLine:     5,
+						BaseRevision: 1,
+						CreatedAt:    "2021-03-23T11:42:56+09:00",
+						UpdatedAt:    "2021-03-23T11:42:56+09:00",
+					}).DoAndReturn(func(ctx context.Context, comment *entity.Comment) error {
+					comment.ID = 1
+					return nil
+				})
+			},
+			prepareMockPost: func(post *mock.MockPost) {
 				post.EXPECT().FindByID(gomock.Any(), 1).Return(
 					&entity.Post{
 						ID:        1,
@@ -378,55 +908,1370 @@ func TestCommentController_Create(t *testing.T) {
 				"post_id": 1,
 				"type": "highlight",
 				"content": "content1",
-				"first_line": 10,
-				"last_line": 12,
+				"first_line": 3,
+				"last_line": 5,
 				"code":"",
+				"base_revision": 1,
 				"created_at":"2021-03-23T11:42:56+09:00",
 				"updated_at":"2021-03-23T11:42:56+09:00"
 			}`,
 		},
 		{
-			name:   "Postのオーナー以外によるcommitならErrCannotCommit",
+			name:   "複数のファイルからなる投稿ではfilenameのファイルの行をhighlightできる",
 			postID: "1",
 			userID: "user-id",
 			body: `{
-				"type": "commit",
+				"type": "highlight",
+				"filename": "handler_test.go",
 				"content": "content1",
-				"code":"hello"
+				"first_line": 3,
+				"last_line": 3
 			}`,
 			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(nil, entity.NewErrorNotFound("comment"))
+				comment.EXPECT().Insert(
+					gomock.Any(),
+					&entity.Comment{
+						UserID:       "user-id",
+						PostID:       1,
+						Type:         "highlight",
+						Filename:     "handler_test.go",
+						Content:      "content1",
+						FirstLine:    3,
+						LastLine:     3,
+						BaseRevision: 1,
+					}).DoAndReturn(func(ctx context.Context, comment *entity.Comment) error {
+					comment.ID = 1
+					return nil
+				})
 			},
 			prepareMockPost: func(post *mock.MockPost) {
 				post.EXPECT().FindByID(gomock.Any(), 1).Return(
 					&entity.Post{
-						ID:        1,
-						UserID:    "other-user-id",
-						Title:     "test title",
-						Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-						Language:  "Go",
-						Content:   "Test code",
-						Source:    "github.com",
-						CreatedAt: "2021-03-23T11:42:56+09:00",
-						UpdatedAt: "2021-03-23T11:42:56+09:00",
+						ID:       1,
+						UserID:   "user-id",
+						Code:     "package handler",
+						Language: "go",
+						Files: []*entity.PostFile{
+							{Filename: "handler.go", Language: "go", Content: "package handler"},
+							{Filename: "handler_test.go", Language: "go", Content: "package handler_test\n\nfunc TestHandler() {}"},
+						},
 					}, nil)
 			},
-			wantErr:  true,
-			wantCode: http.StatusForbidden,
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id": "user-id",
+				"post_id": 1,
+				"type": "highlight",
+				"filename": "handler_test.go",
+				"content": "content1",
+				"first_line": 3,
+				"last_line": 3,
+				"code":"",
+				"base_revision": 1,
+				"created_at":"",
+				"updated_at":""
+			}`,
 		},
 		{
-			name:   "存在しないPostIDならErrNotFound",
-			postID: "100",
+			name:   "複数のファイルからなる投稿でfilenameを省略したcommitは先頭のファイルを指す",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"type": "commit",
+				"code": "package handler\n\nfunc Handler() {}"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(nil, entity.NewErrorNotFound("comment"))
+				comment.EXPECT().Insert(
+					gomock.Any(),
+					&entity.Comment{
+						UserID:   "user-id",
+						PostID:   1,
+						Type:     "commit",
+						Filename: "handler.go",
+						Code:     "package handler\n\nfunc Handler() {}",
+					}).DoAndReturn(func(ctx context.Context, comment *entity.Comment) error {
+					comment.ID = 1
+					comment.Revision = 2
+					return nil
+				})
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:       1,
+						UserID:   "user-id",
+						Code:     "package handler",
+						Language: "go",
+						Files: []*entity.PostFile{
+							{Filename: "handler.go", Language: "go", Content: "package handler"},
+							{Filename: "handler_test.go", Language: "go", Content: "package handler_test\n\nfunc TestHandler() {}"},
+						},
+					}, nil)
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id": "user-id",
+				"post_id": 1,
+				"type": "commit",
+				"filename": "handler.go",
+				"content": "",
+				"first_line": 0,
+				"last_line": 0,
+				"code":"package handler\n\nfunc Handler() {}",
+				"revision": 2,
+				"created_at":"",
+				"updated_at":""
+			}`,
+		},
+		{
+			name:   "存在しないファイルを指すならErrNotFound",
+			postID: "1",
 			userID: "user-id",
 			body: `{
 				"type": "highlight",
-				"content": "content1",
-				"first_line": 10,
-				"last_line": 12
+				"filename": "unknown.go",
+				"first_line": 1,
+				"last_line": 1
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(nil, entity.NewErrorNotFound("comment"))
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:       1,
+						UserID:   "user-id",
+						Code:     "package handler",
+						Language: "go",
+						Files: []*entity.PostFile{
+							{Filename: "handler.go", Language: "go", Content: "package handler"},
+							{Filename: "handler_test.go", Language: "go", Content: "package handler_test\n\nfunc TestHandler() {}"},
+						},
+					}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "投稿のオーナー以外もsuggestionを作成できる",
+			postID: "1",
+			userID: "reviewer-id",
+			body: `{
+				"type": "suggestion",
+				"content": "typo",
+				"first_line": 2,
+				"last_line": 2,
+				"code": "fixed",
+				"created_at":"2021-03-23T11:42:56+09:00",
+				"updated_at":"2021-03-23T11:42:56+09:00"
 			}`,
 			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(nil, entity.NewErrorNotFound("comment"))
+				comment.EXPECT().Insert(
+					gomock.Any(),
+					&entity.Comment{
+						UserID:       "reviewer-id",
+						PostID:       1,
+						Type:         "suggestion",
+						Content:      "typo",
+						FirstLine:    2,
+						LastLine:     2,
+						Code:         "fixed",
+						BaseRevision: 1,
+						CreatedAt:    "2021-03-23T11:42:56+09:00",
+						UpdatedAt:    "2021-03-23T11:42:56+09:00",
+					}).DoAndReturn(func(ctx context.Context, comment *entity.Comment) error {
+					comment.ID = 1
+					return nil
+				})
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "user-id", Code: "a\nb\nc"}, nil)
+			},
+			prepareMockNotification: func(notification *mock.MockNotification) {
+				notification.EXPECT().Insert(gomock.Any(), []*entity.Notification{
+					{UserID: "user-id", ActorID: "reviewer-id", Type: entity.NotificationComment, PostID: 1, CommentID: 1},
+				}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id": "reviewer-id",
+				"post_id": 1,
+				"type": "suggestion",
+				"content": "typo",
+				"first_line": 2,
+				"last_line": 2,
+				"code": "fixed",
+				"base_revision": 1,
+				"created_at":"2021-03-23T11:42:56+09:00",
+				"updated_at":"2021-03-23T11:42:56+09:00"
+			}`,
+		},
+		{
+			name:   "アーカイブされた投稿にはコメントできない",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"type": "none",
+				"content": "content1"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:     1,
+						UserID: "user-id",
+						Code:   "code",
+						Status: entity.PostStatusArchived,
+					}, nil)
+			},
+			wantErr:  true,
+			wantCode: 403,
+			wantBody: "",
+		},
+		{
+			name:   "コードの行数を超えたhighlightならBadRequest",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"type": "highlight",
+				"content": "content1",
+				"first_line": 3,
+				"last_line": 6
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(nil, entity.NewErrorNotFound("comment"))
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:     1,
+						UserID: "user-id",
+						Code:   "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+					}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusBadRequest,
+			wantBody: "",
+		},
+		{
+			name:   "指定したリビジョンのコードに対して範囲を検証する",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"type": "highlight",
+				"content": "content1",
+				"first_line": 1,
+				"last_line": 1,
+				"first_column": 9,
+				"last_column": 12,
+				"base_revision": 2
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return([]*entity.Comment{
+					{ID: 1, UserID: "user-id", PostID: 1, Type: "commit", Code: "package main", Revision: 2},
+					{ID: 2, UserID: "user-id", PostID: 1, Type: "commit", Code: "x", Revision: 3},
+				}, nil)
+				comment.EXPECT().Insert(
+					gomock.Any(),
+					&entity.Comment{
+						UserID:       "user-id",
+						PostID:       1,
+						Type:         "highlight",
+						Content:      "content1",
+						FirstLine:    1,
+						LastLine:     1,
+						FirstColumn:  9,
+						LastColumn:   12,
+						BaseRevision: 2,
+					}).DoAndReturn(func(ctx context.Context, comment *entity.Comment) error {
+					comment.ID = 3
+					return nil
+				})
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{ID: 1, UserID: "user-id", Code: "a"}, nil)
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 3,
+				"user_id": "user-id",
+				"post_id": 1,
+				"type": "highlight",
+				"content": "content1",
+				"first_line": 1,
+				"last_line": 1,
+				"first_column": 9,
+				"last_column": 12,
+				"code":"",
+				"base_revision": 2,
+				"created_at":"",
+				"updated_at":""
+			}`,
+		},
+		{
+			name:   "Postのオーナー以外によるcommitならErrCannotCommit",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"type": "commit",
+				"content": "content1",
+				"code":"hello"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:        1,
+						UserID:    "other-user-id",
+						Title:     "test title",
+						Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+						Language:  "Go",
+						Content:   "Test code",
+						Source:    "github.com",
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:   "コメントへの返信を作成できる",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"parent_id": 1,
+				"type": "none",
+				"content": "reply"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(
+					&entity.Comment{
+						ID:      1,
+						UserID:  "other-user-id",
+						PostID:  1,
+						Type:    "none",
+						Content: "parent",
+					}, nil)
+				comment.EXPECT().Insert(
+					gomock.Any(),
+					&entity.Comment{
+						UserID:   "user-id",
+						PostID:   1,
+						ParentID: 1,
+						Type:     "none",
+						Content:  "reply",
+					}).DoAndReturn(func(ctx context.Context, comment *entity.Comment) error {
+					comment.ID = 2
+					return nil
+				})
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:        1,
+						UserID:    "user-id",
+						Title:     "test title",
+						Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+						Language:  "Go",
+						Content:   "Test code",
+						Source:    "github.com",
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					}, nil)
+			},
+			prepareMockNotification: func(notification *mock.MockNotification) {
+				notification.EXPECT().Insert(gomock.Any(), []*entity.Notification{
+					{UserID: "other-user-id", ActorID: "user-id", Type: entity.NotificationReply, PostID: 1, CommentID: 2},
+				}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 2,
+				"user_id": "user-id",
+				"post_id": 1,
+				"parent_id": 1,
+				"type": "none",
+				"content": "reply",
+				"first_line": 0,
+				"last_line": 0,
+				"code":"",
+				"created_at":"",
+				"updated_at":""
+			}`,
+		},
+		{
+			name:   "メンションを解決して投稿のオーナーとメンションされたユーザに通知する",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"type": "none",
+				"content": "@other-user-id @unknown-user-id please review"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().Insert(
+					gomock.Any(),
+					&entity.Comment{
+						UserID:   "user-id",
+						PostID:   1,
+						Type:     "none",
+						Content:  "@other-user-id @unknown-user-id please review",
+						Mentions: []*entity.Mention{{UserID: "other-user-id", Name: "other"}},
+					}).DoAndReturn(func(ctx context.Context, comment *entity.Comment) error {
+					comment.ID = 3
+					return nil
+				})
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{ID: 1, UserID: "owner-id", Code: "a"}, nil)
+			},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "other-user-id").Return(&entity.User{ID: "other-user-id", Name: "other"}, nil)
+				user.EXPECT().FindByID(gomock.Any(), "unknown-user-id").Return(nil, entity.ErrUserNotFound)
+			},
+			prepareMockNotification: func(notification *mock.MockNotification) {
+				notification.EXPECT().Insert(gomock.Any(), []*entity.Notification{
+					{UserID: "other-user-id", ActorID: "user-id", Type: entity.NotificationMention, PostID: 1, CommentID: 3},
+					{UserID: "owner-id", ActorID: "user-id", Type: entity.NotificationComment, PostID: 1, CommentID: 3},
+				}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 3,
+				"user_id": "user-id",
+				"post_id": 1,
+				"type": "none",
+				"content": "@other-user-id @unknown-user-id please review",
+				"mentions": [{"user_id": "other-user-id", "name": "other"}],
+				"first_line": 0,
+				"last_line": 0,
+				"code":"",
+				"created_at":"",
+				"updated_at":""
+			}`,
+		},
+		{
+			name:   "非公開の投稿では閲覧できないユーザにメンションを通知しない",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"type": "none",
+				"content": "@other-user-id @stranger-id please review"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().Insert(
+					gomock.Any(),
+					&entity.Comment{
+						UserID:  "user-id",
+						PostID:  1,
+						Type:    "none",
+						Content: "@other-user-id @stranger-id please review",
+						Mentions: []*entity.Mention{
+							{UserID: "other-user-id", Name: "other"},
+							{UserID: "stranger-id", Name: "stranger"},
+						},
+					}).DoAndReturn(func(ctx context.Context, comment *entity.Comment) error {
+					comment.ID = 3
+					return nil
+				})
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{ID: 1, UserID: "owner-id", Code: "a", Visibility: entity.PostVisibilityPrivate}, nil)
+				post.EXPECT().IsInvited(gomock.Any(), 1, "user-id").Return(true, nil)
+				post.EXPECT().IsInvited(gomock.Any(), 1, "other-user-id").Return(true, nil)
+				post.EXPECT().IsInvited(gomock.Any(), 1, "stranger-id").Return(false, nil)
+			},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "other-user-id").Return(&entity.User{ID: "other-user-id", Name: "other"}, nil)
+				user.EXPECT().FindByID(gomock.Any(), "stranger-id").Return(&entity.User{ID: "stranger-id", Name: "stranger"}, nil)
+			},
+			prepareMockNotification: func(notification *mock.MockNotification) {
+				notification.EXPECT().Insert(gomock.Any(), []*entity.Notification{
+					{UserID: "other-user-id", ActorID: "user-id", Type: entity.NotificationMention, PostID: 1, CommentID: 3},
+					{UserID: "owner-id", ActorID: "user-id", Type: entity.NotificationComment, PostID: 1, CommentID: 3},
+				}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 3,
+				"user_id": "user-id",
+				"post_id": 1,
+				"type": "none",
+				"content": "@other-user-id @stranger-id please review",
+				"mentions": [{"user_id": "other-user-id", "name": "other"}, {"user_id": "stranger-id", "name": "stranger"}],
+				"first_line": 0,
+				"last_line": 0,
+				"code":"",
+				"created_at":"",
+				"updated_at":""
+			}`,
+		},
+		{
+			name:   "返信先のコメントが存在しないならErrNotFound",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"parent_id": 100,
+				"type": "none",
+				"content": "reply"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 100).Return(nil, entity.NewErrorNotFound("comment"))
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:        1,
+						UserID:    "user-id",
+						Title:     "test title",
+						Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+						Language:  "Go",
+						Content:   "Test code",
+						Source:    "github.com",
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "返信先のコメントが削除済みならBadRequest",
+			postID: "1",
+			userID: "user-id",
+			body: `{
+				"parent_id": 1,
+				"type": "none",
+				"content": "reply"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(
+					&entity.Comment{
+						ID:      1,
+						UserID:  "other-user-id",
+						PostID:  1,
+						Type:    "none",
+						Deleted: true,
+					}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:        1,
+						UserID:    "user-id",
+						Title:     "test title",
+						Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+						Language:  "Go",
+						Content:   "Test code",
+						Source:    "github.com",
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:   "存在しないPostIDならErrNotFound",
+			postID: "100",
+			userID: "user-id",
+			body: `{
+				"type": "highlight",
+				"content": "content1",
+				"first_line": 10,
+				"last_line": 12
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 100).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+			c.Set("userID", tt.userID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			commentRepo := mock.NewMockComment(ctrl)
+			tt.prepareMockComment(commentRepo)
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			if tt.prepareMockUser != nil {
+				tt.prepareMockUser(userRepo)
+			}
+			notificationRepo := mock.NewMockNotification(ctrl)
+			if tt.prepareMockNotification != nil {
+				tt.prepareMockNotification(notificationRepo)
+			}
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			commentBroker.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
+			err := con.Create(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if !tt.wantErr {
+				var gotBody, wantBody map[string]interface{}
+				if err = json.Unmarshal(rec.Body.Bytes(), &gotBody); err != nil {
+					t.Fatal(err)
+				}
+				if err = json.Unmarshal([]byte(tt.wantBody), &wantBody); err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+					t.Errorf("body (-want +got) =\n%s\n", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentController_Update(t *testing.T) {
+	tests := []struct {
+		name               string
+		postID             string
+		userID             string
+		commentID          string
+		body               string
+		prepareMockComment func(comment *mock.MockComment)
+		prepareMockPost    func(post *mock.MockPost)
+		prepareMockUser    func(user *mock.MockUser)
+		wantErr            bool
+		wantCode           int
+	}{
+		{
+			name:      "正しくコメントを更新できる",
+			postID:    "1",
+			userID:    "user-id",
+			commentID: "1",
+			body: `{
+				"type": "highlight",
+				"content": "content1",
+				"first_line": 3,
+				"last_line": 5
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(&entity.Comment{
+					ID:           1,
+					UserID:       "user-id",
+					PostID:       1,
+					Type:         "highlight",
+					FirstLine:    1,
+					LastLine:     1,
+					BaseRevision: 1,
+				}, nil)
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(nil, entity.NewErrorNotFound("comment"))
+				comment.EXPECT().Update(
+					gomock.Any(),
+					&entity.Comment{
+						ID:           1,
+						UserID:       "user-id",
+						PostID:       1,
+						Type:         "highlight",
+						Content:      "content1",
+						FirstLine:    3,
+						LastLine:     5,
+						BaseRevision: 1,
+					}).Return(nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:        1,
+						UserID:    "user-id",
+						Title:     "test title",
+						Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+						Language:  "Go",
+						Content:   "Test code",
+						Source:    "github.com",
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					}, nil)
+			},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "user-id").Return(nil, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "範囲が逆転したhighlightならBadRequest",
+			postID:    "1",
+			userID:    "user-id",
+			commentID: "1",
+			body: `{
+				"type": "highlight",
+				"content": "content1",
+				"first_line": 5,
+				"last_line": 3,
+				"base_revision": 1
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(nil, entity.NewErrorNotFound("comment"))
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:     1,
+						UserID: "user-id",
+						Code:   "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+					}, nil)
+			},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "user-id").Return(nil, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "Postのオーナー以外によるcommitならErrCannotCommit",
+			postID:    "1",
+			userID:    "user-id200",
+			commentID: "1",
+			body: `{
+				"type": "commit",
+				"content": "content1",
+				"code":"hello"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:        1,
+						UserID:    "other-user-id",
+						Title:     "test title",
+						Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+						Language:  "Go",
+						Content:   "Test code",
+						Source:    "github.com",
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					}, nil)
+			},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "user-id200").Return(nil, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:      "招待が取り消された非公開の投稿のコメントは更新できずErrNotFound",
+			postID:    "1",
+			userID:    "user-id",
+			commentID: "1",
+			body: `{
+				"type": "none",
+				"content": "@owner-id updated"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:         1,
+						UserID:     "owner-id",
+						Code:       "package main",
+						Language:   "go",
+						Visibility: entity.PostVisibilityPrivate,
+					}, nil)
+				post.EXPECT().IsInvited(gomock.Any(), 1, "user-id").Return(false, nil)
+			},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "user-id").Return(nil, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:      "commitのコードを変えるならErrCommitImmutableでConflict",
+			postID:    "1",
+			userID:    "user-id",
+			commentID: "1",
+			body: `{
+				"type": "commit",
+				"content": "content1",
+				"code":"hello"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return(nil, entity.NewErrorNotFound("comment"))
+				comment.EXPECT().Update(gomock.Any(), gomock.Any()).Return(entity.ErrCommitImmutable)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:       1,
+						UserID:   "user-id",
+						Code:     "package main",
+						Language: "go",
+					}, nil)
+			},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "user-id").Return(nil, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:      "適用済みのsuggestionを変えるならErrAppliedSuggestionImmutableでConflict",
+			postID:    "1",
+			userID:    "user-id",
+			commentID: "1",
+			body: `{
+				"type": "none",
+				"content": "content1"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().Update(gomock.Any(), gomock.Any()).Return(entity.ErrAppliedSuggestionImmutable)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(
+					&entity.Post{
+						ID:       1,
+						UserID:   "user-id",
+						Code:     "package main",
+						Language: "go",
+					}, nil)
+			},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "user-id").Return(nil, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:      "存在しないユーザによるcommitならErrNotFound",
+			postID:    "1",
+			userID:    "other-user-id",
+			commentID: "1",
+			body: `{
+				"type": "commit",
+				"content": "content1",
+				"code":"hello"
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+			},
+			prepareMockPost: func(post *mock.MockPost) {},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "other-user-id").Return(nil, entity.NewErrorNotFound("user"))
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:      "存在しないPostIDならErrNotFound",
+			postID:    "100",
+			userID:    "user-id",
+			commentID: "1",
+			body: `{
+				"type": "highlight",
+				"content": "content1",
+				"first_line": 10,
+				"last_line": 12
+			}`,
+			prepareMockComment: func(comment *mock.MockComment) {
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 100).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			prepareMockUser: func(user *mock.MockUser) {
+				user.EXPECT().FindByID(gomock.Any(), "user-id").Return(nil, nil)
+			},
+			wantErr:  true,
+			wantCode: 404,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("PUT", "/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID", "commentID")
+			c.SetParamValues(tt.postID, tt.commentID)
+			c.Set("userID", tt.userID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			commentRepo := mock.NewMockComment(ctrl)
+			tt.prepareMockComment(commentRepo)
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			tt.prepareMockUser(userRepo)
+
+			notificationRepo := mock.NewMockNotification(ctrl)
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			commentBroker.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
+			err := con.Update(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentController_Delete(t *testing.T) {
+	tests := []struct {
+		name               string
+		postID             string
+		commentID          string
+		userID             string
+		prepareMockComment func(comment *mock.MockComment)
+		wantErr            bool
+		wantCode           int
+	}{
+		{
+			name:      "正しくコメントを削除できる",
+			postID:    "1",
+			commentID: "1",
+			userID:    "user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().Delete(
+					gomock.Any(),
+					&entity.Comment{
+						ID:     1,
+						PostID: 1,
+						UserID: "user-id",
+					}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "存在しないCommentならErrNotFound",
+			postID:    "100",
+			commentID: "1",
+			userID:    "user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().Delete(
+					gomock.Any(),
+					&entity.Comment{
+						ID:     1,
+						PostID: 100,
+						UserID: "user-id",
+					}).Return(entity.NewErrorNotFound("comment"))
+			},
+			wantErr:  true,
+			wantCode: 404,
+		},
+		{
+			name:      "ユーザーに削除権限がないならForbidden",
+			postID:    "1",
+			commentID: "1",
+			userID:    "other-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().Delete(
+					gomock.Any(),
+					&entity.Comment{
+						ID:     1,
+						PostID: 1,
+						UserID: "other-user-id",
+					}).Return(entity.ErrIsNotAuthor)
+			},
+			wantErr:  true,
+			wantCode: 403,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID", "commentID")
+			c.SetParamValues(tt.postID, tt.commentID)
+			c.Set("userID", tt.userID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			commentRepo := mock.NewMockComment(ctrl)
+			tt.prepareMockComment(commentRepo)
+			postRepo := mock.NewMockPost(ctrl)
+			userRepo := mock.NewMockUser(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			commentBroker.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
+			err := con.Delete(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentController_Resolve(t *testing.T) {
+	tests := []struct {
+		name               string
+		method             string
+		commentID          string
+		userID             string
+		prepareMockComment func(comment *mock.MockComment)
+		prepareMockPost    func(post *mock.MockPost)
+		wantErr            bool
+		wantCode           int
+	}{
+		{
+			name:      "コメントした人はスレッドを解決済みにできる",
+			method:    http.MethodPut,
+			commentID: "1",
+			userID:    "comment-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(&entity.Comment{ID: 1, PostID: 1, UserID: "comment-user-id"}, nil).Times(2)
+				comment.EXPECT().Resolve(gomock.Any(), 1, 1, "comment-user-id").Return(nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "投稿のオーナーはスレッドを未解決に戻せる",
+			method:    http.MethodDelete,
+			commentID: "1",
+			userID:    "post-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(&entity.Comment{ID: 1, PostID: 1, UserID: "comment-user-id"}, nil).Times(2)
+				comment.EXPECT().Reopen(gomock.Any(), 1, 1).Return(nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:      "投稿のオーナーでもコメントした人でもなければForbidden",
+			method:    http.MethodPut,
+			commentID: "1",
+			userID:    "other-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(&entity.Comment{ID: 1, PostID: 1, UserID: "comment-user-id"}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:      "返信は解決済みにできない",
+			method:    http.MethodPut,
+			commentID: "2",
+			userID:    "comment-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 2).Return(&entity.Comment{ID: 2, PostID: 1, ParentID: 1, UserID: "comment-user-id"}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "存在しないコメントならNotFound",
+			method:    http.MethodPut,
+			commentID: "100",
+			userID:    "comment-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 100).Return(nil, entity.NewErrorNotFound("comment"))
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:      "削除されたコメントならNotFound",
+			method:    http.MethodPut,
+			commentID: "1",
+			userID:    "comment-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(&entity.Comment{ID: 1, PostID: 1, UserID: "comment-user-id", Deleted: true}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:               "閲覧できなくなった非公開の投稿のコメントならNotFound",
+			method:             http.MethodPut,
+			commentID:          "1",
+			userID:             "comment-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", Visibility: entity.PostVisibilityPrivate}, nil)
+				post.EXPECT().IsInvited(gomock.Any(), 1, "comment-user-id").Return(false, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID", "commentID")
+			c.SetParamValues("1", tt.commentID)
+			c.Set("userID", tt.userID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			commentRepo := mock.NewMockComment(ctrl)
+			tt.prepareMockComment(commentRepo)
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			commentBroker.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
+
+			var err error
+			if tt.method == http.MethodPut {
+				err = con.Resolve(c)
+			} else {
+				err = con.Reopen(c)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentController_ApplySuggestion(t *testing.T) {
+	suggestion := &entity.Comment{
+		ID:           2,
+		UserID:       "reviewer-id",
+		PostID:       1,
+		Type:         "suggestion",
+		FirstLine:    2,
+		LastLine:     2,
+		Code:         "B",
+		BaseRevision: 1,
+	}
+	tests := []struct {
+		name               string
+		commentID          string
+		userID             string
+		prepareMockComment func(comment *mock.MockComment)
+		prepareMockPost    func(post *mock.MockPost)
+		wantErr            bool
+		wantCode           int
+		wantBody           string
+	}{
+		{
+			name:      "投稿のオーナーはsuggestionを適用してcommitを作成できる",
+			commentID: "2",
+			userID:    "post-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 2).Return(suggestion, nil).Times(2)
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return([]*entity.Comment{suggestion}, nil)
+				comment.EXPECT().ApplySuggestion(gomock.Any(), suggestion, &entity.Comment{
+					UserID:   "post-user-id",
+					PostID:   1,
+					ParentID: 2,
+					Type:     "commit",
+					Code:     "a\nB\nc",
+					AuthorID: "reviewer-id",
+				}).DoAndReturn(func(ctx context.Context, suggestion, commit *entity.Comment) error {
+					commit.ID = 3
+					commit.Revision = 2
+					return nil
+				})
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", Code: "a\nb\nc"}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusCreated,
+			wantBody: `{
+				"id": 3,
+				"user_id": "post-user-id",
+				"post_id": 1,
+				"parent_id": 2,
+				"type": "commit",
+				"content": "",
+				"first_line": 0,
+				"last_line": 0,
+				"code": "a\nB\nc",
+				"revision": 2,
+				"author_id": "reviewer-id",
+				"created_at": "",
+				"updated_at": ""
+			}`,
+		},
+		{
+			name:               "投稿のオーナーでなければForbidden",
+			commentID:          "2",
+			userID:             "reviewer-id",
+			prepareMockComment: func(comment *mock.MockComment) {},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", Code: "a\nb\nc"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:      "suggestionでなければBadRequest",
+			commentID: "3",
+			userID:    "post-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 3).Return(&entity.Comment{ID: 3, PostID: 1, Type: "none", Content: "LGTM"}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", Code: "a\nb\nc"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:      "適用済みのsuggestionならConflict",
+			commentID: "2",
+			userID:    "post-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 2).Return(&entity.Comment{ID: 2, PostID: 1, Type: "suggestion", FirstLine: 2, LastLine: 2, AppliedRevision: 2}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", Code: "a\nb\nc"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:      "提案した行が書き換えられていればConflict",
+			commentID: "2",
+			userID:    "post-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 2).Return(suggestion, nil)
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return([]*entity.Comment{
+					suggestion,
+					{ID: 3, PostID: 1, UserID: "post-user-id", Type: "commit", Code: "a\nbb\nc", Revision: 2},
+				}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", Code: "a\nb\nc"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:      "適用するとコードが空になるならConflict",
+			commentID: "4",
+			userID:    "post-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				deleteAll := &entity.Comment{ID: 4, PostID: 1, UserID: "comment-user-id", Type: "suggestion", FirstLine: 1, LastLine: 3, BaseRevision: 1}
+				comment.EXPECT().FindByID(gomock.Any(), 1, 4).Return(deleteAll, nil)
+				comment.EXPECT().FindByPostID(gomock.Any(), 1).Return([]*entity.Comment{deleteAll}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", Code: "a\nb\nc"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:      "存在しないコメントならNotFound",
+			commentID: "100",
+			userID:    "post-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 100).Return(nil, entity.NewErrorNotFound("comment"))
 			},
 			prepareMockPost: func(post *mock.MockPost) {
-				post.EXPECT().FindByID(gomock.Any(), 100).Return(nil, entity.NewErrorNotFound("post"))
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", Code: "a\nb\nc"}, nil)
 			},
 			wantErr:  true,
 			wantCode: http.StatusNotFound,
@@ -435,24 +2280,29 @@ func TestCommentController_Create(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			c.SetParamNames("postID")
-			c.SetParamValues(tt.postID)
+			c.SetParamNames("postID", "commentID")
+			c.SetParamValues("1", tt.commentID)
 			c.Set("userID", tt.userID)
 
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
+
 			commentRepo := mock.NewMockComment(ctrl)
 			tt.prepareMockComment(commentRepo)
 			postRepo := mock.NewMockPost(ctrl)
 			tt.prepareMockPost(postRepo)
 			userRepo := mock.NewMockUser(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
+			notificationRepo.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			commentBroker.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
 
-			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo))
-			err := con.Create(c)
+			err := con.ApplySuggestion(c)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
@@ -485,146 +2335,106 @@ func TestCommentController_Create(t *testing.T) {
 	}
 }
 
-func TestCommentController_Update(t *testing.T) {
+func TestCommentController_Accept(t *testing.T) {
 	tests := []struct {
 		name               string
-		postID             string
-		userID             string
+		method             string
 		commentID          string
-		body               string
+		userID             string
 		prepareMockComment func(comment *mock.MockComment)
 		prepareMockPost    func(post *mock.MockPost)
-		prepareMockUser    func(user *mock.MockUser)
 		wantErr            bool
 		wantCode           int
 	}{
 		{
-			name:      "正しくコメントを更新できる",
-			postID:    "1",
-			userID:    "user-id",
-			commentID: "1",
-			body: `{
-				"type": "highlight",
-				"content": "content1",
-				"first_line": 10,
-				"last_line": 12
-			}`,
+			name:      "投稿のオーナーはコメントを回答として採用できる",
+			method:    http.MethodPut,
+			commentID: "2",
+			userID:    "post-user-id",
 			prepareMockComment: func(comment *mock.MockComment) {
-				comment.EXPECT().Update(
-					gomock.Any(),
-					&entity.Comment{
-						ID:        1,
-						UserID:    "user-id",
-						PostID:    1,
-						Type:      "highlight",
-						Content:   "content1",
-						FirstLine: 10,
-						LastLine:  12,
-					}).Return(nil)
+				comment.EXPECT().FindByID(gomock.Any(), 1, 2).Return(&entity.Comment{ID: 2, PostID: 1, UserID: "comment-user-id"}, nil)
 			},
 			prepareMockPost: func(post *mock.MockPost) {
-				post.EXPECT().FindByID(gomock.Any(), 1).Return(
-					&entity.Post{
-						ID:        1,
-						UserID:    "user-id",
-						Title:     "test title",
-						Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-						Language:  "Go",
-						Content:   "Test code",
-						Source:    "github.com",
-						CreatedAt: "2021-03-23T11:42:56+09:00",
-						UpdatedAt: "2021-03-23T11:42:56+09:00",
-					}, nil)
-			},
-			prepareMockUser: func(user *mock.MockUser) {
-				user.EXPECT().FindByID(gomock.Any(), "user-id").Return(nil, nil)
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
+				post.EXPECT().UpdateAcceptedComment(gomock.Any(), 1, 2).Return(nil)
 			},
 			wantErr:  false,
 			wantCode: http.StatusOK,
 		},
 		{
-			name:      "Postのオーナー以外によるcommitならErrCannotCommit",
-			postID:    "1",
-			userID:    "user-id200",
-			commentID: "1",
-			body: `{
-				"type": "commit",
-				"content": "content1",
-				"code":"hello"
-			}`,
-			prepareMockComment: func(comment *mock.MockComment) {
+			name:               "投稿のオーナーは採用を取り消せる",
+			method:             http.MethodDelete,
+			commentID:          "2",
+			userID:             "post-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", AcceptedCommentID: 2}, nil)
+				post.EXPECT().UpdateAcceptedComment(gomock.Any(), 1, 0).Return(nil)
 			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:               "採用していないコメントの取り消しは何もしない",
+			method:             http.MethodDelete,
+			commentID:          "3",
+			userID:             "post-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {},
 			prepareMockPost: func(post *mock.MockPost) {
-				post.EXPECT().FindByID(gomock.Any(), 1).Return(
-					&entity.Post{
-						ID:        1,
-						UserID:    "other-user-id",
-						Title:     "test title",
-						Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-						Language:  "Go",
-						Content:   "Test code",
-						Source:    "github.com",
-						CreatedAt: "2021-03-23T11:42:56+09:00",
-						UpdatedAt: "2021-03-23T11:42:56+09:00",
-					}, nil)
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id", AcceptedCommentID: 2}, nil)
 			},
-			prepareMockUser: func(user *mock.MockUser) {
-				user.EXPECT().FindByID(gomock.Any(), "user-id200").Return(nil, nil)
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:               "投稿のオーナーでなければForbidden",
+			method:             http.MethodPut,
+			commentID:          "2",
+			userID:             "comment-user-id",
+			prepareMockComment: func(comment *mock.MockComment) {},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
 			},
 			wantErr:  true,
 			wantCode: http.StatusForbidden,
 		},
 		{
-			name:      "存在しないユーザによるcommitならErrNotFound",
-			postID:    "1",
-			userID:    "other-user-id",
-			commentID: "1",
-			body: `{
-				"type": "commit",
-				"content": "content1",
-				"code":"hello"
-			}`,
+			name:      "墓標になったコメントは採用できない",
+			method:    http.MethodPut,
+			commentID: "2",
+			userID:    "post-user-id",
 			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 2).Return(&entity.Comment{ID: 2, PostID: 1, Deleted: true}, nil)
 			},
-			prepareMockPost: func(post *mock.MockPost) {},
-			prepareMockUser: func(user *mock.MockUser) {
-				user.EXPECT().FindByID(gomock.Any(), "other-user-id").Return(nil, entity.NewErrorNotFound("user"))
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
 			},
 			wantErr:  true,
 			wantCode: http.StatusNotFound,
 		},
 		{
-			name:      "存在しないPostIDならErrNotFound",
-			postID:    "100",
-			userID:    "user-id",
-			commentID: "1",
-			body: `{
-				"type": "highlight",
-				"content": "content1",
-				"first_line": 10,
-				"last_line": 12
-			}`,
+			name:      "存在しないコメントならNotFound",
+			method:    http.MethodPut,
+			commentID: "100",
+			userID:    "post-user-id",
 			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 100).Return(nil, entity.NewErrorNotFound("comment"))
 			},
 			prepareMockPost: func(post *mock.MockPost) {
-				post.EXPECT().FindByID(gomock.Any(), 100).Return(nil, entity.NewErrorNotFound("post"))
-			},
-			prepareMockUser: func(user *mock.MockUser) {
-				user.EXPECT().FindByID(gomock.Any(), "user-id").Return(nil, nil)
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "post-user-id"}, nil)
 			},
 			wantErr:  true,
-			wantCode: 404,
+			wantCode: http.StatusNotFound,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest("PUT", "/", strings.NewReader(tt.body))
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req := httptest.NewRequest(tt.method, "/", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("postID", "commentID")
-			c.SetParamValues(tt.postID, tt.commentID)
+			c.SetParamValues("1", tt.commentID)
 			c.Set("userID", tt.userID)
 
 			ctrl := gomock.NewController(t)
@@ -635,10 +2445,17 @@ func TestCommentController_Update(t *testing.T) {
 			postRepo := mock.NewMockPost(ctrl)
 			tt.prepareMockPost(postRepo)
 			userRepo := mock.NewMockUser(ctrl)
-			tt.prepareMockUser(userRepo)
+			notificationRepo := mock.NewMockNotification(ctrl)
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
 
-			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo))
-			err := con.Update(c)
+			var err error
+			if tt.method == http.MethodPut {
+				err = con.Accept(c)
+			} else {
+				err = con.Unaccept(c)
+			}
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
@@ -657,78 +2474,152 @@ func TestCommentController_Update(t *testing.T) {
 	}
 }
 
-func TestCommentController_Delete(t *testing.T) {
+func TestCommentController_React(t *testing.T) {
 	tests := []struct {
-		name               string
-		postID             string
-		commentID          string
-		userID             string
-		prepareMockComment func(comment *mock.MockComment)
-		wantErr            bool
-		wantCode           int
+		name                string
+		method              string
+		commentID           string
+		emoji               string
+		prepareMockComment  func(comment *mock.MockComment)
+		prepareMockPost     func(post *mock.MockPost)
+		prepareMockReaction func(reaction *mock.MockReaction)
+		wantErr             bool
+		wantCode            int
 	}{
 		{
-			name:      "正しくコメントを削除できる",
-			postID:    "1",
+			name:      "正しくリアクションをつけられる",
+			method:    http.MethodPut,
 			commentID: "1",
-			userID:    "user-id",
+			emoji:     "thumbsup",
 			prepareMockComment: func(comment *mock.MockComment) {
-				comment.EXPECT().Delete(
-					gomock.Any(),
-					&entity.Comment{
-						ID:     1,
-						PostID: 1,
-						UserID: "user-id",
-					}).Return(nil)
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(&entity.Comment{ID: 1, PostID: 1}, nil)
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner"}, nil)
+			},
+			prepareMockReaction: func(reaction *mock.MockReaction) {
+				reaction.EXPECT().Insert(gomock.Any(), &entity.Reaction{
+					UserID:    "user-id",
+					PostID:    1,
+					CommentID: 1,
+					Emoji:     "thumbsup",
+				}).Return(nil)
 			},
 			wantErr:  false,
 			wantCode: http.StatusOK,
 		},
 		{
-			name:      "存在しないCommentならErrNotFound",
-			postID:    "100",
+			name:      "正しくリアクションを外せる",
+			method:    http.MethodDelete,
 			commentID: "1",
-			userID:    "user-id",
+			emoji:     "thumbsup",
 			prepareMockComment: func(comment *mock.MockComment) {
-				comment.EXPECT().Delete(
-					gomock.Any(),
-					&entity.Comment{
-						ID:     1,
-						PostID: 100,
-						UserID: "user-id",
-					}).Return(entity.NewErrorNotFound("comment"))
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(&entity.Comment{ID: 1, PostID: 1}, nil)
 			},
-			wantErr:  true,
-			wantCode: 404,
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner"}, nil)
+			},
+			prepareMockReaction: func(reaction *mock.MockReaction) {
+				reaction.EXPECT().Delete(gomock.Any(), &entity.Reaction{
+					UserID:    "user-id",
+					PostID:    1,
+					CommentID: 1,
+					Emoji:     "thumbsup",
+				}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
 		},
 		{
-			name:      "ユーザーに削除権限がないならForbidden",
-			postID:    "1",
+			name:                "一覧にない絵文字ならBadRequest",
+			method:              http.MethodPut,
+			commentID:           "1",
+			emoji:               "pizza",
+			prepareMockComment:  func(comment *mock.MockComment) {},
+			prepareMockPost:     func(post *mock.MockPost) {},
+			prepareMockReaction: func(reaction *mock.MockReaction) {},
+			wantErr:             true,
+			wantCode:            http.StatusBadRequest,
+		},
+		{
+			name:      "墓標になったコメントにはリアクションをつけられない",
+			method:    http.MethodPut,
 			commentID: "1",
-			userID:    "other-user-id",
+			emoji:     "eyes",
 			prepareMockComment: func(comment *mock.MockComment) {
-				comment.EXPECT().Delete(
-					gomock.Any(),
-					&entity.Comment{
-						ID:     1,
-						PostID: 1,
-						UserID: "other-user-id",
-					}).Return(entity.ErrIsNotAuthor)
+				comment.EXPECT().FindByID(gomock.Any(), 1, 1).Return(&entity.Comment{ID: 1, PostID: 1, Deleted: true}, nil)
 			},
-			wantErr:  true,
-			wantCode: 403,
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner"}, nil)
+			},
+			prepareMockReaction: func(reaction *mock.MockReaction) {},
+			wantErr:             true,
+			wantCode:            http.StatusNotFound,
+		},
+		{
+			name:      "存在しないコメントならNotFound",
+			method:    http.MethodDelete,
+			commentID: "100",
+			emoji:     "eyes",
+			prepareMockComment: func(comment *mock.MockComment) {
+				comment.EXPECT().FindByID(gomock.Any(), 1, 100).Return(nil, entity.NewErrorNotFound("comment"))
+			},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner"}, nil)
+			},
+			prepareMockReaction: func(reaction *mock.MockReaction) {},
+			wantErr:             true,
+			wantCode:            http.StatusNotFound,
+		},
+		{
+			name:               "閲覧できない非公開の投稿のコメントにはリアクションをつけられない",
+			method:             http.MethodPut,
+			commentID:          "1",
+			emoji:              "eyes",
+			prepareMockComment: func(comment *mock.MockComment) {},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner", Visibility: entity.PostVisibilityPrivate}, nil)
+				post.EXPECT().IsInvited(gomock.Any(), 1, "user-id").Return(false, nil)
+			},
+			prepareMockReaction: func(reaction *mock.MockReaction) {},
+			wantErr:             true,
+			wantCode:            http.StatusNotFound,
+		},
+		{
+			name:               "閲覧できない非公開の投稿のコメントからはリアクションを外せない",
+			method:             http.MethodDelete,
+			commentID:          "1",
+			emoji:              "eyes",
+			prepareMockComment: func(comment *mock.MockComment) {},
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1, UserID: "owner", Visibility: entity.PostVisibilityPrivate}, nil)
+				post.EXPECT().IsInvited(gomock.Any(), 1, "user-id").Return(false, nil)
+			},
+			prepareMockReaction: func(reaction *mock.MockReaction) {},
+			wantErr:             true,
+			wantCode:            http.StatusNotFound,
+		},
+		{
+			name:                "commentIDが数値でないならBadRequest",
+			method:              http.MethodPut,
+			commentID:           "a",
+			emoji:               "eyes",
+			prepareMockComment:  func(comment *mock.MockComment) {},
+			prepareMockPost:     func(post *mock.MockPost) {},
+			prepareMockReaction: func(reaction *mock.MockReaction) {},
+			wantErr:             true,
+			wantCode:            http.StatusBadRequest,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest("DELETE", "/", nil)
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req := httptest.NewRequest(tt.method, "/", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			c.SetParamNames("postID", "commentID")
-			c.SetParamValues(tt.postID, tt.commentID)
-			c.Set("userID", tt.userID)
+			c.SetParamNames("postID", "commentID", "emoji")
+			c.SetParamValues("1", tt.commentID, tt.emoji)
+			c.Set("userID", "user-id")
 
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
@@ -736,9 +2627,111 @@ func TestCommentController_Delete(t *testing.T) {
 			commentRepo := mock.NewMockComment(ctrl)
 			tt.prepareMockComment(commentRepo)
 			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(postRepo)
 			userRepo := mock.NewMockUser(ctrl)
-			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo))
-			err := con.Delete(c)
+			notificationRepo := mock.NewMockNotification(ctrl)
+			reactionRepo := mock.NewMockReaction(ctrl)
+			tt.prepareMockReaction(reactionRepo)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
+
+			var err error
+			if tt.method == http.MethodPut {
+				err = con.React(c)
+			} else {
+				err = con.Unreact(c)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestCommentController_Stream(t *testing.T) {
+	tests := []struct {
+		name                     string
+		postID                   string
+		prepareMockPost          func(post *mock.MockPost)
+		prepareMockCommentBroker func(broker *mock.MockCommentBroker)
+		wantErr                  bool
+		wantCode                 int
+		wantBody                 string
+	}{
+		{
+			name:   "コメントの変更をServer-Sent Eventsで配信できる",
+			postID: "1",
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 1).Return(&entity.Post{ID: 1}, nil)
+			},
+			prepareMockCommentBroker: func(broker *mock.MockCommentBroker) {
+				events := make(chan *entity.CommentEvent, 2)
+				events <- entity.NewCommentEvent(entity.CommentEventCreated, &entity.Comment{ID: 1, UserID: "user-id", PostID: 1, Type: "none", Content: "hello"})
+				events <- entity.NewCommentEvent(entity.CommentEventDeleted, &entity.Comment{ID: 1, UserID: "user-id", PostID: 1})
+				close(events)
+				broker.EXPECT().Subscribe(gomock.Any(), 1).Return((<-chan *entity.CommentEvent)(events), nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: "event: created\n" +
+				`data: {"id":1,"user_id":"user-id","post_id":1,"type":"none","content":"hello","first_line":0,"last_line":0,"code":"","created_at":"","updated_at":""}` + "\n\n" +
+				"event: deleted\n" +
+				`data: {"id":1,"user_id":"user-id","post_id":1,"type":"","content":"","first_line":0,"last_line":0,"code":"","created_at":"","updated_at":""}` + "\n\n",
+		},
+		{
+			name:   "存在しない投稿ならNotFound",
+			postID: "100",
+			prepareMockPost: func(post *mock.MockPost) {
+				post.EXPECT().FindByID(gomock.Any(), 100).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			prepareMockCommentBroker: func(broker *mock.MockCommentBroker) {},
+			wantErr:                  true,
+			wantCode:                 http.StatusNotFound,
+			wantBody:                 "",
+		},
+		{
+			name:                     "postIDが数字でなければBadRequest",
+			postID:                   "abc",
+			prepareMockPost:          func(post *mock.MockPost) {},
+			prepareMockCommentBroker: func(broker *mock.MockCommentBroker) {},
+			wantErr:                  true,
+			wantCode:                 http.StatusBadRequest,
+			wantBody:                 "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			commentRepo := mock.NewMockComment(ctrl)
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
+			reactionRepo := mock.NewMockReaction(ctrl)
+			commentBroker := mock.NewMockCommentBroker(ctrl)
+			tt.prepareMockCommentBroker(commentBroker)
+
+			con := NewCommentController(usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker))
+			err := con.Stream(c)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
@@ -752,6 +2745,13 @@ func TestCommentController_Delete(t *testing.T) {
 				if rec.Code != tt.wantCode {
 					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
 				}
+				if got := rec.Header().Get(echo.HeaderContentType); got != "text/event-stream" {
+					t.Errorf("Content-Type = %s, want = text/event-stream", got)
+				}
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
 			}
 		})
 	}
@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/log"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+// FeedController は フィードに関するハンドラに対してHTTPリクエストとして
+// 送られたデータを入力として、ユースケースに伝えるまでを責務とするコントローラです
+type FeedController struct {
+	uc *usecase.FeedUseCase
+}
+
+// NewFeedController はFeedControllerのポインタを生成する関数です
+func NewFeedController(uc *usecase.FeedUseCase) *FeedController {
+	return &FeedController{uc: uc}
+}
+
+// Get は GET /feed のハンドラです
+func (ctrl *FeedController) Get(c echo.Context) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	var limit int
+	if limitStr := c.QueryParam("limit"); len(limitStr) > 0 {
+		var err error
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrInvalidPageLimit.Error())
+		}
+	}
+	cursor, err := service.DecodeFeedCursor(c.QueryParam("cursor"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	page, err := ctrl.uc.Get(c.Request().Context(), userID, cursor, limit)
+	if err != nil {
+		logger.Errorf("error GET /feed: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,128 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/infra/mock"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+func TestFeedController_Get(t *testing.T) {
+	post := &entity.Post{
+		ID:        1,
+		UserID:    "followee-id",
+		Title:     "title",
+		Code:      "code",
+		Language:  "go",
+		CreatedAt: "2021-03-23T11:42:56+09:00",
+		UpdatedAt: "2021-03-23T11:42:56+09:00",
+	}
+	comment := &entity.Comment{
+		ID:        2,
+		UserID:    "followee-id",
+		PostID:    1,
+		Type:      "commit",
+		Content:   "fix",
+		Code:      "fixed code",
+		Revision:  1,
+		CreatedAt: "2021-03-23T11:42:57+09:00",
+		UpdatedAt: "2021-03-23T11:42:57+09:00",
+	}
+	cursor := &entity.FeedCursor{CreatedAt: "2021-03-23T11:42:58+09:00", Type: entity.FeedItemPost, ID: 3}
+
+	tests := []struct {
+		name            string
+		query           string
+		prepareMockFeed func(ctx context.Context, feed *mock.MockFeed)
+		wantErr         bool
+		wantCode        int
+		wantBody        string
+	}{
+		{
+			name:  "フォローしているユーザの投稿とcommitコメントを新しい順に取得できる",
+			query: "limit=1&cursor=" + service.EncodeFeedCursor(cursor),
+			prepareMockFeed: func(ctx context.Context, feed *mock.MockFeed) {
+				feed.EXPECT().FindByFollowerID(ctx, "user-id", cursor, 2).Return([]*entity.FeedItem{
+					{Type: entity.FeedItemCommit, Post: post, Comment: comment, CreatedAt: comment.CreatedAt},
+					{Type: entity.FeedItemPost, Post: post, CreatedAt: post.CreatedAt},
+				}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"items":[{"type":"commit","post":{"id":1,"user_id":"followee-id","title":"title","code":"code","language":"go","content":"","source":"","star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"},"comment":{"id":2,"user_id":"followee-id","post_id":1,"type":"commit","content":"fix","first_line":0,"last_line":0,"code":"fixed code","revision":1,"created_at":"2021-03-23T11:42:57+09:00","updated_at":"2021-03-23T11:42:57+09:00"},"created_at":"2021-03-23T11:42:57+09:00"}],"next_cursor":"` +
+				service.EncodeFeedCursor(&entity.FeedCursor{CreatedAt: comment.CreatedAt, Type: entity.FeedItemCommit, ID: 2}) + `"}
+`,
+		},
+		{
+			name:  "誰もフォローしていなければ空のフィードを返す",
+			query: "",
+			prepareMockFeed: func(ctx context.Context, feed *mock.MockFeed) {
+				feed.EXPECT().FindByFollowerID(ctx, "user-id", nil, entity.DefaultPageLimit+1).Return([]*entity.FeedItem{}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"items":[],"next_cursor":""}
+`,
+		},
+		{
+			name:            "cursorが不正ならBadRequest",
+			query:           "cursor=!!!",
+			prepareMockFeed: func(ctx context.Context, feed *mock.MockFeed) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+			wantBody:        ``,
+		},
+		{
+			name:            "limitが正の整数でないならBadRequest",
+			query:           "limit=0",
+			prepareMockFeed: func(ctx context.Context, feed *mock.MockFeed) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+			wantBody:        ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("userID", "user-id")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			feedRepo := mock.NewMockFeed(ctrl)
+			tt.prepareMockFeed(ctx, feedRepo)
+
+			con := NewFeedController(usecase.NewFeedUseCase(feedRepo))
+			err := con.Get(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/log"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+// FollowController は ユーザのフォローに関するハンドラに対してHTTPリクエストとして
+// 送られたデータを入力として、ユースケースに伝えるまでを責務とするコントローラです
+type FollowController struct {
+	uc *usecase.FollowUseCase
+}
+
+// NewFollowController はFollowControllerのポインタを生成する関数です
+func NewFollowController(uc *usecase.FollowUseCase) *FollowController {
+	return &FollowController{uc: uc}
+}
+
+// Follow は PUT /user/{userID}/follow のハンドラです
+func (ctrl *FollowController) Follow(c echo.Context) error {
+	return ctrl.updateFollow(c, ctrl.uc.Follow)
+}
+
+// Unfollow は DELETE /user/{userID}/follow のハンドラです
+func (ctrl *FollowController) Unfollow(c echo.Context) error {
+	return ctrl.updateFollow(c, ctrl.uc.Unfollow)
+}
+
+// updateFollow はフォローする，外すハンドラに共通の処理です
+func (ctrl *FollowController) updateFollow(c echo.Context, update func(context.Context, *entity.Follow) error) error {
+	logger := log.New()
+
+	follow := &entity.Follow{}
+	var ok bool
+	if follow.FollowerID, ok = c.Get("userID").(string); !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	follow.FolloweeID = c.Param("userID")
+	if len(follow.FolloweeID) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if err := update(c.Request().Context(), follow); err != nil {
+		if errors.Is(err, entity.ErrCannotFollowSelf) {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrCannotFollowSelf.Error())
+		}
+		if errors.Is(err, entity.ErrUserNotFound) {
+			return echo.NewHTTPError(http.StatusNotFound, entity.ErrUserNotFound.Error())
+		}
+
+		logger.Errorf("error %s /user/{userID}/follow: %s", c.Request().Method, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,119 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/infra/mock"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+func TestFollowController_Follow(t *testing.T) {
+	tests := []struct {
+		name              string
+		method            string
+		userID            string
+		targetID          string
+		prepareMockUser   func(ctx context.Context, user *mock.MockUser)
+		prepareMockFollow func(ctx context.Context, follow *mock.MockFollow)
+		wantErr           bool
+		wantCode          int
+	}{
+		{
+			name:     "フォローできる",
+			method:   "PUT",
+			userID:   "user-id",
+			targetID: "target-id",
+			prepareMockUser: func(ctx context.Context, user *mock.MockUser) {
+				user.EXPECT().FindByID(ctx, "target-id").Return(&entity.User{ID: "target-id"}, nil)
+			},
+			prepareMockFollow: func(ctx context.Context, follow *mock.MockFollow) {
+				follow.EXPECT().Insert(ctx, &entity.Follow{FollowerID: "user-id", FolloweeID: "target-id"}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "フォローを外せる",
+			method:   "DELETE",
+			userID:   "user-id",
+			targetID: "target-id",
+			prepareMockUser: func(ctx context.Context, user *mock.MockUser) {
+				user.EXPECT().FindByID(ctx, "target-id").Return(&entity.User{ID: "target-id"}, nil)
+			},
+			prepareMockFollow: func(ctx context.Context, follow *mock.MockFollow) {
+				follow.EXPECT().Delete(ctx, &entity.Follow{FollowerID: "user-id", FolloweeID: "target-id"}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:     "存在しないユーザならNotFound",
+			method:   "PUT",
+			userID:   "user-id",
+			targetID: "invalid-id",
+			prepareMockUser: func(ctx context.Context, user *mock.MockUser) {
+				user.EXPECT().FindByID(ctx, "invalid-id").Return(nil, entity.ErrUserNotFound)
+			},
+			prepareMockFollow: func(ctx context.Context, follow *mock.MockFollow) {},
+			wantErr:           true,
+			wantCode:          http.StatusNotFound,
+		},
+		{
+			name:              "自分自身はフォローできない",
+			method:            "PUT",
+			userID:            "user-id",
+			targetID:          "user-id",
+			prepareMockUser:   func(ctx context.Context, user *mock.MockUser) {},
+			prepareMockFollow: func(ctx context.Context, follow *mock.MockFollow) {},
+			wantErr:           true,
+			wantCode:          http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("userID")
+			c.SetParamValues(tt.targetID)
+			c.Set("userID", tt.userID)
+
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			userRepo := mock.NewMockUser(ctrl)
+			tt.prepareMockUser(ctx, userRepo)
+			followRepo := mock.NewMockFollow(ctrl)
+			tt.prepareMockFollow(ctx, followRepo)
+
+			con := NewFollowController(usecase.NewFollowUseCase(followRepo, userRepo))
+			var err error
+			if tt.method == "PUT" {
+				err = con.Follow(c)
+			} else {
+				err = con.Unfollow(c)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+// LanguageController は 言語に関するハンドラに対してHTTPリクエストとして
+// 送られたデータを入力として、ユースケースに伝えるまでを責務とするコントローラです
+type LanguageController struct {
+	uc *usecase.LanguageUseCase
+}
+
+// NewLanguageController はLanguageControllerのポインタを生成する関数です
+func NewLanguageController(uc *usecase.LanguageUseCase) *LanguageController {
+	return &LanguageController{uc: uc}
+}
+
+// GetAll は GET /language のハンドラです
+func (ctrl *LanguageController) GetAll(c echo.Context) error {
+	return c.JSON(http.StatusOK, ctrl.uc.GetAll())
+}
@@ -0,0 +1,40 @@
+package controller
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+func TestLanguageController_GetAll(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest("GET", "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	con := NewLanguageController(usecase.NewLanguageUseCase())
+	if err := con.GetAll(c); err != nil {
+		t.Fatal(err)
+	}
+	if rec.Code != http.StatusOK {
+		t.Errorf("code = %d, want = %d", rec.Code, http.StatusOK)
+	}
+
+	var got []map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(entity.Languages()) {
+		t.Fatalf("len = %d, want = %d", len(got), len(entity.Languages()))
+	}
+	for _, key := range []string{"id", "name", "aliases", "extensions"} {
+		if _, ok := got[0][key]; !ok {
+			t.Errorf("%sが含まれていない: %v", key, got[0])
+		}
+	}
+}
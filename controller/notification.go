@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/log"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+// NotificationController は 通知に関するハンドラに対してHTTPリクエストとして
+// 送られたデータを入力として、ユースケースに伝えるまでを責務とするコントローラです
+type NotificationController struct {
+	uc *usecase.NotificationUseCase
+}
+
+// NewNotificationController はNotificationControllerのポインタを生成する関数です
+func NewNotificationController(uc *usecase.NotificationUseCase) *NotificationController {
+	return &NotificationController{uc: uc}
+}
+
+// GetAll は GET /notification のハンドラです
+func (ctrl *NotificationController) GetAll(c echo.Context) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	cursor, limit, err := bindPageParams(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	page, err := ctrl.uc.GetAll(c.Request().Context(), userID, cursor, limit)
+	if err != nil {
+		logger.Errorf("error GET /notification: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// Read は PUT /notification/{notificationID}/read のハンドラです
+func (ctrl *NotificationController) Read(c echo.Context) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	notificationID, err := strconv.Atoi(c.Param("notificationID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if err := ctrl.uc.Read(c.Request().Context(), userID, notificationID); err != nil {
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error PUT /notification/{notificationID}/read: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
@@ -0,0 +1,192 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/infra/mock"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+func TestNotificationController_GetAll(t *testing.T) {
+	notifications := []*entity.Notification{
+		{ID: 2, UserID: "user-id", ActorID: "other-id", Type: entity.NotificationReply, PostID: 1, CommentID: 5, CreatedAt: "2021-03-23T11:42:57+09:00"},
+		{ID: 1, UserID: "user-id", ActorID: "other-id", Type: entity.NotificationComment, PostID: 1, CommentID: 4, Read: true, CreatedAt: "2021-03-23T11:42:56+09:00"},
+	}
+
+	tests := []struct {
+		name                    string
+		query                   string
+		prepareMockNotification func(ctx context.Context, notification *mock.MockNotification)
+		wantErr                 bool
+		wantCode                int
+		wantBody                string
+	}{
+		{
+			name:  "通知を新しい順に未読の数とともに取得できる",
+			query: "limit=1",
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {
+				notification.EXPECT().FindByUserID(ctx, "user-id", nil, 2).Return(notifications, nil)
+				notification.EXPECT().CountUnread(ctx, "user-id").Return(1, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"notifications":[{"id":2,"user_id":"user-id","actor_id":"other-id","type":"reply","post_id":1,"comment_id":5,"read":false,"created_at":"2021-03-23T11:42:57+09:00"}],"unread_count":1,"next_cursor":"` +
+				service.EncodeCursor(&entity.Cursor{CreatedAt: "2021-03-23T11:42:57+09:00", ID: 2}) + `"}
+`,
+		},
+		{
+			name:  "通知がなければ空の一覧を返す",
+			query: "",
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {
+				notification.EXPECT().FindByUserID(ctx, "user-id", nil, entity.DefaultPageLimit+1).Return([]*entity.Notification{}, nil)
+				notification.EXPECT().CountUnread(ctx, "user-id").Return(0, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"notifications":[],"unread_count":0,"next_cursor":""}
+`,
+		},
+		{
+			name:                    "cursorが不正ならBadRequest",
+			query:                   "cursor=!!!",
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {},
+			wantErr:                 true,
+			wantCode:                http.StatusBadRequest,
+			wantBody:                ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("userID", "user-id")
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			notificationRepo := mock.NewMockNotification(ctrl)
+			tt.prepareMockNotification(ctx, notificationRepo)
+
+			con := NewNotificationController(usecase.NewNotificationUseCase(notificationRepo))
+			err := con.GetAll(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
+			}
+		})
+	}
+}
+
+func TestNotificationController_Read(t *testing.T) {
+	tests := []struct {
+		name                    string
+		notificationID          string
+		prepareMockNotification func(ctx context.Context, notification *mock.MockNotification)
+		wantErr                 bool
+		wantCode                int
+	}{
+		{
+			name:           "自分への通知を既読にできる",
+			notificationID: "1",
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {
+				n := &entity.Notification{ID: 1, UserID: "user-id"}
+				notification.EXPECT().FindByID(ctx, 1).Return(n, nil)
+				notification.EXPECT().MarkRead(ctx, n).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:           "既読の通知なら何もしない",
+			notificationID: "1",
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {
+				notification.EXPECT().FindByID(ctx, 1).Return(&entity.Notification{ID: 1, UserID: "user-id", Read: true}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:           "他のユーザへの通知ならNotFound",
+			notificationID: "1",
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {
+				notification.EXPECT().FindByID(ctx, 1).Return(&entity.Notification{ID: 1, UserID: "other-id"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:           "存在しない通知ならNotFound",
+			notificationID: "100",
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {
+				notification.EXPECT().FindByID(ctx, 100).Return(nil, entity.NewErrorNotFound("notification"))
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:                    "notificationIDが数字でなければBadRequest",
+			notificationID:          "abc",
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {},
+			wantErr:                 true,
+			wantCode:                http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("PUT", "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("notificationID")
+			c.SetParamValues(tt.notificationID)
+			c.Set("userID", "user-id")
+
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			notificationRepo := mock.NewMockNotification(ctrl)
+			tt.prepareMockNotification(ctx, notificationRepo)
+
+			con := NewNotificationController(usecase.NewNotificationUseCase(notificationRepo))
+			err := con.Read(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
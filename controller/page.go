@@ -0,0 +1,26 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+)
+
+// bindPageParams はクエリパラメータのcursorとlimitを取り出します
+// limitが省略された場合は0を返し，ユースケース側でデフォルト値に置き換えます
+func bindPageParams(c echo.Context) (cursor *entity.Cursor, limit int, err error) {
+	if limitStr := c.QueryParam("limit"); len(limitStr) > 0 {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return nil, 0, entity.ErrInvalidPageLimit
+		}
+	}
+
+	cursor, err = service.DecodeCursor(c.QueryParam("cursor"))
+	if err != nil {
+		return nil, 0, err
+	}
+	return cursor, limit, nil
+}
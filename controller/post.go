@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"strconv"
@@ -23,24 +24,33 @@ func NewPostController(uc *usecase.PostUsecase) *PostController {
 }
 
 // GetAll は GET /postのためのハンドラです
+// クエリパラメータtagを指定するとそのタグがついた投稿のみを返します
 func (ctrl *PostController) GetAll(c echo.Context) error {
 	logger := log.New()
 
-	posts, err := ctrl.uc.GetAll(c.Request().Context())
+	cursor, limit, err := bindPageParams(c)
 	if err != nil {
-		errNF := &entity.ErrNotFound{}
-		if errors.As(err, errNF) {
-			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
-		}
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	filter := &entity.PostFilter{
+		Tag:    c.QueryParam("tag"),
+		Status: c.QueryParam("status"),
+	}
 
+	page, err := ctrl.uc.GetAll(c.Request().Context(), viewerID(c), filter, cursor, limit)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidPostStatus) {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrInvalidPostStatus.Error())
+		}
 		logger.Errorf("error GET /post: %s", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	return c.JSON(http.StatusOK, posts)
+	return c.JSON(http.StatusOK, page)
 }
 
 // Get は GET /post/{postID}のハンドラです
+// クエリパラメータrevisionを指定するとそのリビジョンのコードを，省略すると最新のリビジョンのコードを返します
 func (ctrl *PostController) Get(c echo.Context) error {
 	logger := log.New()
 
@@ -53,14 +63,18 @@ func (ctrl *PostController) Get(c echo.Context) error {
 		// 数字ではない場合はエラー
 		return echo.NewHTTPError(http.StatusBadRequest)
 	}
+	revision, err := bindRevisionParam(c, "revision")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
 
 	ctx := c.Request().Context()
-	post, err := ctrl.uc.Get(ctx, postIDInt)
+	post, err := ctrl.uc.Get(ctx, viewerID(c), postIDInt, revision)
 
 	if err != nil {
 		errNF := &entity.ErrNotFound{}
 		if errors.As(err, errNF) {
-			logger.Error(entity.NewErrorNotFound("post").Error())
+			logger.Error(errNF.Error())
 			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
 		}
 
@@ -71,6 +85,65 @@ func (ctrl *PostController) Get(c echo.Context) error {
 	return c.JSON(http.StatusOK, post)
 }
 
+// GetRevisions は GET /post/{postID}/revision のハンドラです
+func (ctrl *PostController) GetRevisions(c echo.Context) error {
+	logger := log.New()
+
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	revisions, err := ctrl.uc.GetRevisions(c.Request().Context(), viewerID(c), postID)
+	if err != nil {
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("unexpected error GET /post/{postID}/revision: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, revisions)
+}
+
+// GetDiff は GET /post/{postID}/diff のハンドラです
+// クエリパラメータfromを省略すると投稿時のリビジョンから，toを省略すると最新のリビジョンまでの差分を返します
+// クエリパラメータfileで複数のファイルからなる投稿のファイルを指定でき，省略すると先頭のファイルの差分を返します
+func (ctrl *PostController) GetDiff(c echo.Context) error {
+	logger := log.New()
+
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	from, err := bindRevisionParam(c, "from")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	to, err := bindRevisionParam(c, "to")
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	diff, err := ctrl.uc.GetDiff(c.Request().Context(), viewerID(c), postID, c.QueryParam("file"), from, to)
+	if err != nil {
+		if errors.Is(err, entity.ErrDiffTooLarge) {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrDiffTooLarge.Error())
+		}
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("unexpected error GET /post/{postID}/diff: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
+
 // Create は POST /postのハンドラです
 func (ctrl *PostController) Create(c echo.Context) error {
 	logger := log.New()
@@ -90,6 +163,10 @@ func (ctrl *PostController) Create(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := ctrl.uc.Create(ctx, post); err != nil {
+		if isInvalidPostFieldErr(err) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+
 		logger.Errorf("error POST /post: %s", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
@@ -122,6 +199,12 @@ func (ctrl *PostController) Update(c echo.Context) error {
 
 	ctx := c.Request().Context()
 	if err := ctrl.uc.Update(ctx, post); err != nil {
+		if isInvalidPostFieldErr(err) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		if errors.Is(err, entity.ErrPostCodeCommitted) {
+			return echo.NewHTTPError(http.StatusConflict, entity.ErrPostCodeCommitted.Error())
+		}
 		if errors.Is(err, entity.ErrIsNotAuthor) {
 			logger.Errorf("forbidden update occurs: %s", err.Error())
 			return echo.NewHTTPError(http.StatusForbidden, err.Error())
@@ -173,3 +256,260 @@ func (ctrl *PostController) Delete(c echo.Context) error {
 
 	return c.NoContent(http.StatusOK)
 }
+
+// ChangeStatus は PUT /post/{postID}/status のハンドラです
+// リクエストボディのstatus以外のフィールドは使いません
+func (ctrl *PostController) ChangeStatus(c echo.Context) error {
+	logger := log.New()
+
+	req := &entity.Post{}
+	if err := c.Bind(req); err != nil {
+		logger.Info(err.Error())
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if err := ctrl.uc.ChangeStatus(c.Request().Context(), userID, postID, req.Status); err != nil {
+		if errors.Is(err, entity.ErrInvalidPostStatus) {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrInvalidPostStatus.Error())
+		}
+		if errors.Is(err, entity.ErrInvalidStatusTransition) {
+			return echo.NewHTTPError(http.StatusConflict, err.Error())
+		}
+		if errors.Is(err, entity.ErrIsNotAuthor) {
+			return echo.NewHTTPError(http.StatusForbidden, entity.ErrIsNotAuthor.Error())
+		}
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error PUT /post/{postID}/status: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// Publish は POST /post/{postID}/publish のハンドラです
+func (ctrl *PostController) Publish(c echo.Context) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if err := ctrl.uc.Publish(c.Request().Context(), userID, postID); err != nil {
+		if errors.Is(err, entity.ErrPostAlreadyPublished) {
+			return echo.NewHTTPError(http.StatusConflict, entity.ErrPostAlreadyPublished.Error())
+		}
+		if errors.Is(err, entity.ErrIsNotAuthor) {
+			return echo.NewHTTPError(http.StatusForbidden, entity.ErrIsNotAuthor.Error())
+		}
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error POST /post/{postID}/publish: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// Fork は POST /post/{postID}/fork のハンドラです
+// フォーク元の最新のリビジョンのコードで作成した投稿を返します
+func (ctrl *PostController) Fork(c echo.Context) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	post, err := ctrl.uc.Fork(c.Request().Context(), userID, postID)
+	if err != nil {
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error POST /post/{postID}/fork: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusCreated, post)
+}
+
+// GetForks は GET /post/{postID}/fork のハンドラです
+// 投稿をフォークした公開されている投稿の一覧を返します
+func (ctrl *PostController) GetForks(c echo.Context) error {
+	logger := log.New()
+
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	cursor, limit, err := bindPageParams(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	page, err := ctrl.uc.GetForks(c.Request().Context(), viewerID(c), postID, cursor, limit)
+	if err != nil {
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error GET /post/{postID}/fork: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
+
+// Star は PUT /post/{postID}/star のハンドラです
+func (ctrl *PostController) Star(c echo.Context) error {
+	return ctrl.updateStar(c, ctrl.uc.Star)
+}
+
+// Unstar は DELETE /post/{postID}/star のハンドラです
+func (ctrl *PostController) Unstar(c echo.Context) error {
+	return ctrl.updateStar(c, ctrl.uc.Unstar)
+}
+
+// updateStar はスターをつける，外すハンドラに共通の処理です
+func (ctrl *PostController) updateStar(c echo.Context, update func(context.Context, *entity.Star) error) error {
+	logger := log.New()
+
+	star := &entity.Star{}
+	var ok bool
+	if star.UserID, ok = c.Get("userID").(string); !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	var err error
+	if star.PostID, err = strconv.Atoi(c.Param("postID")); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if err := update(c.Request().Context(), star); err != nil {
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error %s /post/{postID}/star: %s", c.Request().Method, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetInvitations は GET /post/{postID}/invitation のハンドラです
+// 投稿に招待しているユーザのIDの一覧を返します
+func (ctrl *PostController) GetInvitations(c echo.Context) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	userIDs, err := ctrl.uc.GetInvitedUserIDs(c.Request().Context(), userID, postID)
+	if err != nil {
+		if errors.Is(err, entity.ErrIsNotAuthor) {
+			return echo.NewHTTPError(http.StatusForbidden, entity.ErrIsNotAuthor.Error())
+		}
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error GET /post/{postID}/invitation: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, userIDs)
+}
+
+// Invite は PUT /post/{postID}/invitation/{userID} のハンドラです
+func (ctrl *PostController) Invite(c echo.Context) error {
+	return ctrl.updateInvitation(c, ctrl.uc.Invite)
+}
+
+// Uninvite は DELETE /post/{postID}/invitation/{userID} のハンドラです
+func (ctrl *PostController) Uninvite(c echo.Context) error {
+	return ctrl.updateInvitation(c, ctrl.uc.Uninvite)
+}
+
+// updateInvitation は投稿に招待する，招待を取り消すハンドラに共通の処理です
+func (ctrl *PostController) updateInvitation(c echo.Context, update func(ctx context.Context, userID string, postID int, inviteeID string) error) error {
+	logger := log.New()
+
+	userID, ok := c.Get("userID").(string)
+	if !ok {
+		logger.Errorf("Failed type assertion of userID: %#v", c.Get("userID"))
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	postID, err := strconv.Atoi(c.Param("postID"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+	inviteeID := c.Param("userID")
+	if len(inviteeID) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	if err := update(c.Request().Context(), userID, postID, inviteeID); err != nil {
+		if errors.Is(err, entity.ErrIsNotAuthor) {
+			return echo.NewHTTPError(http.StatusForbidden, entity.ErrIsNotAuthor.Error())
+		}
+		errNF := &entity.ErrNotFound{}
+		if errors.As(err, errNF) {
+			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
+		}
+
+		logger.Errorf("error %s /post/{postID}/invitation/{userID}: %s", c.Request().Method, err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// isInvalidPostFieldErr は投稿の作成，更新時にリクエストの内容が原因で起きたエラーかどうかを判定します
+func isInvalidPostFieldErr(err error) bool {
+	return errors.Is(err, entity.ErrInvalidTagName) ||
+		errors.Is(err, entity.ErrInvalidPostVisibility) ||
+		errors.Is(err, entity.ErrTooManyTags) ||
+		errors.Is(err, entity.ErrInvalidPostFile) ||
+		errors.Is(err, entity.ErrTooManyPostFiles) ||
+		errors.Is(err, entity.ErrUnknownLanguage)
+}
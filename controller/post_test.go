@@ -19,15 +19,17 @@ import (
 func TestPostController_GetAll(t *testing.T) {
 	tests := []struct {
 		name            string
+		query           string
 		prepareMockPost func(ctx context.Context, post *mock.MockPost)
 		wantErr         bool
 		wantCode        int
 		wantBody        string
 	}{
 		{
-			name: "正しく投稿を取得できる",
+			name:  "正しく投稿を取得できる",
+			query: "",
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().GetAll(ctx).Return([]*entity.Post{
+				post.EXPECT().GetAll(ctx, &entity.PostFilter{}, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{
 					{
 						ID:        1,
 						UserID:    "user-id",
@@ -54,24 +56,135 @@ func TestPostController_GetAll(t *testing.T) {
 			},
 			wantErr:  false,
 			wantCode: http.StatusOK,
-			wantBody: `[{"id":1,"user_id":"user-id","title":"test title","code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}","language":"Go","content":"Test code","source":"github.com","created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"},{"id":2,"user_id":"user-id","title":"test title","code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}","language":"Go","content":"Test code","source":"github.com","created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}]
+			wantBody: `{"posts":[{"id":1,"user_id":"user-id","title":"test title","code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}","language":"Go","content":"Test code","source":"github.com","star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"},{"id":2,"user_id":"user-id","title":"test title","code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}","language":"Go","content":"Test code","source":"github.com","star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}
 `,
 		},
 		{
-			name: "1つも投稿が存在しないならErrUserNotFound",
+			name:  "limitより多く投稿が存在すれば次のページのカーソルを返す",
+			query: "?limit=1",
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().GetAll(ctx).Return(nil, entity.NewErrorNotFound("post"))
+				post.EXPECT().GetAll(ctx, &entity.PostFilter{}, nil, 2).Return([]*entity.Post{
+					{
+						ID:        2,
+						UserID:    "user-id",
+						Title:     "test title",
+						Code:      "code",
+						Language:  "Go",
+						CreatedAt: "2021-03-23T11:42:57+09:00",
+						UpdatedAt: "2021-03-23T11:42:57+09:00",
+					},
+					{
+						ID:        1,
+						UserID:    "user-id",
+						Title:     "test title",
+						Code:      "code",
+						Language:  "Go",
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					},
+				}, nil)
 			},
-			wantErr:  true,
-			wantCode: http.StatusNotFound,
-			wantBody: ``,
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"posts":[{"id":2,"user_id":"user-id","title":"test title","code":"code","language":"Go","content":"","source":"","star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:57+09:00","updated_at":"2021-03-23T11:42:57+09:00"}],"next_cursor":"MjAyMS0wMy0yM1QxMTo0Mjo1NyswOTowMF8y"}
+`,
+		},
+		{
+			name:  "カーソルを指定すると続きから取得する",
+			query: "?cursor=MjAyMS0wMy0yM1QxMTo0Mjo1NyswOTowMF8y&limit=1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().GetAll(ctx, &entity.PostFilter{}, &entity.Cursor{CreatedAt: "2021-03-23T11:42:57+09:00", ID: 2}, 2).Return([]*entity.Post{}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"posts":[],"next_cursor":""}
+`,
+		},
+		{
+			name:  "tagを指定するとそのタグがついた投稿を取得する",
+			query: "?tag=%20Go%20",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().GetAll(ctx, &entity.PostFilter{Tag: "go"}, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{
+					{
+						ID:        1,
+						UserID:    "user-id",
+						Title:     "test title",
+						Code:      "code",
+						Language:  "Go",
+						Tags:      []string{"go", "goroutine"},
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					},
+				}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"posts":[{"id":1,"user_id":"user-id","title":"test title","code":"code","language":"Go","content":"","source":"","tags":["go","goroutine"],"star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}
+`,
+		},
+		{
+			name:  "statusを指定するとその状態の投稿を取得する",
+			query: "?status=in_review",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().GetAll(ctx, &entity.PostFilter{Status: "in_review"}, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{
+					{
+						ID:        1,
+						UserID:    "user-id",
+						Title:     "test title",
+						Code:      "code",
+						Language:  "Go",
+						Status:    "in_review",
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					},
+				}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"posts":[{"id":1,"user_id":"user-id","title":"test title","code":"code","language":"Go","content":"","source":"","status":"in_review","star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}
+`,
+		},
+		{
+			name:            "存在しないstatusならBadRequest",
+			query:           "?status=closed",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+			wantBody:        ``,
+		},
+		{
+			name:  "1つも投稿が存在しなくても空のページを返す",
+			query: "",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().GetAll(ctx, &entity.PostFilter{}, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"posts":[],"next_cursor":""}
+`,
+		},
+		{
+			name:            "limitが数字でなければBadRequest",
+			query:           "?limit=abc",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+			wantBody:        ``,
+		},
+		{
+			name:            "不正なカーソルならBadRequest",
+			query:           "?cursor=invalid",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+			wantBody:        ``,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest("GET", "/", nil)
+			req := httptest.NewRequest("GET", "/"+tt.query, nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 
@@ -81,8 +194,11 @@ func TestPostController_GetAll(t *testing.T) {
 			postRepo := mock.NewMockPost(ctrl)
 			tt.prepareMockPost(ctx, postRepo)
 			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
 
-			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo))
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
 			err := con.GetAll(c)
 
 			if (err != nil) != tt.wantErr {
@@ -107,61 +223,1916 @@ func TestPostController_GetAll(t *testing.T) {
 }
 
 func TestPostController_Get(t *testing.T) {
+	validPost := func() *entity.Post {
+		return &entity.Post{
+			ID:        1,
+			UserID:    "user-id",
+			Title:     "test title",
+			Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:  "Go",
+			Content:   "Test code",
+			Source:    "github.com",
+			CreatedAt: "2021-03-23T11:42:56+09:00",
+			UpdatedAt: "2021-03-23T11:42:56+09:00",
+		}
+	}
+	commits := []*entity.Comment{
+		{
+			ID:        1,
+			UserID:    "user-id",
+			PostID:    1,
+			Type:      "commit",
+			Code:      "revision 2",
+			Revision:  2,
+			CreatedAt: "2021-03-23T11:42:57+09:00",
+			UpdatedAt: "2021-03-23T11:42:57+09:00",
+		},
+		{
+			ID:        2,
+			UserID:    "other-user-id",
+			PostID:    1,
+			Type:      "none",
+			Content:   "LGTM",
+			CreatedAt: "2021-03-23T11:42:58+09:00",
+			UpdatedAt: "2021-03-23T11:42:58+09:00",
+		},
+		{
+			ID:        3,
+			UserID:    "user-id",
+			PostID:    1,
+			Type:      "commit",
+			Code:      "revision 3",
+			Revision:  3,
+			CreatedAt: "2021-03-23T11:42:59+09:00",
+			UpdatedAt: "2021-03-23T11:42:59+09:00",
+		},
+	}
+
 	tests := []struct {
-		name            string
-		postID          string
-		prepareMockPost func(ctx context.Context, post *mock.MockPost)
-		wantErr         bool
-		wantCode        int
+		name               string
+		postID             string
+		query              string
+		viewerID           string
+		prepareMockPost    func(ctx context.Context, post *mock.MockPost)
+		prepareMockComment func(ctx context.Context, comment *mock.MockComment)
+		wantErr            bool
+		wantCode           int
+		wantPostCode       string
+		wantRevision       int
+	}{
+		{
+			name:   "コメントがなければ投稿時のコードを取得できる",
+			postID: "1",
+			query:  "",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(validPost(), nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(nil, entity.NewErrorNotFound("comment"))
+			},
+			wantErr:      false,
+			wantCode:     http.StatusOK,
+			wantPostCode: validPost().Code,
+			wantRevision: 1,
+		},
+		{
+			name:   "revisionを省略すると最新のリビジョンのコードを取得できる",
+			postID: "1",
+			query:  "",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(validPost(), nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(commits, nil)
+			},
+			wantErr:      false,
+			wantCode:     http.StatusOK,
+			wantPostCode: "revision 3",
+			wantRevision: 3,
+		},
+		{
+			name:   "revisionを指定するとそのリビジョンのコードを取得できる",
+			postID: "1",
+			query:  "?revision=2",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(validPost(), nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(commits, nil)
+			},
+			wantErr:      false,
+			wantCode:     http.StatusOK,
+			wantPostCode: "revision 2",
+			wantRevision: 2,
+		},
+		{
+			name:   "存在しないrevisionならErrNotFound",
+			postID: "1",
+			query:  "?revision=4",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(validPost(), nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(commits, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:               "revisionが数字でないならBadRequest",
+			postID:             "1",
+			query:              "?revision=latest",
+			prepareMockPost:    func(ctx context.Context, post *mock.MockPost) {},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusBadRequest,
+		},
+		{
+			name:   "存在しない投稿IDならErrUserNotFound",
+			postID: "0",
+			query:  "",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 0).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusNotFound,
+		},
+		{
+			name:   "未ログインなら非公開の投稿はNotFound",
+			postID: "1",
+			query:  "",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				p := validPost()
+				p.Visibility = entity.PostVisibilityPrivate
+				post.EXPECT().FindByID(ctx, 1).Return(p, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusNotFound,
+		},
+		{
+			name:     "招待されていなければ非公開の投稿はNotFound",
+			postID:   "1",
+			query:    "",
+			viewerID: "other-user-id",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				p := validPost()
+				p.Visibility = entity.PostVisibilityPrivate
+				post.EXPECT().FindByID(ctx, 1).Return(p, nil)
+				post.EXPECT().IsInvited(ctx, 1, "other-user-id").Return(false, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusNotFound,
+		},
+		{
+			name:     "招待されていれば非公開の投稿を取得できる",
+			postID:   "1",
+			query:    "",
+			viewerID: "other-user-id",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				p := validPost()
+				p.Visibility = entity.PostVisibilityPrivate
+				post.EXPECT().FindByID(ctx, 1).Return(p, nil)
+				post.EXPECT().IsInvited(ctx, 1, "other-user-id").Return(true, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(commits, nil)
+			},
+			wantErr:      false,
+			wantCode:     http.StatusOK,
+			wantPostCode: "revision 3",
+			wantRevision: 3,
+		},
+		{
+			name:     "オーナーは非公開の投稿を取得できる",
+			postID:   "1",
+			query:    "",
+			viewerID: "user-id",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				p := validPost()
+				p.Visibility = entity.PostVisibilityPrivate
+				post.EXPECT().FindByID(ctx, 1).Return(p, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(commits, nil)
+			},
+			wantErr:      false,
+			wantCode:     http.StatusOK,
+			wantPostCode: "revision 3",
+			wantRevision: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+			if len(tt.viewerID) > 0 {
+				c.Set("userID", tt.viewerID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(ctx, postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			if len(tt.viewerID) > 0 && !tt.wantErr {
+				starRepo.EXPECT().FindStarredPostIDs(ctx, tt.viewerID, []int{1}).Return([]int{}, nil)
+			}
+			tt.prepareMockComment(ctx, commentRepo)
+			notificationRepo := mock.NewMockNotification(ctrl)
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.Get(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if !tt.wantErr {
+				got := &entity.Post{}
+				if err := json.Unmarshal(rec.Body.Bytes(), got); err != nil {
+					t.Fatal(err)
+				}
+				if got.Code != tt.wantPostCode {
+					t.Errorf("code = %s, want = %s", got.Code, tt.wantPostCode)
+				}
+				if got.Revision != tt.wantRevision {
+					t.Errorf("revision = %d, want = %d", got.Revision, tt.wantRevision)
+				}
+			}
+		})
+	}
+}
+
+func TestPostController_GetRevisions(t *testing.T) {
+	tests := []struct {
+		name               string
+		postID             string
+		prepareMockPost    func(ctx context.Context, post *mock.MockPost)
+		prepareMockComment func(ctx context.Context, comment *mock.MockComment)
+		wantErr            bool
+		wantCode           int
+		wantBody           string
 	}{
 		{
-			name:   "正しく投稿を取得できる",
+			name:   "投稿時のコードとcommitコメントのリビジョンを番号順に取得できる",
 			postID: "1",
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
 				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{
 					ID:        1,
 					UserID:    "user-id",
 					Title:     "test title",
-					Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+					Code:      "revision 1",
 					Language:  "Go",
-					Content:   "Test code",
-					Source:    "github.com",
 					CreatedAt: "2021-03-23T11:42:56+09:00",
 					UpdatedAt: "2021-03-23T11:42:56+09:00",
 				}, nil)
 			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return([]*entity.Comment{
+					{
+						ID:        1,
+						UserID:    "other-user-id",
+						PostID:    1,
+						Type:      "none",
+						Content:   "LGTM",
+						CreatedAt: "2021-03-23T11:42:57+09:00",
+						UpdatedAt: "2021-03-23T11:42:57+09:00",
+					},
+					{
+						ID:        2,
+						UserID:    "user-id",
+						PostID:    1,
+						Type:      "commit",
+						Code:      "revision 2",
+						Revision:  2,
+						CreatedAt: "2021-03-23T11:42:58+09:00",
+						UpdatedAt: "2021-03-23T11:42:58+09:00",
+					},
+				}, nil)
+			},
 			wantErr:  false,
 			wantCode: http.StatusOK,
+			wantBody: `[{"post_id":1,"number":1,"user_id":"user-id","code":"revision 1","created_at":"2021-03-23T11:42:56+09:00"},{"post_id":1,"number":2,"comment_id":2,"user_id":"user-id","code":"revision 2","created_at":"2021-03-23T11:42:58+09:00"}]
+`,
+		},
+		{
+			name:   "存在しない投稿IDならErrNotFound",
+			postID: "100",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 100).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusNotFound,
+			wantBody:           ``,
+		},
+		{
+			name:               "postIDが数値でないならBadRequest",
+			postID:             "a",
+			prepareMockPost:    func(ctx context.Context, post *mock.MockPost) {},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusBadRequest,
+			wantBody:           ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(ctx, postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			tt.prepareMockComment(ctx, commentRepo)
+			notificationRepo := mock.NewMockNotification(ctrl)
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.GetRevisions(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
+			}
+		})
+	}
+}
+
+func TestPostController_GetDiff(t *testing.T) {
+	post := &entity.Post{
+		ID:        1,
+		UserID:    "user-id",
+		Title:     "test title",
+		Code:      "a\nb\nc",
+		Language:  "Go",
+		CreatedAt: "2021-03-23T11:42:56+09:00",
+		UpdatedAt: "2021-03-23T11:42:56+09:00",
+	}
+	comments := []*entity.Comment{
+		{
+			ID:        1,
+			UserID:    "user-id",
+			PostID:    1,
+			Type:      "commit",
+			Code:      "a\nB\nc",
+			Revision:  2,
+			CreatedAt: "2021-03-23T11:42:57+09:00",
+			UpdatedAt: "2021-03-23T11:42:57+09:00",
+		},
+		{
+			ID:        2,
+			UserID:    "user-id",
+			PostID:    1,
+			Type:      "commit",
+			Code:      "a\nB\nc\nd",
+			Revision:  3,
+			CreatedAt: "2021-03-23T11:42:58+09:00",
+			UpdatedAt: "2021-03-23T11:42:58+09:00",
+		},
+	}
+	multiFilePost := &entity.Post{
+		ID:       2,
+		UserID:   "user-id",
+		Title:    "test title",
+		Code:     "a",
+		Language: "go",
+		Files: []*entity.PostFile{
+			{Filename: "main.go", Language: "go", Content: "a"},
+			{Filename: "main_test.go", Language: "go", Content: "x"},
+		},
+	}
+	multiFileCommits := []*entity.Comment{
+		{ID: 3, UserID: "user-id", PostID: 2, Type: "commit", Filename: "main_test.go", Code: "x\ny", Revision: 2},
+	}
+
+	tests := []struct {
+		name               string
+		postID             string
+		query              string
+		prepareMockPost    func(ctx context.Context, post *mock.MockPost)
+		prepareMockComment func(ctx context.Context, comment *mock.MockComment)
+		wantErr            bool
+		wantCode           int
+		wantBody           string
+	}{
+		{
+			name:   "省略すると投稿時から最新のリビジョンまでの差分を取得できる",
+			postID: "1",
+			query:  "",
+			prepareMockPost: func(ctx context.Context, postRepo *mock.MockPost) {
+				postRepo.EXPECT().FindByID(ctx, 1).Return(post, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(comments, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"post_id":1,"from":1,"to":3,"unified":"--- revision/1\n+++ revision/3\n@@ -1,3 +1,4 @@\n a\n-b\n+B\n c\n+d\n","hunks":[{"old_start":1,"old_lines":3,"new_start":1,"new_lines":4,"lines":[{"type":"context","old_line":1,"new_line":1,"content":"a"},{"type":"delete","old_line":2,"content":"b"},{"type":"add","new_line":2,"content":"B"},{"type":"context","old_line":3,"new_line":3,"content":"c"},{"type":"add","new_line":4,"content":"d"}]}]}
+`,
+		},
+		{
+			name:   "fromとtoを指定してリビジョン間の差分を取得できる",
+			postID: "1",
+			query:  "from=2&to=3",
+			prepareMockPost: func(ctx context.Context, postRepo *mock.MockPost) {
+				postRepo.EXPECT().FindByID(ctx, 1).Return(post, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(comments, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"post_id":1,"from":2,"to":3,"unified":"--- revision/2\n+++ revision/3\n@@ -1,3 +1,4 @@\n a\n B\n c\n+d\n","hunks":[{"old_start":1,"old_lines":3,"new_start":1,"new_lines":4,"lines":[{"type":"context","old_line":1,"new_line":1,"content":"a"},{"type":"context","old_line":2,"new_line":2,"content":"B"},{"type":"context","old_line":3,"new_line":3,"content":"c"},{"type":"add","new_line":4,"content":"d"}]}]}
+`,
+		},
+		{
+			name:   "fileを指定して複数のファイルからなる投稿のファイルの差分を取得できる",
+			postID: "2",
+			query:  "file=main_test.go",
+			prepareMockPost: func(ctx context.Context, postRepo *mock.MockPost) {
+				postRepo.EXPECT().FindByID(ctx, 2).Return(multiFilePost, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 2).Return(multiFileCommits, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"post_id":2,"filename":"main_test.go","from":1,"to":2,"unified":"--- revision/1/main_test.go\n+++ revision/2/main_test.go\n@@ -1,1 +1,2 @@\n x\n+y\n","hunks":[{"old_start":1,"old_lines":1,"new_start":1,"new_lines":2,"lines":[{"type":"context","old_line":1,"new_line":1,"content":"x"},{"type":"add","new_line":2,"content":"y"}]}]}
+`,
+		},
+		{
+			name:   "存在しないファイルならErrNotFound",
+			postID: "2",
+			query:  "file=unknown.go",
+			prepareMockPost: func(ctx context.Context, postRepo *mock.MockPost) {
+				postRepo.EXPECT().FindByID(ctx, 2).Return(multiFilePost, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 2).Return(multiFileCommits, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+			wantBody: ``,
+		},
+		{
+			name:   "存在しないリビジョンならErrNotFound",
+			postID: "1",
+			query:  "to=4",
+			prepareMockPost: func(ctx context.Context, postRepo *mock.MockPost) {
+				postRepo.EXPECT().FindByID(ctx, 1).Return(post, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(comments, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+			wantBody: ``,
+		},
+		{
+			name:   "存在しない投稿IDならErrNotFound",
+			postID: "100",
+			query:  "",
+			prepareMockPost: func(ctx context.Context, postRepo *mock.MockPost) {
+				postRepo.EXPECT().FindByID(ctx, 100).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusNotFound,
+			wantBody:           ``,
+		},
+		{
+			name:               "fromが正の整数でないならBadRequest",
+			postID:             "1",
+			query:              "from=0",
+			prepareMockPost:    func(ctx context.Context, postRepo *mock.MockPost) {},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusBadRequest,
+			wantBody:           ``,
+		},
+		{
+			name:               "toが数値でないならBadRequest",
+			postID:             "1",
+			query:              "to=a",
+			prepareMockPost:    func(ctx context.Context, postRepo *mock.MockPost) {},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusBadRequest,
+			wantBody:           ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(ctx, postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			tt.prepareMockComment(ctx, commentRepo)
+			notificationRepo := mock.NewMockNotification(ctrl)
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.GetDiff(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
+			}
+		})
+	}
+}
+
+func TestPostController_Create(t *testing.T) {
+	tests := []struct {
+		name                    string
+		userID                  string
+		body                    string
+		prepareMockPost         func(ctx context.Context, post *mock.MockPost)
+		prepareMockUser         func(ctx context.Context, user *mock.MockUser)
+		prepareMockNotification func(ctx context.Context, notification *mock.MockNotification)
+		wantErr                 bool
+		wantCode                int
+		wantBody                string
+	}{
+		{
+			name:   "正しく投稿を作成できる",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+				"language":"Go",
+				"content":"Test code",
+				"source":"github.com",
+				"created_at":"2021-03-23T11:42:56+09:00",
+				"updated_at":"2021-03-23T11:42:56+09:00"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Insert(ctx, &entity.Post{
+					UserID:    "user-id",
+					Title:     "test title",
+					Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+					Language:  "go",
+					Content:   "Test code",
+					Source:    "github.com",
+					CreatedAt: "2021-03-23T11:42:56+09:00",
+					UpdatedAt: "2021-03-23T11:42:56+09:00",
+				}).DoAndReturn(func(ctx context.Context, user *entity.Post) error {
+					user.ID = 1
+					return nil
+				})
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id":"user-id",
+				"title":"test title",
+				"code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+				"language":"go",
+				"content":"Test code",
+				"source":"github.com",
+				"star_count":0,"fork_count":0,
+				"created_at":"2021-03-23T11:42:56+09:00",
+				"updated_at":"2021-03-23T11:42:56+09:00"
+				}`,
+		},
+		{
+			name:   "複数のファイルを含む投稿は先頭のファイルをcodeとlanguageにして作成できる",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"content":"handler and test",
+				"files":[
+					{"filename":"handler.go","language":"Go","content":"package handler"},
+					{"filename":"handler_test.go","language":"golang","content":"package handler_test"}
+				]
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Insert(ctx, &entity.Post{
+					UserID:   "user-id",
+					Title:    "test title",
+					Code:     "package handler",
+					Language: "go",
+					Content:  "handler and test",
+					Files: []*entity.PostFile{
+						{Filename: "handler.go", Language: "go", Content: "package handler"},
+						{Filename: "handler_test.go", Language: "go", Content: "package handler_test"},
+					},
+				}).DoAndReturn(func(ctx context.Context, post *entity.Post) error {
+					post.ID = 1
+					return nil
+				})
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id":"user-id",
+				"title":"test title",
+				"code":"package handler",
+				"language":"go",
+				"files":[
+					{"filename":"handler.go","language":"go","content":"package handler"},
+					{"filename":"handler_test.go","language":"go","content":"package handler_test"}
+				],
+				"content":"handler and test",
+				"source":"",
+				"star_count":0,"fork_count":0,
+				"created_at":"",
+				"updated_at":""
+				}`,
+		},
+		{
+			name:   "ファイルの言語が省略されていれば拡張子から求め，拡張子がなければ内容から推定する",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"files":[
+					{"filename":"main.py","content":"print(1)"},
+					{"filename":"NOTES","content":"hello"}
+				]
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Insert(ctx, &entity.Post{
+					UserID:            "user-id",
+					Title:             "test title",
+					Code:              "print(1)",
+					Language:          "python",
+					LanguageDetection: &entity.LanguageDetection{Language: "python", Confidence: 1},
+					Files: []*entity.PostFile{
+						{Filename: "main.py", Language: "python", Content: "print(1)",
+							LanguageDetection: &entity.LanguageDetection{Language: "python", Confidence: 1}},
+						{Filename: "NOTES", Language: entity.PlainTextLanguageID, Content: "hello",
+							LanguageDetection: &entity.LanguageDetection{Language: entity.PlainTextLanguageID, Confidence: 0}},
+					},
+				}).DoAndReturn(func(ctx context.Context, post *entity.Post) error {
+					post.ID = 1
+					return nil
+				})
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id":"user-id",
+				"title":"test title",
+				"code":"print(1)",
+				"language":"python",
+				"language_detection":{"language":"python","confidence":1},
+				"files":[
+					{"filename":"main.py","language":"python","content":"print(1)","language_detection":{"language":"python","confidence":1}},
+					{"filename":"NOTES","language":"plaintext","content":"hello","language_detection":{"language":"plaintext","confidence":0}}
+				],
+				"content":"",
+				"source":"",
+				"star_count":0,"fork_count":0,
+				"created_at":"",
+				"updated_at":""
+				}`,
+		},
+		{
+			name:   "ファイルの言語が登録されていなければBadRequest",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"files":[
+					{"filename":"main.go","language":"go","content":"package main"},
+					{"filename":"main.unknown","language":"unknown","content":"???"}
+				]
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+		},
+		{
+			name:   "タグは正規化して保存される",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"code":"code",
+				"language":"golang",
+				"tags":["Go", " goroutine ", "go"]
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Insert(ctx, &entity.Post{
+					UserID:   "user-id",
+					Title:    "test title",
+					Code:     "code",
+					Language: "go",
+					Tags:     []string{"go", "goroutine"},
+				}).DoAndReturn(func(ctx context.Context, post *entity.Post) error {
+					post.ID = 1
+					return nil
+				})
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id":"user-id",
+				"title":"test title",
+				"code":"code",
+				"language":"go",
+				"content":"",
+				"source":"",
+				"tags":["go", "goroutine"],
+				"star_count":0,"fork_count":0,
+				"created_at":"",
+				"updated_at":""
+				}`,
+		},
+		{
+			name:   "空白を含むタグがあればBadRequest",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"code":"code",
+				"language":"Go",
+				"tags":["go lang"]
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+		},
+		{
+			name:   "言語を省略するとコードから推定した言語と確信度を返す",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"code":"package main\n\nfunc main() {}"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Insert(ctx, &entity.Post{
+					UserID:            "user-id",
+					Title:             "test title",
+					Code:              "package main\n\nfunc main() {}",
+					Language:          "go",
+					LanguageDetection: &entity.LanguageDetection{Language: "go", Confidence: 1},
+				}).DoAndReturn(func(ctx context.Context, post *entity.Post) error {
+					post.ID = 1
+					return nil
+				})
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id":"user-id",
+				"title":"test title",
+				"code":"package main\n\nfunc main() {}",
+				"language":"go",
+				"language_detection":{"language":"go","confidence":1},
+				"content":"",
+				"source":"",
+				"star_count":0,"fork_count":0,
+				"created_at":"",
+				"updated_at":""
+				}`,
+		},
+		{
+			name:   "本文のメンションを解決してメンションされたユーザに通知する",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"code":"code",
+				"language":"go",
+				"content":"thanks @reviewer-id and @user-id"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Insert(ctx, &entity.Post{
+					UserID:   "user-id",
+					Title:    "test title",
+					Code:     "code",
+					Language: "go",
+					Content:  "thanks @reviewer-id and @user-id",
+					Mentions: []*entity.Mention{
+						{UserID: "reviewer-id", Name: "reviewer"},
+						{UserID: "user-id", Name: "me"},
+					},
+				}).DoAndReturn(func(ctx context.Context, post *entity.Post) error {
+					post.ID = 1
+					return nil
+				})
+			},
+			prepareMockUser: func(ctx context.Context, user *mock.MockUser) {
+				user.EXPECT().FindByID(ctx, "reviewer-id").Return(&entity.User{ID: "reviewer-id", Name: "reviewer"}, nil)
+				user.EXPECT().FindByID(ctx, "user-id").Return(&entity.User{ID: "user-id", Name: "me"}, nil)
+			},
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {
+				// 自分自身へのメンションは通知しない
+				notification.EXPECT().Insert(ctx, []*entity.Notification{
+					{UserID: "reviewer-id", ActorID: "user-id", Type: entity.NotificationMention, PostID: 1},
+				}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id":"user-id",
+				"title":"test title",
+				"code":"code",
+				"language":"go",
+				"content":"thanks @reviewer-id and @user-id",
+				"mentions":[{"user_id":"reviewer-id","name":"reviewer"},{"user_id":"user-id","name":"me"}],
+				"source":"",
+				"star_count":0,"fork_count":0,
+				"created_at":"",
+				"updated_at":""
+				}`,
+		},
+		{
+			name:   "非公開の投稿では招待されていないユーザにメンションを通知しない",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"code":"code",
+				"language":"go",
+				"content":"thanks @invited-id and @stranger-id",
+				"visibility":"private"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Insert(ctx, &entity.Post{
+					UserID:     "user-id",
+					Title:      "test title",
+					Code:       "code",
+					Language:   "go",
+					Content:    "thanks @invited-id and @stranger-id",
+					Visibility: entity.PostVisibilityPrivate,
+					Mentions: []*entity.Mention{
+						{UserID: "invited-id", Name: "invited"},
+						{UserID: "stranger-id", Name: "stranger"},
+					},
+				}).DoAndReturn(func(ctx context.Context, post *entity.Post) error {
+					post.ID = 1
+					return nil
+				})
+				post.EXPECT().IsInvited(ctx, 1, "invited-id").Return(true, nil)
+				post.EXPECT().IsInvited(ctx, 1, "stranger-id").Return(false, nil)
+			},
+			prepareMockUser: func(ctx context.Context, user *mock.MockUser) {
+				user.EXPECT().FindByID(ctx, "invited-id").Return(&entity.User{ID: "invited-id", Name: "invited"}, nil)
+				user.EXPECT().FindByID(ctx, "stranger-id").Return(&entity.User{ID: "stranger-id", Name: "stranger"}, nil)
+			},
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {
+				notification.EXPECT().Insert(ctx, []*entity.Notification{
+					{UserID: "invited-id", ActorID: "user-id", Type: entity.NotificationMention, PostID: 1},
+				}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: 201,
+			wantBody: `{
+				"id": 1,
+				"user_id":"user-id",
+				"title":"test title",
+				"code":"code",
+				"language":"go",
+				"content":"thanks @invited-id and @stranger-id",
+				"mentions":[{"user_id":"invited-id","name":"invited"},{"user_id":"stranger-id","name":"stranger"}],
+				"source":"",
+				"visibility":"private",
+				"star_count":0,"fork_count":0,
+				"created_at":"",
+				"updated_at":""
+				}`,
+		},
+		{
+			name:   "登録されていない言語ならBadRequest",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"code":"code",
+				"language":"gollang"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+		},
+		{
+			name:   "タグが多すぎればBadRequest",
+			userID: "user-id",
+			body: `{
+				"title":"test title",
+				"code":"code",
+				"language":"Go",
+				"tags":["a", "b", "c", "d", "e", "f"]
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("userID", tt.userID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			postRepo := mock.NewMockPost(ctrl)
+			ctx := context.Background()
+			tt.prepareMockPost(ctx, postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			if tt.prepareMockUser != nil {
+				tt.prepareMockUser(ctx, userRepo)
+			}
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
+			if tt.prepareMockNotification != nil {
+				tt.prepareMockNotification(ctx, notificationRepo)
+			}
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.Create(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if !tt.wantErr {
+				var gotBody, wantBody map[string]interface{}
+				if err = json.Unmarshal(rec.Body.Bytes(), &gotBody); err != nil {
+					t.Fatal(err)
+				}
+				if err = json.Unmarshal([]byte(tt.wantBody), &wantBody); err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+					t.Errorf("body (-want +got) =\n%s\n", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestPostController_Update(t *testing.T) {
+	code := "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}"
+	tests := []struct {
+		name               string
+		userID             string
+		postID             string
+		body               string
+		prepareMockPost    func(ctx context.Context, post *mock.MockPost)
+		prepareMockComment func(ctx context.Context, comment *mock.MockComment)
+		wantErr            bool
+		wantCode           int
+	}{
+		{
+			name:   "正しく投稿を更新できる",
+			userID: "user-id",
+			postID: "1",
+			body: `{
+				"title":"test title",
+				"code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+				"language":"Go",
+				"content":"Test code",
+				"source":"github.com",
+				"created_at":"2021-03-23T11:42:56+09:00",
+				"updated_at":"2021-03-23T11:42:56+09:00"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Code: "package main", Language: "go"}, nil)
+				post.EXPECT().Update(ctx, &entity.Post{
+					ID:        1,
+					UserID:    "user-id",
+					Title:     "test title",
+					Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+					Language:  "go",
+					Content:   "Test code",
+					Source:    "github.com",
+					CreatedAt: "2021-03-23T11:42:56+09:00",
+					UpdatedAt: "2021-03-23T11:42:56+09:00",
+				}).Return(nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return(nil, entity.NewErrorNotFound("comment"))
+			},
+			wantErr:  false,
+			wantCode: 200,
+		},
+		{
+			name:   "commitのある投稿では最新のリビジョンのコードを送っても投稿時のコードを保存する",
+			userID: "user-id",
+			postID: "1",
+			body: `{
+				"title":"updated title",
+				"code":"package main\n\nfunc main() {}",
+				"language":"go",
+				"content":"Test code"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Code: "package main", Language: "go"}, nil)
+				post.EXPECT().Update(ctx, &entity.Post{
+					ID:       1,
+					UserID:   "user-id",
+					Title:    "updated title",
+					Code:     "package main",
+					Language: "go",
+					Content:  "Test code",
+				}).Return(nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return([]*entity.Comment{
+					{ID: 1, UserID: "user-id", PostID: 1, Type: "commit", Code: "package main\n\nfunc main() {}", Revision: 2},
+				}, nil)
+			},
+			wantErr:  false,
+			wantCode: 200,
+		},
+		{
+			name:   "commitのある投稿のコードを書き換えるならErrPostCodeCommittedでConflict",
+			userID: "user-id",
+			postID: "1",
+			body: `{
+				"title":"updated title",
+				"code":"package main\n\nfunc init() {}",
+				"language":"go",
+				"content":"Test code"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Code: "package main", Language: "go"}, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return([]*entity.Comment{
+					{ID: 1, UserID: "user-id", PostID: 1, Type: "commit", Code: "package main\n\nfunc main() {}", Revision: 2},
+				}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:   "不正なBodyならBadRequest",
+			userID: "user-id",
+			postID: "1",
+			body: `{
+				"aaaa":"test title",
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+		},
+		{
+			name:            "bodyがJSON形式でないならBadRequest",
+			userID:          "user-id",
+			postID:          "1",
+			body:            `aaaaa`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+		},
+		{
+			name:   "存在しないポストならばErrNotFoundでNotFound",
+			userID: "user-id",
+			postID: "100",
+			body: `{
+				"title":"test title",
+				"code":"package main",
+				"language":"go",
+				"content":"Test code"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 100).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "投稿のオーナーでなければErrIsNotAuthorでForbidden",
+			userID: "user-id",
+			postID: "1",
+			body: `{
+				"title":"test title",
+				"code":"package main",
+				"language":"go",
+				"content":"Test code"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "other-id", Code: "package main", Language: "go"}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:   "存在しないユーザならばErrNotFoundでNotFound",
+			userID: "user-id2002",
+			postID: "1",
+			body: `{
+				"title":"test title",
+				"code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+				"language":"Go",
+				"content":"Test code",
+				"source":"github.com",
+				"created_at":"2021-03-23T11:42:56+09:00",
+				"updated_at":"2021-03-23T11:42:56+09:00"
+				}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id2002", Code: code, Language: "go"}, nil)
+				post.EXPECT().Update(ctx, &entity.Post{
+					ID:        1,
+					UserID:    "user-id2002",
+					Title:     "test title",
+					Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+					Language:  "go",
+					Content:   "Test code",
+					Source:    "github.com",
+					CreatedAt: "2021-03-23T11:42:56+09:00",
+					UpdatedAt: "2021-03-23T11:42:56+09:00",
+				}).Return(entity.NewErrorNotFound("user"))
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("PUT", "/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+			c.Set("userID", tt.userID)
+
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(ctx, postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			if tt.prepareMockComment != nil {
+				tt.prepareMockComment(ctx, commentRepo)
+			}
+			starRepo := mock.NewMockStar(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.Update(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestPostController_Delete(t *testing.T) {
+	tests := []struct {
+		name            string
+		userID          string
+		postID          string
+		prepareMockPost func(ctx context.Context, post *mock.MockPost)
+		wantErr         bool
+		wantCode        int
+	}{
+		{
+			name:   "正しく投稿を削除できる",
+			userID: "user-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Delete(ctx, &entity.Post{
+					ID:     1,
+					UserID: "user-id",
+				}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: 200,
+		},
+		{
+			name:   "存在しないポストならばErrNotFound",
+			userID: "user-id",
+			postID: "100",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Delete(ctx, &entity.Post{
+					ID:     100,
+					UserID: "user-id",
+				}).Return(entity.NewErrorNotFound("post"))
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:   "ユーザーに削除権限がないならばErrIsNotAuthor",
+			userID: "other-user-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().Delete(ctx, &entity.Post{
+					ID:     1,
+					UserID: "other-user-id",
+				}).Return(entity.ErrIsNotAuthor)
+			},
+			wantErr:  true,
+			wantCode: http.StatusForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("DELETE", "/", nil)
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+			c.Set("userID", tt.userID)
+
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(ctx, postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.Delete(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestPostController_GetAll_Starred(t *testing.T) {
+	tests := []struct {
+		name            string
+		viewerID        string
+		prepareMockStar func(ctx context.Context, star *mock.MockStar)
+		wantBody        string
+	}{
+		{
+			name:     "ログインしていればスターをつけているかを返す",
+			viewerID: "viewer-id",
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {
+				star.EXPECT().FindStarredPostIDs(ctx, "viewer-id", []int{2, 1}).Return([]int{1}, nil)
+			},
+			wantBody: `{"posts":[{"id":2,"user_id":"user-id","title":"test title","code":"code","language":"go","content":"","source":"","star_count":0,"fork_count":0,"starred":false,"editable":false,"created_at":"2021-03-23T11:42:57+09:00","updated_at":"2021-03-23T11:42:57+09:00"},{"id":1,"user_id":"user-id","title":"test title","code":"code","language":"go","content":"","source":"","star_count":3,"fork_count":0,"starred":true,"editable":false,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}
+`,
+		},
+		{
+			name:            "ログインしていなければstarredを含めない",
+			viewerID:        "",
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantBody: `{"posts":[{"id":2,"user_id":"user-id","title":"test title","code":"code","language":"go","content":"","source":"","star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:57+09:00","updated_at":"2021-03-23T11:42:57+09:00"},{"id":1,"user_id":"user-id","title":"test title","code":"code","language":"go","content":"","source":"","star_count":3,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			if len(tt.viewerID) > 0 {
+				c.Set("userID", tt.viewerID)
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			postRepo := mock.NewMockPost(ctrl)
+			postRepo.EXPECT().GetAll(ctx, &entity.PostFilter{ViewerID: tt.viewerID}, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{
+				{
+					ID:        2,
+					UserID:    "user-id",
+					Title:     "test title",
+					Code:      "code",
+					Language:  "go",
+					CreatedAt: "2021-03-23T11:42:57+09:00",
+					UpdatedAt: "2021-03-23T11:42:57+09:00",
+				},
+				{
+					ID:        1,
+					UserID:    "user-id",
+					Title:     "test title",
+					Code:      "code",
+					Language:  "go",
+					StarCount: 3,
+					CreatedAt: "2021-03-23T11:42:56+09:00",
+					UpdatedAt: "2021-03-23T11:42:56+09:00",
+				},
+			}, nil)
+			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			tt.prepareMockStar(ctx, starRepo)
+			notificationRepo := mock.NewMockNotification(ctrl)
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			if err := con.GetAll(c); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
+			}
+		})
+	}
+}
+
+func TestPostController_Star(t *testing.T) {
+	tests := []struct {
+		name            string
+		method          string
+		userID          string
+		postID          string
+		prepareMockPost func(ctx context.Context, post *mock.MockPost)
+		prepareMockStar func(ctx context.Context, star *mock.MockStar)
+		wantErr         bool
+		wantCode        int
+	}{
+		{
+			name:   "スターをつけられる",
+			method: "PUT",
+			userID: "user-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1}, nil)
+			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {
+				star.EXPECT().Insert(ctx, &entity.Star{UserID: "user-id", PostID: 1}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "スターを外せる",
+			method: "DELETE",
+			userID: "user-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1}, nil)
+			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {
+				star.EXPECT().Delete(ctx, &entity.Star{UserID: "user-id", PostID: 1}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "存在しない投稿ならNotFound",
+			method: "PUT",
+			userID: "user-id",
+			postID: "100",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 100).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantErr:         true,
+			wantCode:        http.StatusNotFound,
+		},
+		{
+			name:   "閲覧できない非公開の投稿からはスターを外せずNotFound",
+			method: "DELETE",
+			userID: "user-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "owner", Visibility: entity.PostVisibilityPrivate}, nil)
+				post.EXPECT().IsInvited(ctx, 1, "user-id").Return(false, nil)
+			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantErr:         true,
+			wantCode:        http.StatusNotFound,
+		},
+		{
+			name:            "postIDが数字でなければBadRequest",
+			method:          "PUT",
+			userID:          "user-id",
+			postID:          "abc",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(tt.method, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+			c.Set("userID", tt.userID)
+
+			ctx := context.Background()
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(ctx, postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			tt.prepareMockStar(ctx, starRepo)
+			notificationRepo := mock.NewMockNotification(ctrl)
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			var err error
+			if tt.method == "PUT" {
+				err = con.Star(c)
+			} else {
+				err = con.Unstar(c)
+			}
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestPostController_ChangeStatus(t *testing.T) {
+	tests := []struct {
+		name            string
+		userID          string
+		postID          string
+		body            string
+		prepareMockPost func(ctx context.Context, post *mock.MockPost)
+		wantErr         bool
+		wantCode        int
+	}{
+		{
+			name:   "オーナーは投稿の状態を変えられる",
+			userID: "user-id",
+			postID: "1",
+			body:   `{"status":"resolved"}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Status: entity.PostStatusInReview}, nil)
+				post.EXPECT().UpdateStatus(ctx, 1, entity.PostStatusResolved).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "同じ状態に変える場合は何もしない",
+			userID: "user-id",
+			postID: "1",
+			body:   `{"status":"open"}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Status: entity.PostStatusOpen}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "オーナー以外はForbidden",
+			userID: "other-user-id",
+			postID: "1",
+			body:   `{"status":"resolved"}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Status: entity.PostStatusOpen}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:   "許されていない遷移ならConflict",
+			userID: "user-id",
+			postID: "1",
+			body:   `{"status":"in_review"}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Status: entity.PostStatusArchived}, nil)
+			},
+			wantErr:  true,
+			wantCode: http.StatusConflict,
+		},
+		{
+			name:            "存在しない状態ならBadRequest",
+			userID:          "user-id",
+			postID:          "1",
+			body:            `{"status":"closed"}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+		},
+		{
+			name:   "存在しない投稿ならNotFound",
+			userID: "user-id",
+			postID: "100",
+			body:   `{"status":"resolved"}`,
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 100).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("PUT", "/", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+			c.Set("userID", tt.userID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(ctx, postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.ChangeStatus(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestPostController_Publish(t *testing.T) {
+	tests := []struct {
+		name                    string
+		userID                  string
+		postID                  string
+		prepareMockPost         func(ctx context.Context, post *mock.MockPost)
+		prepareMockNotification func(ctx context.Context, notification *mock.MockNotification)
+		wantErr                 bool
+		wantCode                int
+	}{
+		{
+			name:   "オーナーは下書きを公開し，メンションしたユーザに通知する",
+			userID: "user-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{
+					ID:       1,
+					UserID:   "user-id",
+					Draft:    true,
+					Mentions: []*entity.Mention{{UserID: "mentioned", Name: "mentioned user"}},
+				}, nil)
+				post.EXPECT().Publish(ctx, 1).Return(nil)
+			},
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {
+				notification.EXPECT().Insert(ctx, []*entity.Notification{
+					{UserID: "mentioned", ActorID: "user-id", Type: entity.NotificationMention, PostID: 1},
+				}).Return(nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+		},
+		{
+			name:   "公開済みの投稿ならConflict",
+			userID: "user-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id"}, nil)
+			},
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {},
+			wantErr:                 true,
+			wantCode:                http.StatusConflict,
+		},
+		{
+			name:   "オーナー以外はForbidden",
+			userID: "other-user-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Draft: true}, nil)
+			},
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {},
+			wantErr:                 true,
+			wantCode:                http.StatusForbidden,
+		},
+		{
+			name:   "存在しない投稿ならNotFound",
+			userID: "user-id",
+			postID: "100",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 100).Return(nil, entity.NewErrorNotFound("post"))
+			},
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {},
+			wantErr:                 true,
+			wantCode:                http.StatusNotFound,
+		},
+		{
+			name:                    "postIDが数字でなければBadRequest",
+			userID:                  "user-id",
+			postID:                  "abc",
+			prepareMockPost:         func(ctx context.Context, post *mock.MockPost) {},
+			prepareMockNotification: func(ctx context.Context, notification *mock.MockNotification) {},
+			wantErr:                 true,
+			wantCode:                http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("POST", "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+			c.Set("userID", tt.userID)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(ctx, postRepo)
+			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
+			tt.prepareMockNotification(ctx, notificationRepo)
+
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.Publish(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+		})
+	}
+}
+
+func TestPostController_Fork(t *testing.T) {
+	original := func() *entity.Post {
+		return &entity.Post{
+			ID:         1,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "revision 1",
+			Language:   "go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
+			Tags:       []string{"go"},
+			CreatedAt:  "2021-03-23T11:42:56+09:00",
+			UpdatedAt:  "2021-03-23T11:42:56+09:00",
+		}
+	}
+
+	tests := []struct {
+		name               string
+		userID             string
+		postID             string
+		prepareMockPost    func(ctx context.Context, post *mock.MockPost)
+		prepareMockComment func(ctx context.Context, comment *mock.MockComment)
+		wantErr            bool
+		wantCode           int
+		wantBody           string
+	}{
+		{
+			name:   "最新のリビジョンのコードでフォークした投稿を作成できる",
+			userID: "forker-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(original(), nil)
+				post.EXPECT().Insert(ctx, &entity.Post{
+					UserID:     "forker-id",
+					Title:      "test title",
+					Code:       "revision 2",
+					Language:   "go",
+					Content:    "Test code",
+					Source:     "github.com",
+					Visibility: entity.PostVisibilityPublic,
+					ForkedFrom: 1,
+					Tags:       []string{"go"},
+				}).DoAndReturn(func(ctx context.Context, post *entity.Post) error {
+					post.ID = 2
+					return nil
+				})
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return([]*entity.Comment{
+					{ID: 1, UserID: "user-id", PostID: 1, Type: "commit", Code: "revision 2", Revision: 2},
+				}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusCreated,
+			wantBody: `{"id":2,"user_id":"forker-id","title":"test title","code":"revision 2","language":"go","content":"Test code","source":"github.com","visibility":"public","forked_from":1,"tags":["go"],"star_count":0,"fork_count":0,"created_at":"","updated_at":""}` + "\n",
+		},
+		{
+			name:   "複数のファイルからなる投稿は最新のリビジョンの先頭のファイルをcodeとlanguageにしてフォークする",
+			userID: "forker-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				multiFile := original()
+				// 言語が正規化される前に保存された投稿でも，先頭のファイルの言語を引き継ぐ
+				multiFile.Code = "print(1)"
+				multiFile.Files = []*entity.PostFile{
+					{Filename: "main.py", Language: "py", Content: "print(1)"},
+					{Filename: "util.go", Language: "go", Content: "package util"},
+				}
+				post.EXPECT().FindByID(ctx, 1).Return(multiFile, nil)
+				post.EXPECT().Insert(ctx, &entity.Post{
+					UserID:   "forker-id",
+					Title:    "test title",
+					Code:     "print(2)",
+					Language: "python",
+					Files: []*entity.PostFile{
+						{Filename: "main.py", Language: "python", Content: "print(2)"},
+						{Filename: "util.go", Language: "go", Content: "package util"},
+					},
+					Content:    "Test code",
+					Source:     "github.com",
+					Visibility: entity.PostVisibilityPublic,
+					ForkedFrom: 1,
+					Tags:       []string{"go"},
+				}).DoAndReturn(func(ctx context.Context, post *entity.Post) error {
+					post.ID = 2
+					return nil
+				})
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {
+				comment.EXPECT().FindByPostID(ctx, 1).Return([]*entity.Comment{
+					{ID: 1, UserID: "user-id", PostID: 1, Type: "commit", Filename: "main.py", Code: "print(2)", Revision: 2},
+				}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusCreated,
+			wantBody: `{"id":2,"user_id":"forker-id","title":"test title","code":"print(2)","language":"python","files":[{"filename":"main.py","language":"python","content":"print(2)"},{"filename":"util.go","language":"go","content":"package util"}],"content":"Test code","source":"github.com","visibility":"public","forked_from":1,"tags":["go"],"star_count":0,"fork_count":0,"created_at":"","updated_at":""}` + "\n",
+		},
+		{
+			name:   "閲覧できない非公開の投稿はフォークできずNotFound",
+			userID: "forker-id",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				private := original()
+				private.Visibility = entity.PostVisibilityPrivate
+				post.EXPECT().FindByID(ctx, 1).Return(private, nil)
+				post.EXPECT().IsInvited(ctx, 1, "forker-id").Return(false, nil)
+			},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusNotFound,
 		},
 		{
-			name:   "存在しない投稿IDならErrUserNotFound",
-			postID: "0",
+			name:   "存在しない投稿ならNotFound",
+			userID: "forker-id",
+			postID: "100",
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().FindByID(ctx, 0).Return(&entity.Post{
-					ID:        1,
-					UserID:    "user-id",
-					Title:     "test title",
-					Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-					Language:  "Go",
-					Content:   "Test code",
-					Source:    "github.com",
-					CreatedAt: "2021-03-23T11:42:56+09:00",
-					UpdatedAt: "2021-03-23T11:42:56+09:00",
-				}, entity.NewErrorNotFound("post"))
+				post.EXPECT().FindByID(ctx, 100).Return(nil, entity.NewErrorNotFound("post"))
 			},
-			wantErr:  true,
-			wantCode: http.StatusNotFound,
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusNotFound,
+		},
+		{
+			name:               "postIDが数字でなければBadRequest",
+			userID:             "forker-id",
+			postID:             "abc",
+			prepareMockPost:    func(ctx context.Context, post *mock.MockPost) {},
+			prepareMockComment: func(ctx context.Context, comment *mock.MockComment) {},
+			wantErr:            true,
+			wantCode:           http.StatusBadRequest,
 		},
 	}
-
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest("GET", "/", nil)
+			req := httptest.NewRequest("POST", "/", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("postID")
 			c.SetParamValues(tt.postID)
+			c.Set("userID", tt.userID)
 
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
@@ -169,9 +2140,13 @@ func TestPostController_Get(t *testing.T) {
 			postRepo := mock.NewMockPost(ctrl)
 			tt.prepareMockPost(ctx, postRepo)
 			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			tt.prepareMockComment(ctx, commentRepo)
+			starRepo := mock.NewMockStar(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
 
-			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo))
-			err := con.Get(c)
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.Fork(c)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
@@ -186,80 +2161,100 @@ func TestPostController_Get(t *testing.T) {
 					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
 				}
 			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
+			}
 		})
 	}
 }
 
-func TestPostController_Create(t *testing.T) {
+func TestPostController_GetForks(t *testing.T) {
 	tests := []struct {
 		name            string
-		userID          string
-		body            string
+		viewerID        string
+		postID          string
 		prepareMockPost func(ctx context.Context, post *mock.MockPost)
+		prepareMockStar func(ctx context.Context, star *mock.MockStar)
 		wantErr         bool
 		wantCode        int
 		wantBody        string
 	}{
 		{
-			name:   "正しく投稿を作成できる",
-			userID: "user-id",
-			body: `{
-				"title":"test title",
-				"code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-				"language":"Go",
-				"content":"Test code",
-				"source":"github.com",
-				"created_at":"2021-03-23T11:42:56+09:00",
-				"updated_at":"2021-03-23T11:42:56+09:00"
-				}`,
+			name:   "フォークした投稿の一覧を取得できる",
+			postID: "1",
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().Insert(ctx, &entity.Post{
-					UserID:    "user-id",
-					Title:     "test title",
-					Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-					Language:  "Go",
-					Content:   "Test code",
-					Source:    "github.com",
-					CreatedAt: "2021-03-23T11:42:56+09:00",
-					UpdatedAt: "2021-03-23T11:42:56+09:00",
-				}).DoAndReturn(func(ctx context.Context, user *entity.Post) error {
-					user.ID = 1
-					return nil
-				})
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Visibility: entity.PostVisibilityPublic}, nil)
+				post.EXPECT().FindForks(ctx, 1, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{
+					{ID: 2, UserID: "forker-id", Title: "test title", Code: "code", Language: "go", ForkedFrom: 1, CreatedAt: "2021-03-23T11:42:56+09:00", UpdatedAt: "2021-03-23T11:42:56+09:00"},
+				}, nil)
+			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantErr:         false,
+			wantCode:        http.StatusOK,
+			wantBody:        `{"posts":[{"id":2,"user_id":"forker-id","title":"test title","code":"code","language":"go","content":"","source":"","forked_from":1,"star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}` + "\n",
+		},
+		{
+			name:     "ログインしていればスターをつけているかと編集できるかをセットする",
+			viewerID: "forker-id",
+			postID:   "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Visibility: entity.PostVisibilityPublic}, nil)
+				post.EXPECT().FindForks(ctx, 1, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{
+					{ID: 2, UserID: "forker-id", Title: "test title", Code: "code", Language: "go", ForkedFrom: 1, CreatedAt: "2021-03-23T11:42:56+09:00", UpdatedAt: "2021-03-23T11:42:56+09:00"},
+				}, nil)
+			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {
+				star.EXPECT().FindStarredPostIDs(ctx, "forker-id", []int{2}).Return([]int{}, nil)
 			},
 			wantErr:  false,
-			wantCode: 201,
-			wantBody: `{
-				"id": 1,
-				"user_id":"user-id",
-				"title":"test title",
-				"code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-				"language":"Go",
-				"content":"Test code",
-				"source":"github.com",
-				"created_at":"2021-03-23T11:42:56+09:00",
-				"updated_at":"2021-03-23T11:42:56+09:00"
-				}`,
+			wantCode: http.StatusOK,
+			wantBody: `{"posts":[{"id":2,"user_id":"forker-id","title":"test title","code":"code","language":"go","content":"","source":"","forked_from":1,"star_count":0,"fork_count":0,"starred":false,"editable":true,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}` + "\n",
+		},
+		{
+			name:   "閲覧できない非公開の投稿ならNotFound",
+			postID: "1",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id", Visibility: entity.PostVisibilityPrivate}, nil)
+			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantErr:         true,
+			wantCode:        http.StatusNotFound,
+		},
+		{
+			name:            "postIDが数字でなければBadRequest",
+			postID:          "abc",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest("POST", "/", strings.NewReader(tt.body))
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req := httptest.NewRequest("GET", "/", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			c.Set("userID", tt.userID)
+			c.SetParamNames("postID")
+			c.SetParamValues(tt.postID)
+			if len(tt.viewerID) > 0 {
+				c.Set("userID", tt.viewerID)
+			}
 
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
+			ctx := c.Request().Context()
 			postRepo := mock.NewMockPost(ctrl)
-			ctx := context.Background()
 			tt.prepareMockPost(ctx, postRepo)
 			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			tt.prepareMockStar(ctx, starRepo)
+			notificationRepo := mock.NewMockNotification(ctrl)
 
-			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo))
-			err := con.Create(c)
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.GetForks(c)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
@@ -275,136 +2270,52 @@ func TestPostController_Create(t *testing.T) {
 				}
 			}
 
-			if !tt.wantErr {
-				var gotBody, wantBody map[string]interface{}
-				if err = json.Unmarshal(rec.Body.Bytes(), &gotBody); err != nil {
-					t.Fatal(err)
-				}
-				if err = json.Unmarshal([]byte(tt.wantBody), &wantBody); err != nil {
-					t.Fatal(err)
-				}
-
-				if diff := cmp.Diff(wantBody, gotBody); diff != "" {
-					t.Errorf("body (-want +got) =\n%s\n", diff)
-				}
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
 			}
 		})
 	}
 }
 
-func TestPostController_Update(t *testing.T) {
+func TestPostController_GetInvitations(t *testing.T) {
 	tests := []struct {
 		name            string
 		userID          string
 		postID          string
-		body            string
 		prepareMockPost func(ctx context.Context, post *mock.MockPost)
 		wantErr         bool
 		wantCode        int
+		wantBody        string
 	}{
 		{
-			name:   "正しく投稿を更新できる",
+			name:   "オーナーは招待しているユーザの一覧を取得できる",
 			userID: "user-id",
 			postID: "1",
-			body: `{
-				"title":"test title",
-				"code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-				"language":"Go",
-				"content":"Test code",
-				"source":"github.com",
-				"created_at":"2021-03-23T11:42:56+09:00",
-				"updated_at":"2021-03-23T11:42:56+09:00"
-				}`,
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().Update(ctx, &entity.Post{
-					ID:        1,
-					UserID:    "user-id",
-					Title:     "test title",
-					Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-					Language:  "Go",
-					Content:   "Test code",
-					Source:    "github.com",
-					CreatedAt: "2021-03-23T11:42:56+09:00",
-					UpdatedAt: "2021-03-23T11:42:56+09:00",
-				}).Return(nil)
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id"}, nil)
+				post.EXPECT().FindInvitedUserIDs(ctx, 1).Return([]string{"invitee1", "invitee2"}, nil)
 			},
 			wantErr:  false,
-			wantCode: 200,
+			wantCode: http.StatusOK,
+			wantBody: `["invitee1","invitee2"]
+`,
 		},
 		{
-			name:   "不正なBodyならBadRequest",
-			userID: "user-id",
+			name:   "オーナー以外はForbidden",
+			userID: "other-user-id",
 			postID: "1",
-			body: `{
-				"aaaa":"test title",
-				}`,
-			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
-			wantErr:         true,
-			wantCode:        http.StatusBadRequest,
-		},
-		{
-			name:            "bodyがJSON形式でないならBadRequest",
-			userID:          "user-id",
-			postID:          "1",
-			body:            `aaaaa`,
-			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
-			wantErr:         true,
-			wantCode:        http.StatusBadRequest,
-		},
-		{
-			name:   "存在しないポストならばErrIsNotAuthorでForbidden",
-			userID: "user-id",
-			postID: "100",
-			body: `{
-				"title":"test title",
-				"code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-				"language":"Go",
-				"content":"Test code",
-				"source":"github.com",
-				"created_at":"2021-03-23T11:42:56+09:00",
-				"updated_at":"2021-03-23T11:42:56+09:00"
-				}`,
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().Update(ctx, &entity.Post{
-					ID:        100,
-					UserID:    "user-id",
-					Title:     "test title",
-					Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-					Language:  "Go",
-					Content:   "Test code",
-					Source:    "github.com",
-					CreatedAt: "2021-03-23T11:42:56+09:00",
-					UpdatedAt: "2021-03-23T11:42:56+09:00",
-				}).Return(entity.ErrIsNotAuthor)
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id"}, nil)
 			},
 			wantErr:  true,
 			wantCode: http.StatusForbidden,
 		},
 		{
-			name:   "存在しないユーザならばErrNotFoundでNotFound",
-			userID: "user-id2002",
-			postID: "1",
-			body: `{
-				"title":"test title",
-				"code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-				"language":"Go",
-				"content":"Test code",
-				"source":"github.com",
-				"created_at":"2021-03-23T11:42:56+09:00",
-				"updated_at":"2021-03-23T11:42:56+09:00"
-				}`,
+			name:   "存在しない投稿ならNotFound",
+			userID: "user-id",
+			postID: "100",
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().Update(ctx, &entity.Post{
-					ID:        1,
-					UserID:    "user-id2002",
-					Title:     "test title",
-					Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-					Language:  "Go",
-					Content:   "Test code",
-					Source:    "github.com",
-					CreatedAt: "2021-03-23T11:42:56+09:00",
-					UpdatedAt: "2021-03-23T11:42:56+09:00",
-				}).Return(entity.NewErrorNotFound("user"))
+				post.EXPECT().FindByID(ctx, 100).Return(nil, entity.NewErrorNotFound("post"))
 			},
 			wantErr:  true,
 			wantCode: http.StatusNotFound,
@@ -413,8 +2324,7 @@ func TestPostController_Update(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest("PUT", "/", strings.NewReader(tt.body))
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req := httptest.NewRequest("GET", "/", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
 			c.SetParamNames("postID")
@@ -427,9 +2337,12 @@ func TestPostController_Update(t *testing.T) {
 			postRepo := mock.NewMockPost(ctrl)
 			tt.prepareMockPost(ctx, postRepo)
 			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
 
-			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo))
-			err := con.Update(c)
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			err := con.GetInvitations(c)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
@@ -444,68 +2357,95 @@ func TestPostController_Update(t *testing.T) {
 					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
 				}
 			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
+			}
 		})
 	}
 }
 
-func TestPostController_Delete(t *testing.T) {
+func TestPostController_Invite(t *testing.T) {
 	tests := []struct {
 		name            string
+		method          string
 		userID          string
 		postID          string
+		inviteeID       string
 		prepareMockPost func(ctx context.Context, post *mock.MockPost)
 		wantErr         bool
 		wantCode        int
 	}{
 		{
-			name:   "正しく投稿を削除できる",
-			userID: "user-id",
-			postID: "1",
+			name:      "オーナーはユーザを招待できる",
+			method:    "PUT",
+			userID:    "user-id",
+			postID:    "1",
+			inviteeID: "invitee",
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().Delete(ctx, &entity.Post{
-					ID:     1,
-					UserID: "user-id",
-				}).Return(nil)
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id"}, nil)
+				post.EXPECT().Invite(ctx, 1, "invitee").Return(nil)
 			},
 			wantErr:  false,
-			wantCode: 200,
+			wantCode: http.StatusOK,
 		},
 		{
-			name:   "存在しないポストならばErrNotFound",
-			userID: "user-id",
-			postID: "100",
+			name:      "オーナーは招待を取り消せる",
+			method:    "DELETE",
+			userID:    "user-id",
+			postID:    "1",
+			inviteeID: "invitee",
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().Delete(ctx, &entity.Post{
-					ID:     100,
-					UserID: "user-id",
-				}).Return(entity.NewErrorNotFound("post"))
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id"}, nil)
+				post.EXPECT().Uninvite(ctx, 1, "invitee").Return(nil)
 			},
-			wantErr:  true,
-			wantCode: http.StatusNotFound,
+			wantErr:  false,
+			wantCode: http.StatusOK,
 		},
 		{
-			name:   "ユーザーに削除権限がないならばErrIsNotAuthor",
-			userID: "other-user-id",
-			postID: "1",
+			name:      "オーナー以外はForbidden",
+			method:    "PUT",
+			userID:    "other-user-id",
+			postID:    "1",
+			inviteeID: "other-user-id",
 			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
-				post.EXPECT().Delete(ctx, &entity.Post{
-					ID:     1,
-					UserID: "other-user-id",
-				}).Return(entity.ErrIsNotAuthor)
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id"}, nil)
 			},
 			wantErr:  true,
 			wantCode: http.StatusForbidden,
 		},
+		{
+			name:      "存在しないユーザを招待するとNotFound",
+			method:    "PUT",
+			userID:    "user-id",
+			postID:    "1",
+			inviteeID: "unknown",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {
+				post.EXPECT().FindByID(ctx, 1).Return(&entity.Post{ID: 1, UserID: "user-id"}, nil)
+				post.EXPECT().Invite(ctx, 1, "unknown").Return(entity.NewErrorNotFound("user"))
+			},
+			wantErr:  true,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name:            "postIDが数字でなければBadRequest",
+			method:          "PUT",
+			userID:          "user-id",
+			postID:          "abc",
+			inviteeID:       "invitee",
+			prepareMockPost: func(ctx context.Context, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			e := echo.New()
-			req := httptest.NewRequest("DELETE", "/", nil)
-			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req := httptest.NewRequest(tt.method, "/", nil)
 			rec := httptest.NewRecorder()
 			c := e.NewContext(req, rec)
-			c.SetParamNames("postID")
-			c.SetParamValues(tt.postID)
+			c.SetParamNames("postID", "userID")
+			c.SetParamValues(tt.postID, tt.inviteeID)
 			c.Set("userID", tt.userID)
 
 			ctx := context.Background()
@@ -514,9 +2454,17 @@ func TestPostController_Delete(t *testing.T) {
 			postRepo := mock.NewMockPost(ctrl)
 			tt.prepareMockPost(ctx, postRepo)
 			userRepo := mock.NewMockUser(ctrl)
+			commentRepo := mock.NewMockComment(ctrl)
+			starRepo := mock.NewMockStar(ctrl)
+			notificationRepo := mock.NewMockNotification(ctrl)
 
-			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo))
-			err := con.Delete(c)
+			con := NewPostController(usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo))
+			var err error
+			if tt.method == "PUT" {
+				err = con.Invite(c)
+			} else {
+				err = con.Uninvite(c)
+			}
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
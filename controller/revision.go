@@ -0,0 +1,22 @@
+package controller
+
+import (
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// bindRevisionParam はクエリパラメータnameからリビジョン番号を取り出します
+// 省略された場合は0を返し，ユースケース側で既定のリビジョンに置き換えます
+func bindRevisionParam(c echo.Context, name string) (int, error) {
+	revisionStr := c.QueryParam(name)
+	if len(revisionStr) == 0 {
+		return 0, nil
+	}
+	revision, err := strconv.Atoi(revisionStr)
+	if err != nil || revision <= 0 {
+		return 0, entity.ErrInvalidRevision
+	}
+	return revision, nil
+}
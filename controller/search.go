@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/log"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+// SearchController は 検索に関するハンドラに対してHTTPリクエストとして
+// 送られたデータを入力として、ユースケースに伝えるまでを責務とするコントローラです
+type SearchController struct {
+	uc *usecase.SearchUseCase
+}
+
+// NewSearchController はSearchControllerのポインタを生成する関数です
+func NewSearchController(uc *usecase.SearchUseCase) *SearchController {
+	return &SearchController{uc: uc}
+}
+
+// Search は GET /search のハンドラです
+// クエリパラメータqで検索し，language, user_idで絞り込みます
+func (ctrl *SearchController) Search(c echo.Context) error {
+	logger := log.New()
+
+	query := &entity.SearchQuery{
+		Keyword:  c.QueryParam("q"),
+		Language: c.QueryParam("language"),
+		UserID:   c.QueryParam("user_id"),
+	}
+	if limitStr := c.QueryParam("limit"); len(limitStr) > 0 {
+		limit, err := strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, entity.ErrInvalidPageLimit.Error())
+		}
+		query.Limit = limit
+	}
+	offset, err := service.DecodeOffsetCursor(c.QueryParam("cursor"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	query.Offset = offset
+	if err := query.IsValid(); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	page, err := ctrl.uc.Search(c.Request().Context(), viewerID(c), query)
+	if err != nil {
+		if errors.Is(err, entity.ErrInvalidCursor) {
+			return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+		}
+		logger.Errorf("error GET /search: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, page)
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/infra/mock"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+func TestSearchController_Search(t *testing.T) {
+	tests := []struct {
+		name              string
+		query             string
+		prepareMockSearch func(ctx context.Context, search *mock.MockSearch)
+		wantErr           bool
+		wantCode          int
+		wantBody          string
+	}{
+		{
+			name:  "条件を指定して検索できる",
+			query: "q=goroutine&language=Go&user_id=user-id&limit=1&cursor=" + service.EncodeOffsetCursor(1),
+			prepareMockSearch: func(ctx context.Context, search *mock.MockSearch) {
+				search.EXPECT().SearchPosts(ctx, &entity.SearchQuery{
+					Keyword:  "goroutine",
+					Language: "go",
+					UserID:   "user-id",
+					Offset:   1,
+					Limit:    2,
+				}).Return([]*entity.SearchResult{
+					{
+						Post: &entity.Post{
+							ID:        2,
+							UserID:    "user-id",
+							Title:     "goroutine",
+							Code:      "go f()",
+							Language:  "Go",
+							CreatedAt: "2021-03-23T11:42:56+09:00",
+							UpdatedAt: "2021-03-23T11:42:56+09:00",
+						},
+						Score: 1.5,
+					},
+					{
+						Post:  &entity.Post{ID: 1},
+						Score: 0.5,
+					},
+				}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"results":[{"post":{"id":2,"user_id":"user-id","title":"goroutine","code":"go f()","language":"Go","content":"","source":"","star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"},"score":1.5}],"next_cursor":"` + service.EncodeOffsetCursor(2) + `"}
+`,
+		},
+		{
+			name:  "ヒットしなければ空の結果を返す",
+			query: "q=rust",
+			prepareMockSearch: func(ctx context.Context, search *mock.MockSearch) {
+				search.EXPECT().SearchPosts(ctx, &entity.SearchQuery{
+					Keyword: "rust",
+					Limit:   entity.DefaultPageLimit + 1,
+				}).Return([]*entity.SearchResult{}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"results":[],"next_cursor":""}
+`,
+		},
+		{
+			name:              "qが空ならBadRequest",
+			query:             "language=Go",
+			prepareMockSearch: func(ctx context.Context, search *mock.MockSearch) {},
+			wantErr:           true,
+			wantCode:          http.StatusBadRequest,
+			wantBody:          ``,
+		},
+		{
+			name:              "cursorが不正ならBadRequest",
+			query:             "q=go&cursor=!!!",
+			prepareMockSearch: func(ctx context.Context, search *mock.MockSearch) {},
+			wantErr:           true,
+			wantCode:          http.StatusBadRequest,
+			wantBody:          ``,
+		},
+		{
+			name:              "limitが正の整数でないならBadRequest",
+			query:             "q=go&limit=-1",
+			prepareMockSearch: func(ctx context.Context, search *mock.MockSearch) {},
+			wantErr:           true,
+			wantCode:          http.StatusBadRequest,
+			wantBody:          ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			searchRepo := mock.NewMockSearch(ctrl)
+			tt.prepareMockSearch(ctx, searchRepo)
+
+			starRepo := mock.NewMockStar(ctrl)
+			con := NewSearchController(usecase.NewSearchUseCase(searchRepo, starRepo))
+			err := con.Search(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
+			}
+		})
+	}
+}
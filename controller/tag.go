@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/log"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+// TagController は タグに関するハンドラに対してHTTPリクエストとして
+// 送られたデータを入力として、ユースケースに伝えるまでを責務とするコントローラです
+type TagController struct {
+	uc *usecase.TagUseCase
+}
+
+// NewTagController はTagControllerのポインタを生成する関数です
+func NewTagController(uc *usecase.TagUseCase) *TagController {
+	return &TagController{uc: uc}
+}
+
+// GetAll は GET /tag のハンドラです
+func (ctrl *TagController) GetAll(c echo.Context) error {
+	logger := log.New()
+
+	tags, err := ctrl.uc.GetAll(c.Request().Context())
+	if err != nil {
+		logger.Errorf("error GET /tag: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, tags)
+}
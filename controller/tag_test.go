@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/labstack/echo/v4"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/infra/mock"
+	"github.com/openhacku-saboten/OmnisCode-backend/usecase"
+)
+
+func TestTagController_GetAll(t *testing.T) {
+	tests := []struct {
+		name           string
+		prepareMockTag func(ctx context.Context, tag *mock.MockTag)
+		wantErr        bool
+		wantCode       int
+		wantBody       string
+	}{
+		{
+			name: "使用数つきでタグを取得できる",
+			prepareMockTag: func(ctx context.Context, tag *mock.MockTag) {
+				tag.EXPECT().GetAll(ctx).Return([]*entity.Tag{
+					{Name: "go", Count: 2},
+					{Name: "rust", Count: 1},
+				}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `[{"name":"go","count":2},{"name":"rust","count":1}]
+`,
+		},
+		{
+			name: "タグが1つもなければ空の配列を返す",
+			prepareMockTag: func(ctx context.Context, tag *mock.MockTag) {
+				tag.EXPECT().GetAll(ctx).Return([]*entity.Tag{}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `[]
+`,
+		},
+		{
+			name: "取得に失敗したらInternalServerError",
+			prepareMockTag: func(ctx context.Context, tag *mock.MockTag) {
+				tag.EXPECT().GetAll(ctx).Return(nil, errors.New("error"))
+			},
+			wantErr:  true,
+			wantCode: http.StatusInternalServerError,
+			wantBody: ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			tagRepo := mock.NewMockTag(ctrl)
+			tt.prepareMockTag(ctx, tagRepo)
+
+			con := NewTagController(usecase.NewTagUseCase(tagRepo))
+			err := con.GetAll(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if got := rec.Body.String(); got != tt.wantBody {
+				t.Errorf("\nwant: %s, \nbut: %s", tt.wantBody, got)
+			}
+		})
+	}
+}
@@ -44,6 +44,7 @@ func (ctrl *UserController) Get(c echo.Context) error {
 }
 
 // GetPosts は  GET /user/{userID}/post のHandler
+// ログインしているユーザ本人の投稿一覧には，限定公開と非公開の投稿も含めます
 func (ctrl *UserController) GetPosts(c echo.Context) error {
 	logger := log.New()
 
@@ -52,19 +53,43 @@ func (ctrl *UserController) GetPosts(c echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest)
 	}
 
+	cursor, limit, err := bindPageParams(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
 	ctx := c.Request().Context()
 
-	posts, err := ctrl.uc.GetPosts(ctx, userID)
+	page, err := ctrl.uc.GetPosts(ctx, viewerID(c), userID, cursor, limit)
 	if err != nil {
-		errNF := &entity.ErrNotFound{}
-		if errors.As(err, errNF) {
-			return echo.NewHTTPError(http.StatusNotFound, errNF.Error())
-		}
 		logger.Errorf("error GET /user/{userID}/post: %s", err.Error())
 		return echo.NewHTTPError(http.StatusInternalServerError)
 	}
 
-	return c.JSON(http.StatusOK, posts)
+	return c.JSON(http.StatusOK, page)
+}
+
+// GetStarredPosts は GET /user/{userID}/starred のHandler
+func (ctrl *UserController) GetStarredPosts(c echo.Context) error {
+	logger := log.New()
+
+	userID := c.Param("userID")
+	if len(userID) == 0 {
+		return echo.NewHTTPError(http.StatusBadRequest)
+	}
+
+	cursor, limit, err := bindPageParams(c)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	page, err := ctrl.uc.GetStarredPosts(c.Request().Context(), viewerID(c), userID, cursor, limit)
+	if err != nil {
+		logger.Errorf("error GET /user/{userID}/starred: %s", err.Error())
+		return echo.NewHTTPError(http.StatusInternalServerError)
+	}
+
+	return c.JSON(http.StatusOK, page)
 }
 
 // GetComments は GET /user/{userID}/comment
@@ -77,7 +102,7 @@ func (ctrl *UserController) GetComments(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	comments, err := ctrl.uc.GetComments(ctx, userID)
+	comments, err := ctrl.uc.GetComments(ctx, viewerID(c), userID)
 	if err != nil {
 		errNF := &entity.ErrNotFound{}
 		if errors.As(err, errNF) {
@@ -30,8 +30,15 @@ func TestUserController_Get(t *testing.T) {
 			name:   "正しくユーザーを取得できる",
 			userID: "user-id",
 			prepareMockUser: func(user *mock.MockUser) {
-				user.EXPECT().FindByID(gomock.Any(), "user-id").Return(
-					entity.NewUser("user-id", "name", "profile", "twitter", ""),
+				user.EXPECT().FindProfile(gomock.Any(), "user-id").Return(
+					&entity.User{
+						ID:             "user-id",
+						Name:           "name",
+						Profile:        "profile",
+						TwitterID:      "twitter",
+						FollowerCount:  3,
+						FollowingCount: 1,
+					},
 					nil,
 				)
 			},
@@ -41,18 +48,20 @@ func TestUserController_Get(t *testing.T) {
 			wantErr:  false,
 			wantCode: 200,
 			wantBody: map[string]interface{}{
-				"id":         "user-id",
-				"name":       "name",
-				"profile":    "profile",
-				"twitter_id": "twitter",
-				"icon_url":   "icon-url",
+				"id":              "user-id",
+				"name":            "name",
+				"profile":         "profile",
+				"twitter_id":      "twitter",
+				"icon_url":        "icon-url",
+				"follower_count":  float64(3),
+				"following_count": float64(1),
 			},
 		},
 		{
 			name:   "存在しないユーザーIDならErrUserNotFound",
 			userID: "invalid-user-id",
 			prepareMockUser: func(user *mock.MockUser) {
-				user.EXPECT().FindByID(gomock.Any(), "invalid-user-id").Return(
+				user.EXPECT().FindProfile(gomock.Any(), "invalid-user-id").Return(
 					nil,
 					entity.ErrUserNotFound,
 				)
@@ -91,7 +100,8 @@ func TestUserController_Get(t *testing.T) {
 			postRepo := mock.NewMockPost(ctrl)
 			commentRepo := mock.NewMockComment(ctrl)
 
-			con := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo))
+			starRepo := mock.NewMockStar(ctrl)
+			con := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo, starRepo))
 			err := con.Get(c)
 
 			if (err != nil) != tt.wantErr {
@@ -234,7 +244,8 @@ func TestCommentController_GetByUserID(t *testing.T) {
 			commentRepo := mock.NewMockComment(ctrl)
 			tt.prepareMockComment(ctx, tt.userID, commentRepo)
 
-			userCon := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo))
+			starRepo := mock.NewMockStar(ctrl)
+			userCon := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo, starRepo))
 			err := userCon.GetComments(c)
 
 			if (err != nil) != tt.wantErr {
@@ -272,7 +283,9 @@ func TestUserController_GetPosts(t *testing.T) {
 	tests := []struct {
 		name            string
 		userID          string
+		viewerID        string
 		prepareMockPost func(ctx context.Context, uid string, post *mock.MockPost)
+		prepareMockStar func(ctx context.Context, star *mock.MockStar)
 		wantErr         bool
 		wantCode        int
 		wantBody        string
@@ -281,7 +294,7 @@ func TestUserController_GetPosts(t *testing.T) {
 			name:   "正しく投稿を取得できる",
 			userID: "user-id",
 			prepareMockPost: func(ctx context.Context, uid string, post *mock.MockPost) {
-				post.EXPECT().FindByUserID(ctx, uid).Return([]*entity.Post{
+				post.EXPECT().FindByUserID(ctx, uid, false, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{
 					{
 						ID:        1,
 						UserID:    "user-id",
@@ -306,20 +319,164 @@ func TestUserController_GetPosts(t *testing.T) {
 					},
 				}, nil)
 			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantErr:         false,
+			wantCode:        http.StatusOK,
+			wantBody: `{"posts":[{"id":1,"user_id":"user-id","title":"test title","code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}","language":"Go","content":"Test code","source":"github.com","star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"},{"id":2,"user_id":"user-id","title":"test title","code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}","language":"Go","content":"Test code","source":"github.com","star_count":0,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}
+`,
+		},
+		{
+			name:   "1つも投稿が存在しなくても空のページを返す",
+			userID: "user-id2",
+			prepareMockPost: func(ctx context.Context, uid string, post *mock.MockPost) {
+				post.EXPECT().FindByUserID(ctx, uid, false, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{}, nil)
+			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantErr:         false,
+			wantCode:        http.StatusOK,
+			wantBody:        `{"posts":[],"next_cursor":""}`,
+		},
+		{
+			name:     "本人なら限定公開と非公開の投稿も取得する",
+			userID:   "user-id3",
+			viewerID: "user-id3",
+			prepareMockPost: func(ctx context.Context, uid string, post *mock.MockPost) {
+				post.EXPECT().FindByUserID(ctx, uid, true, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{
+					{
+						ID:         3,
+						UserID:     "user-id3",
+						Title:      "private",
+						Code:       "package main",
+						Language:   "Go",
+						Visibility: entity.PostVisibilityPrivate,
+						CreatedAt:  "2021-03-23T11:42:56+09:00",
+						UpdatedAt:  "2021-03-23T11:42:56+09:00",
+					},
+				}, nil)
+			},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {
+				star.EXPECT().FindStarredPostIDs(ctx, "user-id3", []int{3}).Return([]int{}, nil)
+			},
 			wantErr:  false,
 			wantCode: http.StatusOK,
-			wantBody: `[{"id":1,"user_id":"user-id","title":"test title","code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}","language":"Go","content":"Test code","source":"github.com","created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"},{"id":2,"user_id":"user-id","title":"test title","code":"package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}","language":"Go","content":"Test code","source":"github.com","created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}]
+			wantBody: `{"posts":[{"id":3,"user_id":"user-id3","title":"private","code":"package main","language":"Go","content":"","source":"","visibility":"private","star_count":0,"fork_count":0,"starred":false,"editable":true,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}
 `,
 		},
 		{
-			name:   "1つも投稿が存在しないならErrUserNotFound",
+			name:            "userIDが空ならBadRequest",
+			userID:          "",
+			prepareMockPost: func(ctx context.Context, uid string, post *mock.MockPost) {},
+			prepareMockStar: func(ctx context.Context, star *mock.MockStar) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+			wantBody:        ``,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest("GET", "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := c.Request().Context()
+			authRepo := mock.NewMockAuth(ctrl)
+			userRepo := mock.NewMockUser(ctrl)
+			postRepo := mock.NewMockPost(ctrl)
+			tt.prepareMockPost(ctx, tt.userID, postRepo)
+			commentRepo := mock.NewMockComment(ctrl)
+
+			starRepo := mock.NewMockStar(ctrl)
+			tt.prepareMockStar(ctx, starRepo)
+			con := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo, starRepo))
+			c.SetParamNames("userID")
+			c.SetParamValues(tt.userID)
+			if len(tt.viewerID) > 0 {
+				c.Set("userID", tt.viewerID)
+			}
+			err := con.GetPosts(c)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+
+			if he, ok := err.(*echo.HTTPError); ok {
+				if he.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", he.Code, tt.wantCode)
+				}
+			} else {
+				if rec.Code != tt.wantCode {
+					t.Errorf("code = %d, want = %d", rec.Code, tt.wantCode)
+				}
+			}
+
+			if !tt.wantErr {
+				var gotBody, wantBody map[string]interface{}
+				if err = json.Unmarshal(rec.Body.Bytes(), &gotBody); err != nil {
+					t.Fatal(err)
+				}
+				if err = json.Unmarshal([]byte(tt.wantBody), &wantBody); err != nil {
+					t.Fatal(err)
+				}
+
+				if diff := cmp.Diff(wantBody, gotBody); diff != "" {
+					t.Errorf("body (-want +got) =\n%s\n", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUserController_GetStarredPosts(t *testing.T) {
+	tests := []struct {
+		name            string
+		userID          string
+		prepareMockPost func(ctx context.Context, uid string, post *mock.MockPost)
+		wantErr         bool
+		wantCode        int
+		wantBody        string
+	}{
+		{
+			name:   "スターをつけた投稿を取得できる",
+			userID: "user-id",
+			prepareMockPost: func(ctx context.Context, uid string, post *mock.MockPost) {
+				post.EXPECT().GetAll(ctx, &entity.PostFilter{StarredBy: uid}, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{
+					{
+						ID:        3,
+						UserID:    "other-user-id",
+						Title:     "test title",
+						Code:      "code",
+						Language:  "go",
+						StarCount: 2,
+						CreatedAt: "2021-03-23T11:42:56+09:00",
+						UpdatedAt: "2021-03-23T11:42:56+09:00",
+					},
+				}, nil)
+			},
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"posts":[{"id":3,"user_id":"other-user-id","title":"test title","code":"code","language":"go","content":"","source":"","star_count":2,"fork_count":0,"created_at":"2021-03-23T11:42:56+09:00","updated_at":"2021-03-23T11:42:56+09:00"}],"next_cursor":""}`,
+		},
+		{
+			name:   "スターをつけた投稿がなければ空のページを返す",
 			userID: "user-id2",
 			prepareMockPost: func(ctx context.Context, uid string, post *mock.MockPost) {
-				post.EXPECT().FindByUserID(ctx, uid).Return(nil, entity.NewErrorNotFound("post"))
+				post.EXPECT().GetAll(ctx, &entity.PostFilter{StarredBy: uid}, nil, entity.DefaultPageLimit+1).Return([]*entity.Post{}, nil)
 			},
-			wantErr:  true,
-			wantCode: http.StatusNotFound,
-			wantBody: ``,
+			wantErr:  false,
+			wantCode: http.StatusOK,
+			wantBody: `{"posts":[],"next_cursor":""}`,
+		},
+		{
+			name:            "userIDが空ならBadRequest",
+			userID:          "",
+			prepareMockPost: func(ctx context.Context, uid string, post *mock.MockPost) {},
+			wantErr:         true,
+			wantCode:        http.StatusBadRequest,
+			wantBody:        ``,
 		},
 	}
 
@@ -339,10 +496,11 @@ func TestUserController_GetPosts(t *testing.T) {
 			tt.prepareMockPost(ctx, tt.userID, postRepo)
 			commentRepo := mock.NewMockComment(ctrl)
 
-			con := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo))
+			starRepo := mock.NewMockStar(ctrl)
+			con := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo, starRepo))
 			c.SetParamNames("userID")
 			c.SetParamValues(tt.userID)
-			err := con.GetPosts(c)
+			err := con.GetStarredPosts(c)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
@@ -359,7 +517,7 @@ func TestUserController_GetPosts(t *testing.T) {
 			}
 
 			if !tt.wantErr {
-				var gotBody, wantBody []map[string]interface{}
+				var gotBody, wantBody map[string]interface{}
 				if err = json.Unmarshal(rec.Body.Bytes(), &gotBody); err != nil {
 					t.Fatal(err)
 				}
@@ -489,7 +647,8 @@ func TestUserController_Create(t *testing.T) {
 			postRepo := mock.NewMockPost(ctrl)
 			commentRepo := mock.NewMockComment(ctrl)
 
-			con := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo))
+			starRepo := mock.NewMockStar(ctrl)
+			con := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo, starRepo))
 			err := con.Create(c)
 
 			if (err != nil) != tt.wantErr {
@@ -637,7 +796,8 @@ func TestUserController_Update(t *testing.T) {
 			postRepo := mock.NewMockPost(ctrl)
 			commentRepo := mock.NewMockComment(ctrl)
 
-			con := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo))
+			starRepo := mock.NewMockStar(ctrl)
+			con := NewUserController(usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo, starRepo))
 			err := con.Update(c)
 
 			if (err != nil) != tt.wantErr {
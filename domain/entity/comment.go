@@ -2,18 +2,93 @@ package entity
 
 // Comment は投稿に紐づくコメント情報を表します
 type Comment struct {
-	ID        int    `json:"id"`
-	UserID    string `json:"user_id"`
-	PostID    int    `json:"post_id"`
-	Type      string `json:"type"`
-	Content   string `json:"content"`
-	FirstLine int    `json:"first_line"`
-	LastLine  int    `json:"last_line"`
-	Code      string `json:"code"`
+	ID     int    `json:"id"`
+	UserID string `json:"user_id"`
+	PostID int    `json:"post_id"`
+	// ParentID が0でなければ，同じ投稿のParentIDのコメントへの返信です
+	ParentID int `json:"parent_id,omitempty"`
+	// Type はhighlight, commit, suggestion, noneのいずれかで，suggestionはFirstLineからLastLineまでをCodeで置き換える提案です
+	Type string `json:"type"`
+	// Filename はReferencesFileなコメントが指す，複数のファイルからなる投稿のファイル名です
+	Filename string `json:"filename,omitempty"`
+	Content  string `json:"content"`
+	// Mentions はContentの中でメンションされた実在するユーザの一覧です
+	Mentions  []*Mention `json:"mentions,omitempty"`
+	FirstLine int        `json:"first_line"`
+	LastLine  int        `json:"last_line"`
+	// FirstColumn, LastColumn は1から始まる文字単位の列で，0なら行全体をハイライトします
+	FirstColumn int    `json:"first_column,omitempty"`
+	LastColumn  int    `json:"last_column,omitempty"`
+	Code        string `json:"code"`
+	// Revision はcommitコメントによって作られたリビジョンの番号です
+	Revision int `json:"revision,omitempty"`
+	// BaseRevision はFirstLine, LastLineが指しているリビジョンの番号です
+	BaseRevision int `json:"base_revision,omitempty"`
+	// AppliedRevision はsuggestionコメントを投稿のオーナーが適用して作られたリビジョンの番号です
+	AppliedRevision int `json:"applied_revision,omitempty"`
+	// AuthorID はsuggestionコメントを適用して作られたcommitコメントで，変更を提案したユーザのIDです
+	AuthorID   string               `json:"author_id,omitempty"`
+	Projection *HighlightProjection `json:"projection,omitempty"`
+	// Reactions はコメントについた絵文字ごとのリアクションの数です
+	Reactions []*ReactionCount `json:"reactions,omitempty"`
+	// Resolved はスレッドの最初のコメントにだけつき，ResolvedByのユーザがResolvedAtに解決済みにしたことを表します
+	Resolved   bool   `json:"resolved,omitempty"`
+	ResolvedBy string `json:"resolved_by,omitempty"`
+	ResolvedAt string `json:"resolved_at,omitempty"`
+	// Accepted は投稿のオーナーが回答として採用したコメントかどうかで，保存はされません
+	Accepted bool `json:"accepted,omitempty"`
+	// Editable は閲覧しているユーザがコメントを編集できるかどうかで，未ログインの場合はnilです
+	Editable *bool `json:"editable,omitempty"`
+	// Deleted がtrueのコメントは内容を消して残された墓標です
+	Deleted   bool   `json:"deleted,omitempty"`
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
 
+// HasLineRange はFirstLine, LastLineでBaseRevisionのコードの行範囲を指すコメントかどうかを返します
+func (c *Comment) HasLineRange() bool {
+	return c.Type == "highlight" || c.Type == "suggestion"
+}
+
+// ReferencesFile はFilenameで投稿のファイルを指すコメントかどうかを返します
+func (c *Comment) ReferencesFile() bool {
+	return c.HasLineRange() || c.Type == "commit"
+}
+
+// IsEditableBy はuserIDのユーザがコメントを編集，削除できるかを返します
+// 編集できるのはコメントした人だけで，墓標になったコメントは誰も編集できません
+func (c *Comment) IsEditableBy(userID string) bool {
+	return len(userID) > 0 && c.UserID == userID && !c.Deleted
+}
+
+// PinAcceptedComment は回答として採用されたコメントのAcceptedをtrueにして先頭に移します
+// 他のコメントの順序は保ち，acceptedIDのコメントが含まれていなければそのまま返します
+func PinAcceptedComment(comments []*Comment, acceptedID int) []*Comment {
+	if acceptedID == 0 {
+		return comments
+	}
+	for i, comment := range comments {
+		if comment.ID != acceptedID {
+			continue
+		}
+		comment.Accepted = true
+		pinned := make([]*Comment, 0, len(comments))
+		pinned = append(pinned, comment)
+		pinned = append(pinned, comments[:i]...)
+		return append(pinned, comments[i+1:]...)
+	}
+	return comments
+}
+
+// HighlightProjection はhighlightコメントの行範囲を別のリビジョンのコードに投影した結果です
+// 範囲内の行が全て削除されていた場合はOutdatedがtrueになり，行番号は0になります
+type HighlightProjection struct {
+	Revision  int  `json:"revision"`
+	FirstLine int  `json:"first_line,omitempty"`
+	LastLine  int  `json:"last_line,omitempty"`
+	Outdated  bool `json:"outdated"`
+}
+
 // IsValid はCommentのバリデーションを行うメソッドです
 func (c *Comment) IsValid() error {
 	if c.ID < 0 {
@@ -28,6 +103,15 @@ func (c *Comment) IsValid() error {
 	if c.PostID == 0 {
 		return NewErrorEmpty("comment PostID")
 	}
+	if c.ParentID < 0 {
+		return NewErrorNegativeValue("comment ParentID")
+	}
+	if c.ParentID != 0 && c.ParentID == c.ID {
+		return ErrInvalidParentComment
+	}
+	if len([]rune(c.Filename)) > MaxFilenameLength {
+		return NewErrorTooLong("comment Filename")
+	}
 	// Typeに応じて必要なフィールドが含まれていなかったらエラー
 	switch c.Type {
 	case "none":
@@ -44,14 +128,50 @@ func (c *Comment) IsValid() error {
 		if c.LastLine <= 0 {
 			return NewErrorEmpty("comment LastLine")
 		}
+		if c.FirstLine > c.LastLine {
+			return NewErrorOutOfRange("comment LastLine")
+		}
+		if c.FirstColumn < 0 {
+			return NewErrorNegativeValue("comment FirstColumn")
+		}
+		if c.LastColumn < 0 {
+			return NewErrorNegativeValue("comment LastColumn")
+		}
+		if c.FirstLine == c.LastLine && c.FirstColumn != 0 && c.LastColumn != 0 && c.FirstColumn > c.LastColumn {
+			return NewErrorOutOfRange("comment LastColumn")
+		}
+		if c.BaseRevision < 0 {
+			return NewErrorNegativeValue("comment BaseRevision")
+		}
 	case "commit":
 		// Contentは空でも良い
 		// Codeが空ならエラー
 		if len(c.Code) == 0 {
 			return NewErrorEmpty("comment Code")
 		}
+	case "suggestion":
+		// ContentとCodeは空でも良い(Codeが空なら行を削除する提案になる)
+		// 行単位で置き換えるので列は指定できない
+		if c.FirstLine <= 0 {
+			return NewErrorEmpty("comment FirstLine")
+		}
+		if c.LastLine <= 0 {
+			return NewErrorEmpty("comment LastLine")
+		}
+		if c.FirstLine > c.LastLine {
+			return NewErrorOutOfRange("comment LastLine")
+		}
+		if c.FirstColumn != 0 {
+			return NewErrorOutOfRange("comment FirstColumn")
+		}
+		if c.LastColumn != 0 {
+			return NewErrorOutOfRange("comment LastColumn")
+		}
+		if c.BaseRevision < 0 {
+			return NewErrorNegativeValue("comment BaseRevision")
+		}
 	default:
-		// none,highlight,commit以外の文字列の場合
+		// none,highlight,commit,suggestion以外の文字列の場合
 		return ErrInvalidCommentType
 	}
 	return nil
@@ -0,0 +1,27 @@
+package entity
+
+const (
+	// CommentEventCreated はコメントが作成されたことを表すイベントの種類です
+	CommentEventCreated = "created"
+	// CommentEventUpdated はコメントが更新されたことを表すイベントの種類です
+	CommentEventUpdated = "updated"
+	// CommentEventDeleted はコメントが削除されたことを表すイベントの種類です
+	CommentEventDeleted = "deleted"
+)
+
+// CommentEvent は投稿についているコメントの変更を購読者に伝えるイベントです
+// Typeがdeletedの場合，CommentにはIDとPostIDなど削除時に分かっている値だけが入っています
+type CommentEvent struct {
+	Type    string   `json:"type"`
+	PostID  int      `json:"post_id"`
+	Comment *Comment `json:"comment"`
+}
+
+// NewCommentEvent はコメントの変更を表すCommentEventのポインタを生成する関数です
+func NewCommentEvent(eventType string, comment *Comment) *CommentEvent {
+	return &CommentEvent{
+		Type:    eventType,
+		PostID:  comment.PostID,
+		Comment: comment,
+	}
+}
@@ -3,6 +3,8 @@ package entity
 import (
 	"strings"
 	"testing"
+
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestComment_IsValid(t *testing.T) {
@@ -89,6 +91,30 @@ func TestComment_IsValid(t *testing.T) {
 			},
 			wantErr: NewErrorEmpty("comment PostID"),
 		},
+		{
+			name: "ParentIDがマイナスならエラー",
+			comment: &Comment{
+				ID:       1,
+				UserID:   "user-id",
+				PostID:   1,
+				ParentID: -1,
+				Type:     "none",
+				Content:  "type none",
+			},
+			wantErr: NewErrorNegativeValue("comment ParentID"),
+		},
+		{
+			name: "自分自身への返信ならエラー",
+			comment: &Comment{
+				ID:       1,
+				UserID:   "user-id",
+				PostID:   1,
+				ParentID: 1,
+				Type:     "none",
+				Content:  "type none",
+			},
+			wantErr: ErrInvalidParentComment,
+		},
 		{
 			name: "TypeがnoneなのにContentが空ならエラー",
 			comment: &Comment{
@@ -111,6 +137,59 @@ func TestComment_IsValid(t *testing.T) {
 			},
 			wantErr: NewErrorEmpty("comment FirstLine"),
 		},
+		{
+			name: "highlightのFirstLineがLastLineより後ならエラー",
+			comment: &Comment{
+				ID:        1,
+				UserID:    "user-id",
+				PostID:    1,
+				Type:      "highlight",
+				FirstLine: 12,
+				LastLine:  10,
+			},
+			wantErr: NewErrorOutOfRange("comment LastLine"),
+		},
+		{
+			name: "highlightの1行内でFirstColumnがLastColumnより後ならエラー",
+			comment: &Comment{
+				ID:          1,
+				UserID:      "user-id",
+				PostID:      1,
+				Type:        "highlight",
+				FirstLine:   10,
+				LastLine:    10,
+				FirstColumn: 5,
+				LastColumn:  3,
+			},
+			wantErr: NewErrorOutOfRange("comment LastColumn"),
+		},
+		{
+			name: "highlightの複数行ならFirstColumnがLastColumnより大きくても良い",
+			comment: &Comment{
+				ID:          1,
+				UserID:      "user-id",
+				PostID:      1,
+				Type:        "highlight",
+				FirstLine:   10,
+				LastLine:    11,
+				FirstColumn: 5,
+				LastColumn:  3,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "highlightのFirstColumnがマイナスならエラー",
+			comment: &Comment{
+				ID:          1,
+				UserID:      "user-id",
+				PostID:      1,
+				Type:        "highlight",
+				FirstLine:   10,
+				LastLine:    10,
+				FirstColumn: -1,
+			},
+			wantErr: NewErrorNegativeValue("comment FirstColumn"),
+		},
 		{
 			name: "TypeがcommitなのにCodeが空ならエラー",
 			comment: &Comment{
@@ -124,7 +203,59 @@ func TestComment_IsValid(t *testing.T) {
 			wantErr: NewErrorEmpty("comment Code"),
 		},
 		{
-			name: "Typeがnone,highlight,commitでなかったらエラー",
+			name: "suggestionに問題なければnilを返す",
+			comment: &Comment{
+				ID:        1,
+				UserID:    "user-id",
+				PostID:    1,
+				Type:      "suggestion",
+				FirstLine: 2,
+				LastLine:  3,
+				Code:      "replaced",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "suggestionのCodeが空でも行の削除としてnilを返す",
+			comment: &Comment{
+				ID:        1,
+				UserID:    "user-id",
+				PostID:    1,
+				Type:      "suggestion",
+				FirstLine: 2,
+				LastLine:  2,
+			},
+			wantErr: nil,
+		},
+		{
+			name: "suggestionの範囲が逆転していればエラー",
+			comment: &Comment{
+				ID:        1,
+				UserID:    "user-id",
+				PostID:    1,
+				Type:      "suggestion",
+				FirstLine: 3,
+				LastLine:  2,
+				Code:      "replaced",
+			},
+			wantErr: NewErrorOutOfRange("comment LastLine"),
+		},
+		{
+			name: "suggestionで列を指定したらエラー",
+			comment: &Comment{
+				ID:          1,
+				UserID:      "user-id",
+				PostID:      1,
+				Type:        "suggestion",
+				FirstLine:   2,
+				LastLine:    2,
+				FirstColumn: 1,
+				Code:        "replaced",
+			},
+			wantErr: NewErrorOutOfRange("comment FirstColumn"),
+		},
+		{
+			name: "Typeがnone,highlight,commit,suggestionでなかったらエラー",
 			comment: &Comment{
 				ID:      1,
 				UserID:  "user-id",
@@ -147,3 +278,87 @@ func TestComment_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestPinAcceptedComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		acceptedID int
+		wantIDs    []int
+		wantPinned bool
+	}{
+		{
+			name:       "採用されたコメントが先頭になり，他の順序は保たれる",
+			acceptedID: 3,
+			wantIDs:    []int{3, 1, 2, 4},
+			wantPinned: true,
+		},
+		{
+			name:       "採用されていなければそのまま",
+			acceptedID: 0,
+			wantIDs:    []int{1, 2, 3, 4},
+		},
+		{
+			name:       "採用されたコメントが含まれていなければそのまま",
+			acceptedID: 5,
+			wantIDs:    []int{1, 2, 3, 4},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			comments := []*Comment{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}}
+			got := PinAcceptedComment(comments, tt.acceptedID)
+
+			gotIDs := make([]int, 0, len(got))
+			for _, comment := range got {
+				gotIDs = append(gotIDs, comment.ID)
+			}
+			if diff := cmp.Diff(tt.wantIDs, gotIDs); diff != "" {
+				t.Errorf("IDs (-want +got) =\n%s\n", diff)
+			}
+			if got[0].Accepted != tt.wantPinned {
+				t.Errorf("Accepted = %v, want = %v", got[0].Accepted, tt.wantPinned)
+			}
+		})
+	}
+}
+
+func TestComment_IsEditableBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		userID  string
+		deleted bool
+		want    bool
+	}{
+		{
+			name:   "コメントした人は編集できる",
+			userID: "author",
+			want:   true,
+		},
+		{
+			name:   "コメントした人以外は編集できない",
+			userID: "other",
+			want:   false,
+		},
+		{
+			name:   "未ログインでは編集できない",
+			userID: "",
+			want:   false,
+		},
+		{
+			name:    "墓標になったコメントは編集できない",
+			userID:  "author",
+			deleted: true,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			comment := &Comment{UserID: "author", Deleted: tt.deleted}
+			if got := comment.IsEditableBy(tt.userID); got != tt.want {
+				t.Errorf("IsEditableBy() = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
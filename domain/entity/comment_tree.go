@@ -0,0 +1,59 @@
+package entity
+
+// CommentNode はコメントのツリーにおける1つのノードを表します
+type CommentNode struct {
+	*Comment
+	Replies []*CommentNode `json:"replies"`
+}
+
+// NewCommentTree は同じ投稿に属するコメントのスライスから返信のツリーを組み立てます
+// 返信先が見つからないコメントはルートとして扱います
+// 兄弟ノードの順序は引数のスライスの順序を保ちます
+func NewCommentTree(comments []*Comment) []*CommentNode {
+	nodes := make(map[int]*CommentNode, len(comments))
+	for _, comment := range comments {
+		nodes[comment.ID] = &CommentNode{
+			Comment: comment,
+			Replies: []*CommentNode{},
+		}
+	}
+
+	roots := []*CommentNode{}
+	for _, comment := range comments {
+		node := nodes[comment.ID]
+		parent, ok := nodes[comment.ParentID]
+		if comment.ParentID == 0 || !ok {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Replies = append(parent.Replies, node)
+	}
+	return roots
+}
+
+// FilterCommentsByResolved はスレッドが解決済みかどうかでコメントを絞り込みます
+// 返信はスレッドの最初のコメントの状態に従い，返信先が見つからないコメントはスレッドの最初のコメントとして扱います
+// コメントの順序は引数のスライスの順序を保ちます
+func FilterCommentsByResolved(comments []*Comment, resolved bool) []*Comment {
+	byID := make(map[int]*Comment, len(comments))
+	for _, comment := range comments {
+		byID[comment.ID] = comment
+	}
+
+	filtered := []*Comment{}
+	for _, comment := range comments {
+		root := comment
+		// 返信の循環があっても止まるように，辿る回数はコメントの数までにする
+		for i := 0; i < len(comments) && root.ParentID != 0; i++ {
+			parent, ok := byID[root.ParentID]
+			if !ok {
+				break
+			}
+			root = parent
+		}
+		if root.Resolved == resolved {
+			filtered = append(filtered, comment)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,95 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewCommentTree(t *testing.T) {
+	root := &Comment{ID: 1, PostID: 1, Type: "none", Content: "root"}
+	reply := &Comment{ID: 2, PostID: 1, ParentID: 1, Type: "none", Content: "reply"}
+	nested := &Comment{ID: 3, PostID: 1, ParentID: 2, Type: "none", Content: "nested"}
+	orphan := &Comment{ID: 5, PostID: 1, ParentID: 4, Type: "none", Content: "orphan"}
+
+	tests := []struct {
+		name     string
+		comments []*Comment
+		want     []*CommentNode
+	}{
+		{
+			name:     "返信が親コメントの下にぶら下がる",
+			comments: []*Comment{root, reply, nested},
+			want: []*CommentNode{
+				{
+					Comment: root,
+					Replies: []*CommentNode{
+						{
+							Comment: reply,
+							Replies: []*CommentNode{
+								{Comment: nested, Replies: []*CommentNode{}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:     "返信先が見つからないコメントはルートになる",
+			comments: []*Comment{root, orphan},
+			want: []*CommentNode{
+				{Comment: root, Replies: []*CommentNode{}},
+				{Comment: orphan, Replies: []*CommentNode{}},
+			},
+		},
+		{
+			name:     "コメントがなければ空のスライスを返す",
+			comments: nil,
+			want:     []*CommentNode{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCommentTree(tt.comments)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Data (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestFilterCommentsByResolved(t *testing.T) {
+	resolved := &Comment{ID: 1, PostID: 1, Type: "highlight", Resolved: true}
+	resolvedReply := &Comment{ID: 2, PostID: 1, ParentID: 1, Type: "none"}
+	open := &Comment{ID: 3, PostID: 1, Type: "highlight"}
+	openNested := &Comment{ID: 5, PostID: 1, ParentID: 4, Type: "none"}
+	openReply := &Comment{ID: 4, PostID: 1, ParentID: 3, Type: "none"}
+	comments := []*Comment{resolved, resolvedReply, open, openNested, openReply}
+
+	tests := []struct {
+		name     string
+		resolved bool
+		want     []*Comment
+	}{
+		{
+			name:     "解決済みのスレッドのコメントは返信も含めて残る",
+			resolved: true,
+			want:     []*Comment{resolved, resolvedReply},
+		},
+		{
+			name:     "未解決のスレッドのコメントは返信の返信も含めて残る",
+			resolved: false,
+			want:     []*Comment{open, openNested, openReply},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := FilterCommentsByResolved(comments, tt.resolved)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Data (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package entity
+
+const (
+	// DiffLineContext は変更のない行を表します
+	DiffLineContext = "context"
+	// DiffLineAdd は追加された行を表します
+	DiffLineAdd = "add"
+	// DiffLineDelete は削除された行を表します
+	DiffLineDelete = "delete"
+)
+
+// Diff は投稿の2つのリビジョン間のコードの差分を表します
+type Diff struct {
+	PostID int `json:"post_id"`
+	// Filename は差分を取ったファイルの名前で，先頭のファイルを対象にした場合は空です
+	Filename string      `json:"filename,omitempty"`
+	From     int         `json:"from"`
+	To       int         `json:"to"`
+	Unified  string      `json:"unified"`
+	Hunks    []*DiffHunk `json:"hunks"`
+}
+
+// DiffHunk は差分のうち，連続した変更とその前後の行のまとまりを表します
+// 行番号は1から始まり，行数が0の場合はその直前の行番号になります
+type DiffHunk struct {
+	OldStart int         `json:"old_start"`
+	OldLines int         `json:"old_lines"`
+	NewStart int         `json:"new_start"`
+	NewLines int         `json:"new_lines"`
+	Lines    []*DiffLine `json:"lines"`
+}
+
+// DiffLine は差分の1行を表します
+// 追加された行はOldLineが，削除された行はNewLineが0になります
+type DiffLine struct {
+	Type    string `json:"type"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+	Content string `json:"content"`
+}
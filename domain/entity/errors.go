@@ -20,6 +20,60 @@ var (
 	ErrCannotCommit = errors.New("non-post-owner cannot commit")
 	// ErrIsNotAuthor はユーザがAuthorではないことが原因で生じたエラー
 	ErrIsNotAuthor = errors.New("user is not the author")
+	// ErrCommitImmutable はcommitコメントの種類，ファイル，コードを変えたり，他の種類のコメントをcommitにしたりしようとしたときのエラー
+	ErrCommitImmutable = errors.New("type, file and code of a commit cannot be changed")
+	// ErrAppliedSuggestionImmutable は適用済みのsuggestionコメントの種類，内容，行範囲，ファイル，コードを変えようとしたときのエラー
+	ErrAppliedSuggestionImmutable = errors.New("an applied suggestion cannot be changed")
+	// ErrInvalidParentComment は返信先のコメントとして指定できないコメントだったときのエラー
+	ErrInvalidParentComment = errors.New("invalid parent comment")
+	// ErrInvalidCursor はページングのカーソルが不正な値だったときのエラー
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidPageLimit はページングのlimitが正の整数でなかったときのエラー
+	ErrInvalidPageLimit = errors.New("limit must be a positive integer")
+	// ErrInvalidRevision はリビジョン番号が正の整数でなかったときのエラー
+	ErrInvalidRevision = errors.New("revision must be a positive integer")
+	// ErrInvalidTagName はタグ名が空，長すぎる，または使えない文字を含むときのエラー
+	ErrInvalidTagName = errors.New("invalid tag name")
+	// ErrTooManyTags は投稿につけたタグが多すぎるときのエラー
+	ErrTooManyTags = errors.New("too many tags")
+	// ErrInvalidPostFile は投稿のファイルのファイル名が空か重複している，または言語や内容が空のときのエラー
+	ErrInvalidPostFile = errors.New("invalid post file")
+	// ErrTooManyPostFiles は投稿に含めたファイルが多すぎるときのエラー
+	ErrTooManyPostFiles = errors.New("too many post files")
+	// ErrUnknownLanguage は登録されていない言語が指定されたときのエラー
+	ErrUnknownLanguage = errors.New("unknown language")
+	// ErrCannotFollowSelf は自分自身をフォローしようとしたときのエラー
+	ErrCannotFollowSelf = errors.New("cannot follow yourself")
+	// ErrUnknownReaction はリアクションとしてつけられない絵文字が指定されたときのエラー
+	ErrUnknownReaction = errors.New("unknown reaction emoji")
+	// ErrCannotResolve は投稿のオーナーとコメントした人以外がスレッドを解決済みにしようとしたときのエラー
+	ErrCannotResolve = errors.New("only the post owner or the comment author can resolve the thread")
+	// ErrCannotResolveReply はスレッドの最初のコメントではない返信を解決済みにしようとしたときのエラー
+	ErrCannotResolveReply = errors.New("cannot resolve a reply")
+	// ErrInvalidPostStatus は投稿の状態として正しくない値が指定されたときのエラー
+	ErrInvalidPostStatus = errors.New("invalid post status")
+	// ErrInvalidStatusTransition は投稿の状態を許されていない状態に変えようとしたときのエラー
+	ErrInvalidStatusTransition = errors.New("invalid post status transition")
+	// ErrInvalidPostVisibility は投稿の公開範囲として正しくない値が指定されたときのエラー
+	ErrInvalidPostVisibility = errors.New("invalid post visibility")
+	// ErrPostAlreadyPublished は既に公開されている投稿を公開しようとしたときのエラー
+	ErrPostAlreadyPublished = errors.New("post is already published")
+	// ErrPostCodeCommitted はcommitのある投稿のコードを更新で書き換えようとしたときのエラー
+	ErrPostCodeCommitted = errors.New("cannot change the code of a post that has commits")
+	// ErrPostArchived はアーカイブされた投稿にコメントしようとしたときのエラー
+	ErrPostArchived = errors.New("cannot comment on an archived post")
+	// ErrCannotApplySuggestion はPostのオーナー以外がsuggestionコメントを適用しようとしたときのエラー
+	ErrCannotApplySuggestion = errors.New("only the post owner can apply a suggestion")
+	// ErrNotSuggestion はsuggestionではないコメントを適用しようとしたときのエラー
+	ErrNotSuggestion = errors.New("comment is not a suggestion")
+	// ErrSuggestionApplied は既に適用したsuggestionコメントをもう一度適用しようとしたときのエラー
+	ErrSuggestionApplied = errors.New("suggestion has already been applied")
+	// ErrDiffTooLarge は差分を取るコードの行数や変更が多すぎるときのエラー
+	ErrDiffTooLarge = errors.New("diff is too large")
+	// ErrSuggestionOutdated はsuggestionコメントが指す行が最新のリビジョンで変わっていて適用できないときのエラー
+	ErrSuggestionOutdated = errors.New("suggested lines have changed in the latest revision")
+	// ErrSuggestionEmptiesCode はsuggestionコメントを適用するとファイルのコードが空になるときのエラー
+	ErrSuggestionEmptiesCode = errors.New("applying the suggestion would leave the code empty")
 )
 
 // ErrTooLong はフィールドの内容が長すぎるときのエラー
@@ -37,6 +91,11 @@ type ErrNegativeValue struct {
 	fieldName string
 }
 
+// ErrOutOfRange はフィールドの値が許される範囲の外にあるときのエラー
+type ErrOutOfRange struct {
+	fieldName string
+}
+
 // ErrNotFound はエンティティが存在しないときのエラー
 type ErrNotFound struct {
 	entityName string
@@ -80,6 +139,17 @@ func (e ErrNegativeValue) Error() string {
 	return fmt.Sprintf("%s is negative value", e.fieldName)
 }
 
+// NewErrorOutOfRange はフィールドの値が範囲外のときのエラーを生成します
+func NewErrorOutOfRange(fieldName string) error {
+	return ErrOutOfRange{
+		fieldName: fieldName,
+	}
+}
+
+func (e ErrOutOfRange) Error() string {
+	return fmt.Sprintf("%s is out of range", e.fieldName)
+}
+
 // NewErrorNotFound はフィールド名が存在しないときのエラーを生成します
 func NewErrorNotFound(entityName string) error {
 	return ErrNotFound{
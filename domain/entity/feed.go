@@ -0,0 +1,46 @@
+package entity
+
+const (
+	// FeedItemPost はフォローしているユーザの新しい投稿を表すフィードの項目の種類です
+	FeedItemPost = "post"
+	// FeedItemCommit はフォローしているユーザのcommitコメントを表すフィードの項目の種類です
+	FeedItemCommit = "commit"
+)
+
+// FeedItem はフィードの1つの項目を表します
+// Typeがcommitの場合，Postはcommitコメントがついた投稿でCommentがそのcommitコメントです
+// Typeがpostの場合，Commentはnilです
+type FeedItem struct {
+	Type      string   `json:"type"`
+	Post      *Post    `json:"post"`
+	Comment   *Comment `json:"comment,omitempty"`
+	CreatedAt string   `json:"created_at"`
+}
+
+// FeedCursor はフィードをcreated_at, type, idの降順でページングするときの位置を表します
+// 投稿とコメントのidは重複しうるので，typeも含めて位置を決めます
+type FeedCursor struct {
+	CreatedAt string
+	Type      string
+	ID        int
+}
+
+// NewFeedCursor はフィードの項目の位置を指すFeedCursorのポインタを生成する関数です
+func NewFeedCursor(item *FeedItem) *FeedCursor {
+	id := item.Post.ID
+	if item.Type == FeedItemCommit {
+		id = item.Comment.ID
+	}
+	return &FeedCursor{
+		CreatedAt: item.CreatedAt,
+		Type:      item.Type,
+		ID:        id,
+	}
+}
+
+// FeedPage はページングされたフィードを表します
+// NextCursorが空の場合は次のページが存在しません
+type FeedPage struct {
+	Items      []*FeedItem `json:"items"`
+	NextCursor string      `json:"next_cursor"`
+}
@@ -0,0 +1,29 @@
+package entity
+
+// Follow はユーザが別のユーザをフォローしている関係を表します
+// FollowerIDのユーザがFolloweeIDのユーザをフォローしています
+type Follow struct {
+	FollowerID string `json:"follower_id"`
+	FolloweeID string `json:"followee_id"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// IsValid はFollowのバリデーションを行うメソッドです
+func (f *Follow) IsValid() error {
+	if len(f.FollowerID) == 0 {
+		return NewErrorEmpty("follow FollowerID")
+	}
+	if len(f.FolloweeID) == 0 {
+		return NewErrorEmpty("follow FolloweeID")
+	}
+	if len([]rune(f.FollowerID)) > 128 {
+		return NewErrorTooLong("follow FollowerID")
+	}
+	if len([]rune(f.FolloweeID)) > 128 {
+		return NewErrorTooLong("follow FolloweeID")
+	}
+	if f.FollowerID == f.FolloweeID {
+		return ErrCannotFollowSelf
+	}
+	return nil
+}
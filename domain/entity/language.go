@@ -0,0 +1,99 @@
+package entity
+
+import "strings"
+
+// Language は投稿のコードに使われるプログラミング言語を表します
+// IDは投稿のLanguageに保存される正規の識別子で，Nameは表示名です
+// Aliasesは正規化の際にIDとして扱う別名で，Extensionsはその言語のファイルの拡張子です
+type Language struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Aliases    []string `json:"aliases"`
+	Extensions []string `json:"extensions"`
+}
+
+// LanguageDetection はコードの内容から推定した言語のIDとその確信度を表します
+// Confidenceは0から1の値で，大きいほど推定が確かです
+type LanguageDetection struct {
+	Language   string  `json:"language"`
+	Confidence float64 `json:"confidence"`
+}
+
+// PlainTextLanguageID はどのプログラミング言語でもないテキストを表す言語のIDです
+const PlainTextLanguageID = "plaintext"
+
+// languages は扱う言語の一覧で，GET /languageはこの順番で返します
+var languages = []*Language{
+	{ID: "c", Name: "C", Aliases: []string{}, Extensions: []string{".c", ".h"}},
+	{ID: "cpp", Name: "C++", Aliases: []string{"cxx"}, Extensions: []string{".cpp", ".cc", ".cxx", ".hpp"}},
+	{ID: "csharp", Name: "C#", Aliases: []string{"cs"}, Extensions: []string{".cs"}},
+	{ID: "css", Name: "CSS", Aliases: []string{}, Extensions: []string{".css"}},
+	{ID: "dart", Name: "Dart", Aliases: []string{}, Extensions: []string{".dart"}},
+	{ID: "go", Name: "Go", Aliases: []string{"golang"}, Extensions: []string{".go"}},
+	{ID: "haskell", Name: "Haskell", Aliases: []string{"hs"}, Extensions: []string{".hs"}},
+	{ID: "html", Name: "HTML", Aliases: []string{}, Extensions: []string{".html", ".htm"}},
+	{ID: "java", Name: "Java", Aliases: []string{}, Extensions: []string{".java"}},
+	{ID: "javascript", Name: "JavaScript", Aliases: []string{"js", "node", "nodejs"}, Extensions: []string{".js", ".mjs", ".cjs", ".jsx"}},
+	{ID: "kotlin", Name: "Kotlin", Aliases: []string{"kt"}, Extensions: []string{".kt", ".kts"}},
+	{ID: "php", Name: "PHP", Aliases: []string{}, Extensions: []string{".php"}},
+	{ID: PlainTextLanguageID, Name: "Plain Text", Aliases: []string{"text", "txt", "plain"}, Extensions: []string{".txt"}},
+	{ID: "python", Name: "Python", Aliases: []string{"py", "python3"}, Extensions: []string{".py"}},
+	{ID: "ruby", Name: "Ruby", Aliases: []string{"rb"}, Extensions: []string{".rb"}},
+	{ID: "rust", Name: "Rust", Aliases: []string{"rs"}, Extensions: []string{".rs"}},
+	{ID: "scala", Name: "Scala", Aliases: []string{}, Extensions: []string{".scala"}},
+	{ID: "shell", Name: "Shell", Aliases: []string{"sh", "bash", "zsh"}, Extensions: []string{".sh", ".bash"}},
+	{ID: "sql", Name: "SQL", Aliases: []string{"mysql"}, Extensions: []string{".sql"}},
+	{ID: "swift", Name: "Swift", Aliases: []string{}, Extensions: []string{".swift"}},
+	{ID: "typescript", Name: "TypeScript", Aliases: []string{"ts"}, Extensions: []string{".ts", ".tsx"}},
+}
+
+// languageIndex はID，表示名，別名を小文字にしたものから言語を引くための索引です
+var languageIndex = func() map[string]*Language {
+	index := make(map[string]*Language)
+	for _, lang := range languages {
+		index[lang.ID] = lang
+		index[strings.ToLower(lang.Name)] = lang
+		for _, alias := range lang.Aliases {
+			index[alias] = lang
+		}
+	}
+	return index
+}()
+
+// Languages は扱う言語を全て返します
+func Languages() []*Language {
+	return languages
+}
+
+// FindLanguage はID，表示名，別名のいずれかが一致する言語を返します
+// 前後の空白と大文字小文字の違いは無視します
+func FindLanguage(name string) (*Language, bool) {
+	lang, ok := languageIndex[strings.ToLower(strings.TrimSpace(name))]
+	return lang, ok
+}
+
+// FindLanguageByExtension はファイルの拡張子(".go"など)からその言語を返します
+func FindLanguageByExtension(ext string) (*Language, bool) {
+	ext = strings.ToLower(ext)
+	for _, lang := range languages {
+		for _, e := range lang.Extensions {
+			if e == ext {
+				return lang, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// NormalizeLanguage は言語名を正規のIDに変換します
+// 空文字列はそのまま返し，登録されていない言語の場合はErrUnknownLanguageを返します
+func NormalizeLanguage(name string) (string, error) {
+	if len(strings.TrimSpace(name)) == 0 {
+		return "", nil
+	}
+	lang, ok := FindLanguage(name)
+	if !ok {
+		return "", ErrUnknownLanguage
+	}
+	return lang.ID, nil
+}
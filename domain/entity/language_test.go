@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		want     string
+		wantErr  error
+	}{
+		{
+			name:     "IDはそのまま",
+			language: "go",
+			want:     "go",
+		},
+		{
+			name:     "表示名は大文字小文字を区別せずIDになる",
+			language: " Go ",
+			want:     "go",
+		},
+		{
+			name:     "別名はIDになる",
+			language: "golang",
+			want:     "go",
+		},
+		{
+			name:     "記号を含む表示名もIDになる",
+			language: "C++",
+			want:     "cpp",
+		},
+		{
+			name:     "空文字列はそのまま",
+			language: "",
+			want:     "",
+		},
+		{
+			name:     "登録されていない言語はエラー",
+			language: "gollang",
+			wantErr:  ErrUnknownLanguage,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeLanguage(tt.language)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("got = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	seen := make(map[string]string)
+	for _, lang := range Languages() {
+		for _, name := range append([]string{lang.ID, lang.Name}, lang.Aliases...) {
+			got, ok := FindLanguage(name)
+			if !ok || got != lang {
+				t.Errorf("FindLanguage(%q) = %v, want = %s", name, got, lang.ID)
+			}
+		}
+		for _, ext := range lang.Extensions {
+			if other, ok := seen[ext]; ok {
+				t.Errorf("拡張子%sが%sと%sで重複している", ext, other, lang.ID)
+			}
+			seen[ext] = lang.ID
+			if got, ok := FindLanguageByExtension(ext); !ok || got != lang {
+				t.Errorf("FindLanguageByExtension(%q) = %v, want = %s", ext, got, lang.ID)
+			}
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package entity
+
+// MaxMentions は1つの本文の中で解決するメンションの最大数です
+// これを超えるメンションは無視されます
+const MaxMentions = 20
+
+// Mention は投稿やコメントの本文の中でメンションされたユーザを表します
+type Mention struct {
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+}
+
+// MentionUserIDs はメンションされたユーザのIDを順に返します
+func MentionUserIDs(mentions []*Mention) []string {
+	ids := make([]string, 0, len(mentions))
+	for _, mention := range mentions {
+		ids = append(ids, mention.UserID)
+	}
+	return ids
+}
@@ -0,0 +1,43 @@
+package entity
+
+const (
+	// NotificationComment は自分の投稿にコメントがついたことを表す通知の種類です
+	NotificationComment = "comment"
+	// NotificationHighlight は自分の投稿にhighlightコメントがついたことを表す通知の種類です
+	NotificationHighlight = "highlight"
+	// NotificationReply は自分のコメントに返信がついたことを表す通知の種類です
+	NotificationReply = "reply"
+	// NotificationMention は投稿やコメントの本文で自分がメンションされたことを表す通知の種類です
+	NotificationMention = "mention"
+)
+
+// Notification はユーザへの通知を表します
+// UserIDは通知を受け取るユーザ，ActorIDは通知のきっかけとなった投稿やコメントをしたユーザです
+// CommentIDが0の通知は投稿の本文でのメンションによるものです
+type Notification struct {
+	ID        int    `json:"id"`
+	UserID    string `json:"user_id"`
+	ActorID   string `json:"actor_id"`
+	Type      string `json:"type"`
+	PostID    int    `json:"post_id"`
+	CommentID int    `json:"comment_id,omitempty"`
+	Read      bool   `json:"read"`
+	CreatedAt string `json:"created_at"`
+}
+
+// NewNotificationCursor は通知の位置を指すCursorのポインタを生成する関数です
+func NewNotificationCursor(notification *Notification) *Cursor {
+	return &Cursor{
+		CreatedAt: notification.CreatedAt,
+		ID:        notification.ID,
+	}
+}
+
+// NotificationPage はページングされた通知一覧を表します
+// UnreadCountはページに関係なく，ユーザの未読の通知の総数です
+// NextCursorが空の場合は次のページが存在しません
+type NotificationPage struct {
+	Notifications []*Notification `json:"notifications"`
+	UnreadCount   int             `json:"unread_count"`
+	NextCursor    string          `json:"next_cursor"`
+}
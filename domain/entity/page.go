@@ -0,0 +1,42 @@
+package entity
+
+const (
+	// DefaultPageLimit はlimitが指定されなかったときの1ページあたりの件数です
+	DefaultPageLimit = 20
+	// MaxPageLimit は1ページあたりに取得できる最大件数です
+	MaxPageLimit = 100
+)
+
+// Cursor は一覧をcreated_atとidの降順でページングするときの位置を表します
+// カーソルが指す要素自体は次のページに含まれません
+type Cursor struct {
+	CreatedAt string
+	ID        int
+}
+
+// NewPostCursor は投稿の位置を指すCursorのポインタを生成する関数です
+func NewPostCursor(post *Post) *Cursor {
+	return &Cursor{
+		CreatedAt: post.CreatedAt,
+		ID:        post.ID,
+	}
+}
+
+// PostPage はページングされた投稿一覧を表します
+// NextCursorが空の場合は次のページが存在しません
+type PostPage struct {
+	Posts      []*Post `json:"posts"`
+	NextCursor string  `json:"next_cursor"`
+}
+
+// NormalizePageLimit はlimitを1以上MaxPageLimit以下に丸めます
+// 0以下の場合はDefaultPageLimitを返します
+func NormalizePageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultPageLimit
+	}
+	if limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return limit
+}
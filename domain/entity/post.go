@@ -6,13 +6,41 @@ import (
 
 // Post は投稿を表します
 type Post struct {
-	ID        int    `json:"id"`
-	UserID    string `json:"user_id"`
-	Title     string `json:"title"`
-	Code      string `json:"code"`
-	Language  string `json:"language"`
-	Content   string `json:"content"`
-	Source    string `json:"source"`
+	ID       int    `json:"id"`
+	UserID   string `json:"user_id"`
+	Title    string `json:"title"`
+	Code     string `json:"code"`
+	Language string `json:"language"`
+	// Files は複数のファイルからなる投稿のファイルで，先頭のファイルの内容と言語がCodeとLanguageになります
+	Files   []*PostFile `json:"files,omitempty"`
+	Content string      `json:"content"`
+	Source  string      `json:"source"`
+	// Status はPostStatusOpenなどの投稿の状態で，投稿の作成，更新では変えられません
+	Status string `json:"status,omitempty"`
+	// Visibility はPostVisibilityPublicなどの公開範囲で，作成時に省略するとpublic，更新時に省略すると変更しません
+	Visibility string `json:"visibility,omitempty"`
+	// Draft は下書きかどうかで，下書きはオーナーにしか見えず，更新では公開できません
+	Draft bool `json:"draft,omitempty"`
+	// ForkedFrom はフォーク元の投稿のIDで，フォークでない場合やフォーク元が削除された場合は0です
+	ForkedFrom int `json:"forked_from,omitempty"`
+	// AcceptedCommentID はオーナーが回答として採用したコメントのIDで，採用していなければ0です
+	AcceptedCommentID int `json:"accepted_comment_id,omitempty"`
+	// Tags はNormalizeTagsで正規化されたタグ名の一覧です
+	Tags []string `json:"tags,omitempty"`
+	// Mentions はContentの中でメンションされた実在するユーザの一覧です
+	Mentions []*Mention `json:"mentions,omitempty"`
+	// LanguageDetection はLanguageを省略して投稿したときに推定した言語の確信度で，保存はされません
+	LanguageDetection *LanguageDetection `json:"language_detection,omitempty"`
+	StarCount         int                `json:"star_count"`
+	// ForkCount は投稿をフォークした投稿のうち公開されているものの数です
+	ForkCount int `json:"fork_count"`
+	// Starred は閲覧しているユーザがスターをつけているかどうかで，未ログインの場合はnilです
+	Starred *bool `json:"starred,omitempty"`
+	// Editable は閲覧しているユーザが投稿を編集できるかどうかで，未ログインの場合はnilです
+	Editable *bool `json:"editable,omitempty"`
+	// Revision が0でない場合，CodeとFilesはその番号のリビジョンのコードです
+	Revision int `json:"revision,omitempty"`
+	// CreatedAt は下書きを公開すると公開した時刻になります
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
 }
@@ -46,6 +74,64 @@ func (p *Post) IsValid() error {
 	if len([]rune(p.Source)) > 2048 {
 		return NewErrorTooLong("post Source")
 	}
+	if len(p.Visibility) > 0 && !IsPostVisibility(p.Visibility) {
+		return ErrInvalidPostVisibility
+	}
+	if err := validateTags(p.Tags); err != nil {
+		return err
+	}
+	if err := validatePostFiles(p.Files); err != nil {
+		return err
+	}
 
 	return nil
 }
+
+// PostFilter は投稿一覧の絞り込み条件を表します
+// 空のフィールドは条件に含めません
+// StarredByを指定するとそのユーザがスターをつけた投稿に絞り込みます
+// Statusを指定するとその状態の投稿に絞り込みます
+// ViewerIDを指定するとそのユーザの下書きも含めます
+type PostFilter struct {
+	Tag       string
+	StarredBy string
+	Status    string
+	ViewerID  string
+}
+
+// IsEditableBy はuserIDのユーザが投稿を編集，削除できるかを返します
+// 編集できるのは投稿のオーナーだけです
+func (p *Post) IsEditableBy(userID string) bool {
+	return len(userID) > 0 && p.UserID == userID
+}
+
+// ApplyRevision は投稿のコードとファイルをrevisionの内容に置き換えます
+func (p *Post) ApplyRevision(revision *Revision) {
+	p.Code = revision.Code
+	p.Files = revision.Files
+	p.Revision = revision.Number
+}
+
+// HasSameCode は投稿のコード，言語，ファイルがotherと同じかを返します
+func (p *Post) HasSameCode(other *Post) bool {
+	if p.Code != other.Code || p.Language != other.Language || len(p.Files) != len(other.Files) {
+		return false
+	}
+	for i := range p.Files {
+		f, o := p.Files[i], other.Files[i]
+		if f.Filename != o.Filename || f.Language != o.Language || f.Content != o.Content {
+			return false
+		}
+	}
+	return true
+}
+
+// SyncPrimaryFile は複数のファイルからなる投稿の先頭のファイルの内容と言語を，CodeとLanguageにセットします
+// Filesが空の場合は何もしません
+func (p *Post) SyncPrimaryFile() {
+	if len(p.Files) == 0 {
+		return
+	}
+	p.Code = p.Files[0].Content
+	p.Language = p.Files[0].Language
+}
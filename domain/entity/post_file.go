@@ -0,0 +1,72 @@
+package entity
+
+import "fmt"
+
+const (
+	// MaxFilesPerPost は1つの投稿に含められるファイルの最大数です
+	MaxFilesPerPost = 20
+	// MaxFilenameLength はファイル名の最大文字数です
+	MaxFilenameLength = 255
+)
+
+// PostFile は複数のファイルからなる投稿の1つのファイルを表します
+// ファイルは投稿の中での並び順を持ち，先頭のファイルのContentとLanguageが投稿のCodeとLanguageになります
+type PostFile struct {
+	Filename string `json:"filename"`
+	Language string `json:"language"`
+	Content  string `json:"content"`
+	// LanguageDetection はLanguageを省略したときに拡張子か内容から求めた言語の確信度で，保存はされません
+	LanguageDetection *LanguageDetection `json:"language_detection,omitempty"`
+}
+
+// FindPostFile はファイルの一覧からファイル名が一致するファイルを探します
+// 見つからなければnilを返します
+func FindPostFile(files []*PostFile, filename string) *PostFile {
+	for _, file := range files {
+		if file.Filename == filename {
+			return file
+		}
+	}
+	return nil
+}
+
+// copyPostFiles はファイルの一覧を，ファイルごと複製します
+func copyPostFiles(files []*PostFile) []*PostFile {
+	if len(files) == 0 {
+		return nil
+	}
+	copied := make([]*PostFile, 0, len(files))
+	for _, file := range files {
+		f := *file
+		copied = append(copied, &f)
+	}
+	return copied
+}
+
+// validatePostFiles はファイルの一覧を検証します
+// ファイル名は投稿の中で重複できず，ファイルの言語と内容は空にできません
+func validatePostFiles(files []*PostFile) error {
+	if len(files) > MaxFilesPerPost {
+		return ErrTooManyPostFiles
+	}
+	seen := make(map[string]bool, len(files))
+	for _, file := range files {
+		if len(file.Filename) == 0 {
+			return fmt.Errorf("%w: filename is empty", ErrInvalidPostFile)
+		}
+		if len([]rune(file.Filename)) > MaxFilenameLength {
+			return fmt.Errorf("%w: filename %q is too long", ErrInvalidPostFile, file.Filename)
+		}
+		if seen[file.Filename] {
+			return fmt.Errorf("%w: filename %q is duplicated", ErrInvalidPostFile, file.Filename)
+		}
+		seen[file.Filename] = true
+		if len(file.Language) == 0 {
+			return fmt.Errorf("%w: language of %q is empty", ErrInvalidPostFile, file.Filename)
+		}
+		if len(file.Content) == 0 {
+			return fmt.Errorf("%w: content of %q is empty", ErrInvalidPostFile, file.Filename)
+		}
+	}
+	return nil
+}
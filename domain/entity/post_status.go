@@ -0,0 +1,43 @@
+package entity
+
+const (
+	// PostStatusOpen はレビューを募集している投稿の状態です
+	PostStatusOpen = "open"
+	// PostStatusInReview はレビューを受けている最中の投稿の状態です
+	PostStatusInReview = "in_review"
+	// PostStatusResolved はレビューが済んだ投稿の状態です
+	PostStatusResolved = "resolved"
+	// PostStatusArchived はアーカイブされ，新しいコメントを受け付けない投稿の状態です
+	PostStatusArchived = "archived"
+)
+
+// postStatusTransitions は投稿の状態ごとに遷移できる状態の一覧です
+var postStatusTransitions = map[string][]string{
+	PostStatusOpen:     {PostStatusInReview, PostStatusResolved, PostStatusArchived},
+	PostStatusInReview: {PostStatusOpen, PostStatusResolved, PostStatusArchived},
+	PostStatusResolved: {PostStatusOpen, PostStatusArchived},
+	PostStatusArchived: {PostStatusOpen},
+}
+
+// IsPostStatus はstatusが投稿の状態として正しい値かを返します
+func IsPostStatus(status string) bool {
+	_, ok := postStatusTransitions[status]
+	return ok
+}
+
+// CanTransitPostStatus は投稿の状態をfromからtoに変えられるかを返します
+// 同じ状態への遷移は変化がないので常に許可します
+func CanTransitPostStatus(from, to string) bool {
+	if !IsPostStatus(to) {
+		return false
+	}
+	if from == to {
+		return true
+	}
+	for _, next := range postStatusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,27 @@
+package entity
+
+import "testing"
+
+func TestCanTransitPostStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		from string
+		to   string
+		want bool
+	}{
+		{name: "openからin_reviewに変えられる", from: PostStatusOpen, to: PostStatusInReview, want: true},
+		{name: "resolvedからopenに戻せる", from: PostStatusResolved, to: PostStatusOpen, want: true},
+		{name: "resolvedからin_reviewには変えられない", from: PostStatusResolved, to: PostStatusInReview, want: false},
+		{name: "archivedはopenにしか戻せない", from: PostStatusArchived, to: PostStatusResolved, want: false},
+		{name: "同じ状態への遷移は許可する", from: PostStatusArchived, to: PostStatusArchived, want: true},
+		{name: "存在しない状態には変えられない", from: PostStatusOpen, to: "closed", want: false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CanTransitPostStatus(tt.from, tt.to); got != tt.want {
+				t.Errorf("CanTransitPostStatus(%s, %s) = %v, want = %v", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package entity_test
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -58,7 +59,7 @@ func TestIsValid(t *testing.T) {
 				CreatedAt: "2021-03-23T11:42:56+09:00",
 				UpdatedAt: "2021-03-23T11:42:56+09:00",
 			},
-			wantErr: entity.NewErrorEmpty("post ID"),
+			wantErr: entity.NewErrorEmpty("post UserID"),
 		},
 		{
 			name: "too long userID",
@@ -165,18 +166,108 @@ func TestIsValid(t *testing.T) {
 			},
 			wantErr: entity.NewErrorTooLong("post Source"),
 		},
+		{
+			name: "複数のファイルを含む投稿",
+			postE: &entity.Post{
+				UserID:   "test",
+				Title:    "test title",
+				Code:     "package main",
+				Language: "go",
+				Files: []*entity.PostFile{
+					{Filename: "main.go", Language: "go", Content: "package main"},
+					{Filename: "schema.sql", Language: "sql", Content: "CREATE TABLE t (id INTEGER);"},
+				},
+			},
+			wantErr: nil,
+		},
+		{
+			name: "duplicated filename",
+			postE: &entity.Post{
+				UserID:   "test",
+				Title:    "test title",
+				Code:     "package main",
+				Language: "go",
+				Files: []*entity.PostFile{
+					{Filename: "main.go", Language: "go", Content: "package main"},
+					{Filename: "main.go", Language: "go", Content: "package main"},
+				},
+			},
+			wantErr: fmt.Errorf("%w: filename %q is duplicated", entity.ErrInvalidPostFile, "main.go"),
+		},
+		{
+			name: "empty filename",
+			postE: &entity.Post{
+				UserID:   "test",
+				Title:    "test title",
+				Code:     "package main",
+				Language: "go",
+				Files:    []*entity.PostFile{{Language: "go", Content: "package main"}},
+			},
+			wantErr: fmt.Errorf("%w: filename is empty", entity.ErrInvalidPostFile),
+		},
+		{
+			name: "empty file content",
+			postE: &entity.Post{
+				UserID:   "test",
+				Title:    "test title",
+				Code:     "package main",
+				Language: "go",
+				Files: []*entity.PostFile{
+					{Filename: "main.go", Language: "go", Content: "package main"},
+					{Filename: "main_test.go", Language: "go"},
+				},
+			},
+			wantErr: fmt.Errorf("%w: content of %q is empty", entity.ErrInvalidPostFile, "main_test.go"),
+		},
+		{
+			name: "too many files",
+			postE: &entity.Post{
+				UserID:   "test",
+				Title:    "test title",
+				Code:     "package main",
+				Language: "go",
+				Files:    make([]*entity.PostFile, entity.MaxFilesPerPost+1),
+			},
+			wantErr: entity.ErrTooManyPostFiles,
+		},
 	}
 
 	for _, tc := range tests {
+		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			got := tc.postE.IsValid()
 			if got == nil && tc.wantErr == nil {
 				return
 			}
+			if got == nil || tc.wantErr == nil {
+				t.Errorf("postE.IsValid() = %v, want = %v", got, tc.wantErr)
+				return
+			}
 			if got.Error() != tc.wantErr.Error() {
 				t.Errorf("postE.IsValid() = %s, want = %s", got.Error(), tc.wantErr.Error())
 			}
 		})
 	}
 }
+
+func TestPost_SyncPrimaryFile(t *testing.T) {
+	post := &entity.Post{
+		Code:     "old",
+		Language: "plaintext",
+		Files: []*entity.PostFile{
+			{Filename: "main.go", Language: "go", Content: "package main"},
+			{Filename: "schema.sql", Language: "sql", Content: "CREATE TABLE t (id INTEGER);"},
+		},
+	}
+	post.SyncPrimaryFile()
+	if post.Code != "package main" || post.Language != "go" {
+		t.Errorf("Code, Language = %q, %q, want = %q, %q", post.Code, post.Language, "package main", "go")
+	}
+
+	single := &entity.Post{Code: "print(1)", Language: "python"}
+	single.SyncPrimaryFile()
+	if single.Code != "print(1)" || single.Language != "python" {
+		t.Errorf("単一のファイルの投稿は変わらないべき: Code, Language = %q, %q", single.Code, single.Language)
+	}
+}
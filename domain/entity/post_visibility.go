@@ -0,0 +1,36 @@
+package entity
+
+const (
+	// PostVisibilityPublic は誰でも閲覧でき，一覧にも表示される投稿の公開範囲です
+	PostVisibilityPublic = "public"
+	// PostVisibilityUnlisted はIDを知っていれば誰でも閲覧できるが，一覧には表示されない投稿の公開範囲です
+	PostVisibilityUnlisted = "unlisted"
+	// PostVisibilityPrivate はオーナーと招待されたユーザだけが閲覧できる投稿の公開範囲です
+	PostVisibilityPrivate = "private"
+)
+
+// IsPostVisibility はvisibilityが投稿の公開範囲として正しい値かを返します
+func IsPostVisibility(visibility string) bool {
+	switch visibility {
+	case PostVisibilityPublic, PostVisibilityUnlisted, PostVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+// IsVisibleTo はviewerIDのユーザが投稿を閲覧できるかを返します
+// invitedはviewerIDのユーザが投稿に招待されているかで，非公開の投稿の場合のみ参照します
+// 未ログインの場合はviewerIDを空文字列とし，公開範囲が未設定の投稿は公開されているものとして扱います
+// 下書きは公開範囲によらずオーナーだけが閲覧できます
+func (p *Post) IsVisibleTo(viewerID string, invited bool) bool {
+	if p.Draft {
+		return len(viewerID) > 0 && p.UserID == viewerID
+	}
+	if p.Visibility != PostVisibilityPrivate {
+		return true
+	}
+	if len(viewerID) == 0 {
+		return false
+	}
+	return p.UserID == viewerID || invited
+}
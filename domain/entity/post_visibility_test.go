@@ -0,0 +1,90 @@
+package entity
+
+import "testing"
+
+func TestPost_IsVisibleTo(t *testing.T) {
+	tests := []struct {
+		name       string
+		visibility string
+		draft      bool
+		viewerID   string
+		invited    bool
+		want       bool
+	}{
+		{
+			name:       "公開された投稿は未ログインでも閲覧できる",
+			visibility: PostVisibilityPublic,
+			viewerID:   "",
+			want:       true,
+		},
+		{
+			name:       "限定公開の投稿は未ログインでも閲覧できる",
+			visibility: PostVisibilityUnlisted,
+			viewerID:   "",
+			want:       true,
+		},
+		{
+			name:       "公開範囲が未設定なら公開されているものとして扱う",
+			visibility: "",
+			viewerID:   "",
+			want:       true,
+		},
+		{
+			name:       "非公開の投稿は未ログインでは閲覧できない",
+			visibility: PostVisibilityPrivate,
+			viewerID:   "",
+			invited:    true,
+			want:       false,
+		},
+		{
+			name:       "非公開の投稿はオーナーが閲覧できる",
+			visibility: PostVisibilityPrivate,
+			viewerID:   "owner",
+			want:       true,
+		},
+		{
+			name:       "非公開の投稿は招待されたユーザが閲覧できる",
+			visibility: PostVisibilityPrivate,
+			viewerID:   "guest",
+			invited:    true,
+			want:       true,
+		},
+		{
+			name:       "非公開の投稿は招待されていないユーザは閲覧できない",
+			visibility: PostVisibilityPrivate,
+			viewerID:   "other",
+			want:       false,
+		},
+		{
+			name:       "下書きはオーナーが閲覧できる",
+			visibility: PostVisibilityPublic,
+			draft:      true,
+			viewerID:   "owner",
+			want:       true,
+		},
+		{
+			name:       "下書きは公開範囲によらずオーナー以外は閲覧できない",
+			visibility: PostVisibilityPublic,
+			draft:      true,
+			viewerID:   "other",
+			want:       false,
+		},
+		{
+			name:       "非公開の下書きは招待されたユーザも閲覧できない",
+			visibility: PostVisibilityPrivate,
+			draft:      true,
+			viewerID:   "guest",
+			invited:    true,
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			post := &Post{UserID: "owner", Visibility: tt.visibility, Draft: tt.draft}
+			if got := post.IsVisibleTo(tt.viewerID, tt.invited); got != tt.want {
+				t.Errorf("IsVisibleTo() = %v, want = %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package entity
+
+// ReactionEmojis はコメントにつけられるリアクションの絵文字の一覧です
+// コメントのReactionsもこの順に並びます
+var ReactionEmojis = []string{
+	"thumbsup",   // 👍
+	"thumbsdown", // 👎
+	"smile",      // 😄
+	"tada",       // 🎉
+	"thinking",   // 🤔
+	"heart",      // ❤️
+	"rocket",     // 🚀
+	"eyes",       // 👀
+}
+
+// Reaction はユーザがコメントにつけた絵文字のリアクションを表します
+// 1人のユーザは1つのコメントに同じ絵文字のリアクションを1つまでつけられます
+type Reaction struct {
+	UserID    string `json:"user_id"`
+	PostID    int    `json:"post_id"`
+	CommentID int    `json:"comment_id"`
+	Emoji     string `json:"emoji"`
+	CreatedAt string `json:"created_at"`
+}
+
+// ReactionCount はコメントについた絵文字ごとのリアクションの数です
+type ReactionCount struct {
+	Emoji string `json:"emoji"`
+	Count int    `json:"count"`
+}
+
+// IsValid はReactionのバリデーションを行うメソッドです
+func (r *Reaction) IsValid() error {
+	if len(r.UserID) == 0 {
+		return NewErrorEmpty("reaction UserID")
+	}
+	if len([]rune(r.UserID)) > 128 {
+		return NewErrorTooLong("reaction UserID")
+	}
+	if r.PostID <= 0 {
+		return NewErrorEmpty("reaction PostID")
+	}
+	if r.CommentID <= 0 {
+		return NewErrorEmpty("reaction CommentID")
+	}
+	if !IsReactionEmoji(r.Emoji) {
+		return ErrUnknownReaction
+	}
+	return nil
+}
+
+// IsReactionEmoji はemojiがリアクションとしてつけられる絵文字かを返します
+func IsReactionEmoji(emoji string) bool {
+	for _, e := range ReactionEmojis {
+		if e == emoji {
+			return true
+		}
+	}
+	return false
+}
+
+// NewReactionCounts は絵文字ごとのリアクションの数をReactionEmojisの順に並べます
+// リアクションが1つもない絵文字は含めません
+func NewReactionCounts(counts map[string]int) []*ReactionCount {
+	var reactions []*ReactionCount
+	for _, emoji := range ReactionEmojis {
+		if counts[emoji] > 0 {
+			reactions = append(reactions, &ReactionCount{Emoji: emoji, Count: counts[emoji]})
+		}
+	}
+	return reactions
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReaction_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		reaction *Reaction
+		wantErr  error
+	}{
+		{
+			name:     "正しいリアクション",
+			reaction: &Reaction{UserID: "user-id", PostID: 1, CommentID: 1, Emoji: "thumbsup"},
+		},
+		{
+			name:     "一覧にない絵文字はエラー",
+			reaction: &Reaction{UserID: "user-id", PostID: 1, CommentID: 1, Emoji: "pizza"},
+			wantErr:  ErrUnknownReaction,
+		},
+		{
+			name:     "CommentIDが空ならエラー",
+			reaction: &Reaction{UserID: "user-id", PostID: 1, Emoji: "thumbsup"},
+			wantErr:  NewErrorEmpty("reaction CommentID"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.reaction.IsValid(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("IsValid() error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewReactionCounts(t *testing.T) {
+	got := NewReactionCounts(map[string]int{"eyes": 1, "thumbsup": 3, "heart": 0})
+	want := []*ReactionCount{
+		{Emoji: "thumbsup", Count: 3},
+		{Emoji: "eyes", Count: 1},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewReactionCounts (-want +got) =\n%s\n", diff)
+	}
+}
@@ -0,0 +1,128 @@
+package entity
+
+import "sort"
+
+// OriginalRevision は投稿時のコードを表すリビジョン番号です
+// commitコメントが作成されるたびに，それ以降の番号が順に割り当てられます
+const OriginalRevision = 1
+
+// Revision は投稿のコードのある時点での版を表します
+type Revision struct {
+	PostID int `json:"post_id"`
+	Number int `json:"number"`
+	// CommentID はリビジョンを作ったcommitコメントのIDで，投稿時のコードでは0です
+	CommentID int    `json:"comment_id,omitempty"`
+	UserID    string `json:"user_id"`
+	// AuthorID はsuggestionコメントを適用して作られたリビジョンで，変更を提案したユーザのIDです
+	AuthorID string `json:"author_id,omitempty"`
+	// Code は複数のファイルからなる投稿では先頭のファイルの内容です
+	Code string `json:"code"`
+	// Files は複数のファイルからなる投稿のその時点でのファイルの一覧です
+	Files     []*PostFile `json:"files,omitempty"`
+	CreatedAt string      `json:"created_at"`
+}
+
+// NewRevisions は投稿とその投稿に属するコメントからリビジョンの一覧を番号順に組み立てます
+// リビジョン番号が割り当てられていないコメントは含まれません．墓標になったcommitコメントもリビジョンとして残ります
+// 複数のファイルからなる投稿では，commitコメントは1つ前のリビジョンのFilenameのファイルだけを置き換えます
+func NewRevisions(post *Post, comments []*Comment) []*Revision {
+	commits := make([]*Comment, 0, len(comments))
+	for _, comment := range comments {
+		if comment.Type != "commit" || comment.Revision <= OriginalRevision {
+			continue
+		}
+		commits = append(commits, comment)
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Revision < commits[j].Revision
+	})
+
+	revisions := make([]*Revision, 0, len(commits)+1)
+	revisions = append(revisions, &Revision{
+		PostID:    post.ID,
+		Number:    OriginalRevision,
+		UserID:    post.UserID,
+		Code:      post.Code,
+		Files:     post.Files,
+		CreatedAt: post.CreatedAt,
+	})
+	for _, commit := range commits {
+		revision := &Revision{
+			PostID:    commit.PostID,
+			Number:    commit.Revision,
+			CommentID: commit.ID,
+			UserID:    commit.UserID,
+			AuthorID:  commit.AuthorID,
+			Code:      commit.Code,
+			CreatedAt: commit.CreatedAt,
+		}
+		if previous := revisions[len(revisions)-1]; len(previous.Files) > 0 {
+			revision.Files = commitFile(previous.Files, commit)
+			revision.Code = revision.Files[0].Content
+		}
+		revisions = append(revisions, revision)
+	}
+	return revisions
+}
+
+// commitFile はファイルの一覧を複製し，commitコメントのFilenameのファイルの内容をCodeで置き換えます
+// Filenameが空の場合は先頭のファイルを置き換え，存在しないファイルの場合は何も置き換えません
+func commitFile(files []*PostFile, commit *Comment) []*PostFile {
+	committed := copyPostFiles(files)
+	target := committed[0]
+	if len(commit.Filename) > 0 {
+		target = FindPostFile(committed, commit.Filename)
+	}
+	if target != nil {
+		target.Content = commit.Code
+	}
+	return committed
+}
+
+// FileCode はリビジョンでのファイルのコードを返します
+// filenameが空文字列の場合は先頭のファイルのコードを返します．ファイルが存在しなければfalseを返します
+func (r *Revision) FileCode(filename string) (string, bool) {
+	if len(filename) == 0 {
+		return r.Code, true
+	}
+	file := FindPostFile(r.Files, filename)
+	if file == nil {
+		return "", false
+	}
+	return file.Content, true
+}
+
+// ResolveFilename はコメントが指すファイルのファイル名をリビジョンのファイルから決めます
+// 複数のファイルからなる投稿でfilenameが省略された場合は先頭のファイルを指すものとします
+// 存在しないファイルや，単一のファイルの投稿でファイル名を指定した場合はErrNotFoundを返します
+func (r *Revision) ResolveFilename(filename string) (string, error) {
+	if len(filename) == 0 {
+		if len(r.Files) == 0 {
+			return "", nil
+		}
+		return r.Files[0].Filename, nil
+	}
+	if FindPostFile(r.Files, filename) == nil {
+		return "", NewErrorNotFound("file")
+	}
+	return filename, nil
+}
+
+// FindRevision はリビジョンの一覧から指定した番号のリビジョンを探します
+func FindRevision(revisions []*Revision, number int) (*Revision, error) {
+	for _, revision := range revisions {
+		if revision.Number == number {
+			return revision, nil
+		}
+	}
+	return nil, NewErrorNotFound("revision")
+}
+
+// LatestRevision はリビジョンの一覧から最新のリビジョンを返します
+// revisionsはNewRevisionsで生成された番号順のスライスであることを想定しています
+func LatestRevision(revisions []*Revision) *Revision {
+	if len(revisions) == 0 {
+		return nil
+	}
+	return revisions[len(revisions)-1]
+}
@@ -0,0 +1,175 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNewRevisions(t *testing.T) {
+	post := &Post{
+		ID:        1,
+		UserID:    "owner",
+		Code:      "original",
+		CreatedAt: "2021-03-23T11:42:56+09:00",
+	}
+	tests := []struct {
+		name     string
+		comments []*Comment
+		want     []*Revision
+	}{
+		{
+			name:     "コメントがなければ投稿時のリビジョンのみ",
+			comments: nil,
+			want: []*Revision{
+				{PostID: 1, Number: 1, UserID: "owner", Code: "original", CreatedAt: "2021-03-23T11:42:56+09:00"},
+			},
+		},
+		{
+			name: "commitコメントのみがリビジョン番号順に並ぶ",
+			comments: []*Comment{
+				{ID: 4, PostID: 1, UserID: "owner", Type: "commit", Code: "third", Revision: 3, CreatedAt: "2021-03-23T11:42:59+09:00"},
+				{ID: 2, PostID: 1, UserID: "other", Type: "none", Content: "LGTM"},
+				{ID: 3, PostID: 1, UserID: "owner", Type: "commit", Code: "second", Revision: 2, CreatedAt: "2021-03-23T11:42:58+09:00"},
+			},
+			want: []*Revision{
+				{PostID: 1, Number: 1, UserID: "owner", Code: "original", CreatedAt: "2021-03-23T11:42:56+09:00"},
+				{PostID: 1, Number: 2, CommentID: 3, UserID: "owner", Code: "second", CreatedAt: "2021-03-23T11:42:58+09:00"},
+				{PostID: 1, Number: 3, CommentID: 4, UserID: "owner", Code: "third", CreatedAt: "2021-03-23T11:42:59+09:00"},
+			},
+		},
+		{
+			name: "suggestionを適用したcommitは提案したユーザを記録する",
+			comments: []*Comment{
+				{ID: 2, PostID: 1, UserID: "reviewer", Type: "suggestion", Code: "fixed", FirstLine: 1, LastLine: 1, AppliedRevision: 2},
+				{ID: 3, PostID: 1, ParentID: 2, UserID: "owner", AuthorID: "reviewer", Type: "commit", Code: "fixed", Revision: 2, CreatedAt: "2021-03-23T11:42:58+09:00"},
+			},
+			want: []*Revision{
+				{PostID: 1, Number: 1, UserID: "owner", Code: "original", CreatedAt: "2021-03-23T11:42:56+09:00"},
+				{PostID: 1, Number: 2, CommentID: 3, UserID: "owner", AuthorID: "reviewer", Code: "fixed", CreatedAt: "2021-03-23T11:42:58+09:00"},
+			},
+		},
+		{
+			name: "墓標になったcommitコメントもリビジョンとして残る",
+			comments: []*Comment{
+				{ID: 2, PostID: 1, UserID: "owner", Type: "commit", Code: "second", Revision: 2, Deleted: true, CreatedAt: "2021-03-23T11:42:57+09:00"},
+			},
+			want: []*Revision{
+				{PostID: 1, Number: 1, UserID: "owner", Code: "original", CreatedAt: "2021-03-23T11:42:56+09:00"},
+				{PostID: 1, Number: 2, CommentID: 2, UserID: "owner", Code: "second", CreatedAt: "2021-03-23T11:42:57+09:00"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRevisions(post, tt.comments)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Data (-want +got) =\n%s\n", diff)
+			}
+			if latest := LatestRevision(got); latest != got[len(got)-1] {
+				t.Errorf("LatestRevision() = %+v, want = %+v", latest, got[len(got)-1])
+			}
+		})
+	}
+}
+
+func TestFindRevision(t *testing.T) {
+	revisions := []*Revision{
+		{PostID: 1, Number: 1},
+		{PostID: 1, Number: 3},
+	}
+	if got, err := FindRevision(revisions, 3); err != nil || got != revisions[1] {
+		t.Errorf("FindRevision(3) = %+v, %v", got, err)
+	}
+	if _, err := FindRevision(revisions, 2); err == nil || err.Error() != NewErrorNotFound("revision").Error() {
+		t.Errorf("FindRevision(2) error = %v, want = %v", err, NewErrorNotFound("revision"))
+	}
+}
+
+func TestNewRevisions_Files(t *testing.T) {
+	post := &Post{
+		ID:     1,
+		UserID: "owner",
+		Code:   "main v1",
+		Files: []*PostFile{
+			{Filename: "main.go", Language: "go", Content: "main v1"},
+			{Filename: "main_test.go", Language: "go", Content: "test v1"},
+		},
+	}
+	comments := []*Comment{
+		{ID: 3, PostID: 1, UserID: "owner", Type: "commit", Filename: "main.go", Code: "main v2", Revision: 3},
+		{ID: 2, PostID: 1, UserID: "owner", Type: "commit", Filename: "main_test.go", Code: "test v2", Revision: 2},
+		{ID: 4, PostID: 1, UserID: "owner", Type: "commit", Filename: "unknown.go", Code: "unknown", Revision: 4},
+	}
+	want := []*Revision{
+		{PostID: 1, Number: 1, UserID: "owner", Code: "main v1", Files: []*PostFile{
+			{Filename: "main.go", Language: "go", Content: "main v1"},
+			{Filename: "main_test.go", Language: "go", Content: "test v1"},
+		}},
+		{PostID: 1, Number: 2, CommentID: 2, UserID: "owner", Code: "main v1", Files: []*PostFile{
+			{Filename: "main.go", Language: "go", Content: "main v1"},
+			{Filename: "main_test.go", Language: "go", Content: "test v2"},
+		}},
+		{PostID: 1, Number: 3, CommentID: 3, UserID: "owner", Code: "main v2", Files: []*PostFile{
+			{Filename: "main.go", Language: "go", Content: "main v2"},
+			{Filename: "main_test.go", Language: "go", Content: "test v2"},
+		}},
+		// 存在しないファイルへのcommitはコードを変えない
+		{PostID: 1, Number: 4, CommentID: 4, UserID: "owner", Code: "main v2", Files: []*PostFile{
+			{Filename: "main.go", Language: "go", Content: "main v2"},
+			{Filename: "main_test.go", Language: "go", Content: "test v2"},
+		}},
+	}
+
+	got := NewRevisions(post, comments)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Data (-want +got) =\n%s\n", diff)
+	}
+	if post.Files[1].Content != "test v1" {
+		t.Errorf("投稿のファイルは書き換えないべき: %q", post.Files[1].Content)
+	}
+}
+
+func TestRevision_ResolveFilename(t *testing.T) {
+	multi := &Revision{Number: 1, Code: "main", Files: []*PostFile{
+		{Filename: "main.go", Content: "main"},
+		{Filename: "main_test.go", Content: "test"},
+	}}
+	single := &Revision{Number: 1, Code: "main"}
+	tests := []struct {
+		name     string
+		revision *Revision
+		filename string
+		want     string
+		wantCode string
+		wantErr  error
+	}{
+		{name: "ファイル名を省略すると先頭のファイルを指す", revision: multi, filename: "", want: "main.go", wantCode: "main"},
+		{name: "指定したファイルを指す", revision: multi, filename: "main_test.go", want: "main_test.go", wantCode: "test"},
+		{name: "存在しないファイルはNotFound", revision: multi, filename: "unknown.go", wantErr: NewErrorNotFound("file")},
+		{name: "単一のファイルの投稿ではファイル名を持たない", revision: single, filename: "", want: "", wantCode: "main"},
+		{name: "単一のファイルの投稿でファイル名を指定するとNotFound", revision: single, filename: "main.go", wantErr: NewErrorNotFound("file")},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.revision.ResolveFilename(tt.filename)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Errorf("ResolveFilename() error = %v, want = %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveFilename() = %q, want = %q", got, tt.want)
+			}
+			if code, ok := tt.revision.FileCode(got); !ok || code != tt.wantCode {
+				t.Errorf("FileCode(%q) = %q, %v, want = %q", got, code, ok, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package entity
+
+// MaxSearchKeywordLength は検索キーワードの最大文字数です
+const MaxSearchKeywordLength = 256
+
+// SearchQuery は投稿の全文検索の条件を表します
+// Language, UserIDが空でなければその言語，ユーザーの投稿に絞り込みます
+// Offsetは関連度順に並べた検索結果のうち読み飛ばす件数です
+type SearchQuery struct {
+	Keyword  string
+	Language string
+	UserID   string
+	Offset   int
+	Limit    int
+}
+
+// IsValid はSearchQueryのバリデーションを行うメソッドです
+func (q *SearchQuery) IsValid() error {
+	if len(q.Keyword) == 0 {
+		return NewErrorEmpty("search keyword")
+	}
+	if len([]rune(q.Keyword)) > MaxSearchKeywordLength {
+		return NewErrorTooLong("search keyword")
+	}
+	if q.Offset < 0 {
+		return NewErrorNegativeValue("search offset")
+	}
+	return nil
+}
+
+// SearchResult は検索にヒットした投稿とキーワードとの関連度を表します
+// Scoreは同じ検索結果の中での順位付けにのみ意味を持ちます
+type SearchResult struct {
+	Post  *Post   `json:"post"`
+	Score float64 `json:"score"`
+}
+
+// SearchPage はページングされた検索結果を表します
+// NextCursorが空の場合は次のページが存在しません
+type SearchPage struct {
+	Results    []*SearchResult `json:"results"`
+	NextCursor string          `json:"next_cursor"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+// Star はユーザが投稿につけたスターを表します
+// 1人のユーザは1つの投稿に1つまでスターをつけられます
+type Star struct {
+	UserID    string `json:"user_id"`
+	PostID    int    `json:"post_id"`
+	CreatedAt string `json:"created_at"`
+}
+
+// IsValid はStarのバリデーションを行うメソッドです
+func (s *Star) IsValid() error {
+	if len(s.UserID) == 0 {
+		return NewErrorEmpty("star UserID")
+	}
+	if len([]rune(s.UserID)) > 128 {
+		return NewErrorTooLong("star UserID")
+	}
+	if s.PostID <= 0 {
+		return NewErrorEmpty("star PostID")
+	}
+	return nil
+}
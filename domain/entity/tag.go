@@ -0,0 +1,77 @@
+package entity
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxTagsPerPost は1つの投稿につけられるタグの最大数です
+	MaxTagsPerPost = 5
+	// MaxTagNameLength はタグ名の最大文字数です
+	MaxTagNameLength = 32
+)
+
+// Tag は投稿につけられたタグとそのタグがついた投稿の数を表します
+type Tag struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// NormalizeTagName はタグ名の前後の空白を取り除き，小文字にします
+func NormalizeTagName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NormalizeTags はタグ名をそれぞれ正規化し，重複を取り除いて名前順に並べます
+// 正規化した結果がタグとして不正な場合はエラーを返します
+func NormalizeTags(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool, len(names))
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		name = NormalizeTagName(name)
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, name)
+	}
+	sort.Strings(tags)
+
+	if err := validateTags(tags); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+// validateTags は正規化済みのタグの一覧を検証します
+func validateTags(tags []string) error {
+	if len(tags) > MaxTagsPerPost {
+		return ErrTooManyTags
+	}
+	for _, tag := range tags {
+		if err := validateTagName(tag); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateTagName は正規化済みのタグ名を検証します
+// 空白と区切り文字として使われる記号はタグ名に含められません
+func validateTagName(name string) error {
+	if len(name) == 0 || len([]rune(name)) > MaxTagNameLength {
+		return ErrInvalidTagName
+	}
+	for _, r := range name {
+		if unicode.IsSpace(r) || unicode.IsUpper(r) || strings.ContainsRune(",/?&#", r) {
+			return ErrInvalidTagName
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     []string
+		wantTags []string
+		wantErr  error
+	}{
+		{
+			name:     "小文字にして重複を除き名前順に並べる",
+			tags:     []string{" Go", "goroutine", "go ", "C++"},
+			wantTags: []string{"c++", "go", "goroutine"},
+		},
+		{
+			name:     "タグがなければnil",
+			tags:     []string{},
+			wantTags: nil,
+		},
+		{
+			name:    "空のタグはエラー",
+			tags:    []string{"go", "  "},
+			wantErr: ErrInvalidTagName,
+		},
+		{
+			name:    "空白を含むタグはエラー",
+			tags:    []string{"go lang"},
+			wantErr: ErrInvalidTagName,
+		},
+		{
+			name:    "カンマを含むタグはエラー",
+			tags:    []string{"go,rust"},
+			wantErr: ErrInvalidTagName,
+		},
+		{
+			name:    "長すぎるタグはエラー",
+			tags:    []string{strings.Repeat("あ", MaxTagNameLength+1)},
+			wantErr: ErrInvalidTagName,
+		},
+		{
+			name:    "タグが多すぎるとエラー",
+			tags:    []string{"a", "b", "c", "d", "e", "f"},
+			wantErr: ErrTooManyTags,
+		},
+		{
+			name:     "重複を除いて上限以内ならエラーにならない",
+			tags:     []string{"a", "b", "c", "d", "e", "A"},
+			wantTags: []string{"a", "b", "c", "d", "e"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTags(tt.tags)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if diff := cmp.Diff(tt.wantTags, got); diff != "" {
+				t.Errorf("Tags (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -7,6 +7,10 @@ type User struct {
 	Profile   string `json:"profile"`
 	TwitterID string `json:"twitter_id"`
 	IconURL   string `json:"icon_url"`
+	// FollowerCount はこのユーザをフォローしているユーザの数で，プロフィールとして取得したときだけセットされます
+	FollowerCount int `json:"follower_count"`
+	// FollowingCount はこのユーザがフォローしているユーザの数で，プロフィールとして取得したときだけセットされます
+	FollowingCount int `json:"following_count"`
 }
 
 // NewUser はUserのポインタを生成する関数です
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+const cursorSeparator = "_"
+
+// EncodeCursor はCursorをURLに含められる不透明な文字列に変換します
+func EncodeCursor(cursor *entity.Cursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw := cursor.CreatedAt + cursorSeparator + strconv.Itoa(cursor.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor はEncodeCursorで生成した文字列をCursorに戻します
+// 空文字列の場合は先頭ページを表すnilを返します
+func DecodeCursor(s string) (*entity.Cursor, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cursor: %w", entity.ErrInvalidCursor)
+	}
+	idx := strings.LastIndex(string(raw), cursorSeparator)
+	if idx < 0 {
+		return nil, entity.ErrInvalidCursor
+	}
+	createdAt := string(raw[:idx])
+	if _, err := ConvertStrToTime(createdAt); err != nil {
+		return nil, fmt.Errorf("failed to parse cursor time: %w", entity.ErrInvalidCursor)
+	}
+	id, err := strconv.Atoi(string(raw[idx+1:]))
+	if err != nil || id < 0 {
+		return nil, entity.ErrInvalidCursor
+	}
+	return &entity.Cursor{
+		CreatedAt: createdAt,
+		ID:        id,
+	}, nil
+}
+
+// EncodeOffsetCursor は関連度順など作成日時で並ばない一覧の読み飛ばす件数を不透明な文字列に変換します
+func EncodeOffsetCursor(offset int) string {
+	if offset <= 0 {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// DecodeOffsetCursor はEncodeOffsetCursorで生成した文字列を読み飛ばす件数に戻します
+// 空文字列の場合は先頭ページを表す0を返します
+func DecodeOffsetCursor(s string) (int, error) {
+	if len(s) == 0 {
+		return 0, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode cursor: %w", entity.ErrInvalidCursor)
+	}
+	offset, err := strconv.Atoi(string(raw))
+	if err != nil || offset < 0 {
+		return 0, entity.ErrInvalidCursor
+	}
+	return offset, nil
+}
+
+// EncodeFeedCursor はFeedCursorをURLに含められる不透明な文字列に変換します
+func EncodeFeedCursor(cursor *entity.FeedCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	raw := strings.Join([]string{cursor.CreatedAt, cursor.Type, strconv.Itoa(cursor.ID)}, cursorSeparator)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeFeedCursor はEncodeFeedCursorで生成した文字列をFeedCursorに戻します
+// 空文字列の場合は先頭ページを表すnilを返します
+func DecodeFeedCursor(s string) (*entity.FeedCursor, error) {
+	if len(s) == 0 {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode cursor: %w", entity.ErrInvalidCursor)
+	}
+	parts := strings.Split(string(raw), cursorSeparator)
+	if len(parts) != 3 {
+		return nil, entity.ErrInvalidCursor
+	}
+	if _, err := ConvertStrToTime(parts[0]); err != nil {
+		return nil, fmt.Errorf("failed to parse cursor time: %w", entity.ErrInvalidCursor)
+	}
+	if parts[1] != entity.FeedItemPost && parts[1] != entity.FeedItemCommit {
+		return nil, entity.ErrInvalidCursor
+	}
+	id, err := strconv.Atoi(parts[2])
+	if err != nil || id < 0 {
+		return nil, entity.ErrInvalidCursor
+	}
+	return &entity.FeedCursor{
+		CreatedAt: parts[0],
+		Type:      parts[1],
+		ID:        id,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestDecodeCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		cursor     string
+		wantCursor *entity.Cursor
+		wantErr    error
+	}{
+		{
+			name:       "EncodeCursorで生成した文字列を元に戻せる",
+			cursor:     EncodeCursor(&entity.Cursor{CreatedAt: "2021-03-23T11:42:56+09:00", ID: 10}),
+			wantCursor: &entity.Cursor{CreatedAt: "2021-03-23T11:42:56+09:00", ID: 10},
+			wantErr:    nil,
+		},
+		{
+			name:       "空文字列ならnilを返す",
+			cursor:     "",
+			wantCursor: nil,
+			wantErr:    nil,
+		},
+		{
+			name:       "base64でなければErrInvalidCursor",
+			cursor:     "!!!",
+			wantCursor: nil,
+			wantErr:    entity.ErrInvalidCursor,
+		},
+		{
+			name:       "時刻の形式が不正ならErrInvalidCursor",
+			cursor:     EncodeCursor(&entity.Cursor{CreatedAt: "2021-03-23", ID: 10}),
+			wantCursor: nil,
+			wantErr:    entity.ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeCursor(tt.cursor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.wantCursor, got); diff != "" {
+				t.Errorf("Data (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeOffsetCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		cursor     string
+		wantOffset int
+		wantErr    error
+	}{
+		{
+			name:       "EncodeOffsetCursorで生成した文字列を元に戻せる",
+			cursor:     EncodeOffsetCursor(40),
+			wantOffset: 40,
+			wantErr:    nil,
+		},
+		{
+			name:       "空文字列なら0を返す",
+			cursor:     "",
+			wantOffset: 0,
+			wantErr:    nil,
+		},
+		{
+			name:       "数値でなければErrInvalidCursor",
+			cursor:     EncodeCursor(&entity.Cursor{CreatedAt: "2021-03-23T11:42:56+09:00", ID: 10}),
+			wantOffset: 0,
+			wantErr:    entity.ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeOffsetCursor(tt.cursor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.wantOffset {
+				t.Errorf("offset = %d, want = %d", got, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestDecodeFeedCursor(t *testing.T) {
+	tests := []struct {
+		name       string
+		cursor     string
+		wantCursor *entity.FeedCursor
+		wantErr    error
+	}{
+		{
+			name:       "EncodeFeedCursorで生成した文字列を元に戻せる",
+			cursor:     EncodeFeedCursor(&entity.FeedCursor{CreatedAt: "2021-03-23T11:42:56+09:00", Type: entity.FeedItemCommit, ID: 10}),
+			wantCursor: &entity.FeedCursor{CreatedAt: "2021-03-23T11:42:56+09:00", Type: entity.FeedItemCommit, ID: 10},
+			wantErr:    nil,
+		},
+		{
+			name:       "空文字列ならnilを返す",
+			cursor:     "",
+			wantCursor: nil,
+			wantErr:    nil,
+		},
+		{
+			name:       "種類が不正ならErrInvalidCursor",
+			cursor:     EncodeFeedCursor(&entity.FeedCursor{CreatedAt: "2021-03-23T11:42:56+09:00", Type: "comment", ID: 10}),
+			wantCursor: nil,
+			wantErr:    entity.ErrInvalidCursor,
+		},
+		{
+			name:       "投稿一覧のcursorならErrInvalidCursor",
+			cursor:     EncodeCursor(&entity.Cursor{CreatedAt: "2021-03-23T11:42:56+09:00", ID: 10}),
+			wantCursor: nil,
+			wantErr:    entity.ErrInvalidCursor,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DecodeFeedCursor(tt.cursor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+				return
+			}
+			if diff := cmp.Diff(tt.wantCursor, got); diff != "" {
+				t.Errorf("Data (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
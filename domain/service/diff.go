@@ -0,0 +1,310 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// DiffContextLines はhunkに含める変更前後の行数です
+const DiffContextLines = 3
+
+const (
+	// MaxDiffLines は差分を取るコードの行数の上限です
+	MaxDiffLines = 10000
+	// MaxDiffEditDistance は差分を取る2つのコードの編集距離(追加と削除の行数の合計)の上限です
+	MaxDiffEditDistance = 2000
+)
+
+// SplitLines はコードを行ごとに分割します
+// 改行コードの違いで差分が出ないように\r\nは\nとして扱い，末尾の改行は行として数えません
+func SplitLines(code string) []string {
+	code = strings.ReplaceAll(code, "\r\n", "\n")
+	if len(code) == 0 {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(code, "\n"), "\n")
+}
+
+// DiffLines はoldCodeからnewCodeへの行単位の差分をhunkの一覧として返します
+// 言語に依存しないように，行の内容のみを比較します
+// 差分が大きすぎる場合はErrDiffTooLargeを返します
+func DiffLines(oldCode, newCode string) ([]*entity.DiffHunk, error) {
+	oldLines, newLines := SplitLines(oldCode), SplitLines(newCode)
+	edits, err := shortestEdit(oldLines, newLines)
+	if err != nil {
+		return nil, err
+	}
+	return newHunks(edits, oldLines, newLines, DiffContextLines), nil
+}
+
+// FormatUnifiedDiff はhunkの一覧をunified diff形式の文字列にします
+// 差分がない場合は空文字列を返します
+func FormatUnifiedDiff(oldName, newName string, hunks []*entity.DiffHunk) string {
+	if len(hunks) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", oldName, newName)
+	for _, hunk := range hunks {
+		fmt.Fprintf(&b, "@@ -%d,%d +%d,%d @@\n", hunk.OldStart, hunk.OldLines, hunk.NewStart, hunk.NewLines)
+		for _, line := range hunk.Lines {
+			switch line.Type {
+			case entity.DiffLineAdd:
+				b.WriteString("+")
+			case entity.DiffLineDelete:
+				b.WriteString("-")
+			default:
+				b.WriteString(" ")
+			}
+			b.WriteString(line.Content)
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+type editOp int
+
+const (
+	editEqual editOp = iota
+	editDelete
+	editInsert
+)
+
+// edit は編集スクリプトの1操作です
+// oldIdx, newIdxはそれぞれの行の0始まりのインデックスです
+type edit struct {
+	op     editOp
+	oldIdx int
+	newIdx int
+}
+
+// shortestEdit はMyersのアルゴリズムでaをbにする最短の編集スクリプトを求めます
+// 各ステップでは次のステップが参照する対角線の状態だけを記録するので，メモリは編集距離の2乗に比例します
+// 行数がMaxDiffLinesを超えるか，編集距離がMaxDiffEditDistanceを超える場合はErrDiffTooLargeを返します
+// ref: http://www.xmailserver.org/diff2.pdf
+func shortestEdit(a, b []string) ([]edit, error) {
+	n, m := len(a), len(b)
+	if n > MaxDiffLines || m > MaxDiffLines {
+		return nil, entity.ErrDiffTooLarge
+	}
+	max := n + m
+	if max > MaxDiffEditDistance {
+		max = MaxDiffEditDistance
+	}
+	offset := max + 1
+	v := make([]int, 2*max+3)
+	var trace [][]int
+
+	for d := 0; d <= max; d++ {
+		// ステップdが参照する対角線-d-1, -d+1, ..., d+1の状態だけを記録する
+		snapshot := make([]int, d+2)
+		for i := range snapshot {
+			snapshot[i] = v[offset-d-1+2*i]
+		}
+		trace = append(trace, snapshot)
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrackEdit(trace, n, m), nil
+			}
+		}
+	}
+	return nil, entity.ErrDiffTooLarge
+}
+
+// backtrackEdit はshortestEditで記録した各ステップの状態を終点から辿って編集スクリプトを復元します
+// trace[d]にはステップdの直前の対角線-d-1, -d+1, ..., d+1の状態が順に入っています
+func backtrackEdit(trace [][]int, n, m int) []edit {
+	var edits []edit
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		at := func(k int) int {
+			return v[(k+d+1)/2]
+		}
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{op: editEqual, oldIdx: x, newIdx: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{op: editInsert, oldIdx: x, newIdx: prevY})
+			} else {
+				edits = append(edits, edit{op: editDelete, oldIdx: prevX, newIdx: y})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// newHunks は編集スクリプトを前後contextLines行を含むhunkにまとめます
+// 間の変更のない行がcontextLinesの2倍以下なら1つのhunkにまとめます
+func newHunks(edits []edit, a, b []string, contextLines int) []*entity.DiffHunk {
+	var hunks []*entity.DiffHunk
+	i := 0
+	for i < len(edits) {
+		// 次の変更を探す
+		for i < len(edits) && edits[i].op == editEqual {
+			i++
+		}
+		if i == len(edits) {
+			break
+		}
+
+		start := i - contextLines
+		if start < 0 {
+			start = 0
+		}
+		// 変更が途切れてからcontextLinesの2倍を超えて変更がなければhunkを閉じる
+		end := i
+		for end < len(edits) {
+			if edits[end].op != editEqual {
+				end++
+				continue
+			}
+			next := end
+			for next < len(edits) && edits[next].op == editEqual {
+				next++
+			}
+			if next == len(edits) || next-end > 2*contextLines {
+				end += contextLines
+				if end > len(edits) {
+					end = len(edits)
+				}
+				break
+			}
+			end = next
+		}
+
+		hunks = append(hunks, newHunk(edits[start:end], a, b))
+		i = end
+	}
+	return hunks
+}
+
+// newHunk は編集スクリプトの一部から1つのhunkを生成します
+func newHunk(edits []edit, a, b []string) *entity.DiffHunk {
+	hunk := &entity.DiffHunk{
+		OldStart: edits[0].oldIdx,
+		NewStart: edits[0].newIdx,
+		Lines:    make([]*entity.DiffLine, 0, len(edits)),
+	}
+	for _, e := range edits {
+		switch e.op {
+		case editEqual:
+			hunk.OldLines++
+			hunk.NewLines++
+			hunk.Lines = append(hunk.Lines, &entity.DiffLine{
+				Type:    entity.DiffLineContext,
+				OldLine: e.oldIdx + 1,
+				NewLine: e.newIdx + 1,
+				Content: a[e.oldIdx],
+			})
+		case editDelete:
+			hunk.OldLines++
+			hunk.Lines = append(hunk.Lines, &entity.DiffLine{
+				Type:    entity.DiffLineDelete,
+				OldLine: e.oldIdx + 1,
+				Content: a[e.oldIdx],
+			})
+		case editInsert:
+			hunk.NewLines++
+			hunk.Lines = append(hunk.Lines, &entity.DiffLine{
+				Type:    entity.DiffLineAdd,
+				NewLine: e.newIdx + 1,
+				Content: b[e.newIdx],
+			})
+		}
+	}
+	// 行数が0でなければ開始行は1始まりの行番号にする
+	if hunk.OldLines > 0 {
+		hunk.OldStart++
+	}
+	if hunk.NewLines > 0 {
+		hunk.NewStart++
+	}
+	return hunk
+}
+
+// LineMapping は古いコードの各行が新しいコードで何行目に残っているかの対応を表します
+type LineMapping struct {
+	// newLines[i]は古いコードのi+1行目の新しいコードでの行番号で，残っていなければ0です
+	newLines []int
+}
+
+// NewLineMapping はoldCodeからnewCodeへの行の対応を求めます
+// 同じ2つのコードの間で複数の行範囲を投影する場合は，差分を1度だけ取るためにこれを使い回します
+// 差分が大きすぎる場合はErrDiffTooLargeを返します
+func NewLineMapping(oldCode, newCode string) (*LineMapping, error) {
+	oldLines, newLines := SplitLines(oldCode), SplitLines(newCode)
+	edits, err := shortestEdit(oldLines, newLines)
+	if err != nil {
+		return nil, err
+	}
+	mapping := &LineMapping{newLines: make([]int, len(oldLines))}
+	for _, e := range edits {
+		if e.op == editEqual {
+			mapping.newLines[e.oldIdx] = e.newIdx + 1
+		}
+	}
+	return mapping, nil
+}
+
+// Project は古いコードのfirstLineからlastLineまでの行範囲を新しいコードでの行範囲に投影します
+// 範囲内で残っている行のうち最初と最後の行の移動先を返し，全ての行が削除されていればokがfalseになります
+func (m *LineMapping) Project(firstLine, lastLine int) (first, last int, ok bool) {
+	for line := firstLine; line <= lastLine && line <= len(m.newLines); line++ {
+		if line < 1 || m.newLines[line-1] == 0 {
+			continue
+		}
+		if !ok {
+			first = m.newLines[line-1]
+			ok = true
+		}
+		last = m.newLines[line-1]
+	}
+	return first, last, ok
+}
+
+// ProjectLineRange はoldCodeのfirstLineからlastLineまでの行範囲をnewCodeでの行範囲に投影します
+// 範囲内で残っている行のうち最初と最後の行の移動先を返し，全ての行が削除されていればokがfalseになります
+// 差分が大きすぎる場合はErrDiffTooLargeを返します
+func ProjectLineRange(oldCode, newCode string, firstLine, lastLine int) (first, last int, ok bool, err error) {
+	mapping, err := NewLineMapping(oldCode, newCode)
+	if err != nil {
+		return 0, 0, false, err
+	}
+	first, last, ok = mapping.Project(firstLine, lastLine)
+	return first, last, ok, nil
+}
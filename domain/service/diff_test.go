@@ -0,0 +1,257 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldCode   string
+		newCode   string
+		wantHunks []*entity.DiffHunk
+	}{
+		{
+			name:      "同じコードなら差分はない",
+			oldCode:   "a\nb\nc\n",
+			newCode:   "a\nb\nc",
+			wantHunks: nil,
+		},
+		{
+			name:    "行の書き換えは削除と追加になる",
+			oldCode: "a\nb\nc",
+			newCode: "a\nB\nc",
+			wantHunks: []*entity.DiffHunk{
+				{
+					OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 3,
+					Lines: []*entity.DiffLine{
+						{Type: entity.DiffLineContext, OldLine: 1, NewLine: 1, Content: "a"},
+						{Type: entity.DiffLineDelete, OldLine: 2, Content: "b"},
+						{Type: entity.DiffLineAdd, NewLine: 2, Content: "B"},
+						{Type: entity.DiffLineContext, OldLine: 3, NewLine: 3, Content: "c"},
+					},
+				},
+			},
+		},
+		{
+			name:    "空のコードからの差分は全て追加になる",
+			oldCode: "",
+			newCode: "a\nb",
+			wantHunks: []*entity.DiffHunk{
+				{
+					OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 2,
+					Lines: []*entity.DiffLine{
+						{Type: entity.DiffLineAdd, NewLine: 1, Content: "a"},
+						{Type: entity.DiffLineAdd, NewLine: 2, Content: "b"},
+					},
+				},
+			},
+		},
+		{
+			name:    "離れた変更は別のhunkになる",
+			oldCode: "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12",
+			newCode: "0\n1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11",
+			wantHunks: []*entity.DiffHunk{
+				{
+					OldStart: 1, OldLines: 3, NewStart: 1, NewLines: 4,
+					Lines: []*entity.DiffLine{
+						{Type: entity.DiffLineAdd, NewLine: 1, Content: "0"},
+						{Type: entity.DiffLineContext, OldLine: 1, NewLine: 2, Content: "1"},
+						{Type: entity.DiffLineContext, OldLine: 2, NewLine: 3, Content: "2"},
+						{Type: entity.DiffLineContext, OldLine: 3, NewLine: 4, Content: "3"},
+					},
+				},
+				{
+					OldStart: 9, OldLines: 4, NewStart: 10, NewLines: 3,
+					Lines: []*entity.DiffLine{
+						{Type: entity.DiffLineContext, OldLine: 9, NewLine: 10, Content: "9"},
+						{Type: entity.DiffLineContext, OldLine: 10, NewLine: 11, Content: "10"},
+						{Type: entity.DiffLineContext, OldLine: 11, NewLine: 12, Content: "11"},
+						{Type: entity.DiffLineDelete, OldLine: 12, Content: "12"},
+					},
+				},
+			},
+		},
+		{
+			name:    "改行コードの違いは差分にならない",
+			oldCode: "a\r\nb\r\n",
+			newCode: "a\nb\nc\n",
+			wantHunks: []*entity.DiffHunk{
+				{
+					OldStart: 1, OldLines: 2, NewStart: 1, NewLines: 3,
+					Lines: []*entity.DiffLine{
+						{Type: entity.DiffLineContext, OldLine: 1, NewLine: 1, Content: "a"},
+						{Type: entity.DiffLineContext, OldLine: 2, NewLine: 2, Content: "b"},
+						{Type: entity.DiffLineAdd, NewLine: 3, Content: "c"},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DiffLines(tt.oldCode, tt.newCode)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantHunks, got); diff != "" {
+				t.Errorf("Hunks (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestDiffLines_TooLarge(t *testing.T) {
+	numberedLines := func(prefix string, n int) string {
+		lines := make([]string, n)
+		for i := range lines {
+			lines[i] = fmt.Sprintf("%s%d", prefix, i)
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	tests := []struct {
+		name    string
+		oldCode string
+		newCode string
+		wantErr error
+	}{
+		{
+			name:    "編集距離が上限以内なら差分を取れる",
+			oldCode: numberedLines("a", MaxDiffEditDistance/2),
+			newCode: numberedLines("b", MaxDiffEditDistance/2),
+			wantErr: nil,
+		},
+		{
+			name:    "行数が上限を超えるとErrDiffTooLarge",
+			oldCode: numberedLines("a", MaxDiffLines+1),
+			newCode: "a0",
+			wantErr: entity.ErrDiffTooLarge,
+		},
+		{
+			name:    "編集距離が上限を超えるとErrDiffTooLarge",
+			oldCode: numberedLines("a", MaxDiffEditDistance/2+1),
+			newCode: numberedLines("b", MaxDiffEditDistance/2),
+			wantErr: entity.ErrDiffTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DiffLines(tt.oldCode, tt.newCode); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatUnifiedDiff(t *testing.T) {
+	hunks, err := DiffLines("a\nb\nc", "a\nB\nc\nd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "--- revision/1\n+++ revision/2\n@@ -1,3 +1,4 @@\n a\n-b\n+B\n c\n+d\n"
+	if got := FormatUnifiedDiff("revision/1", "revision/2", hunks); got != want {
+		t.Errorf("\nwant: %q, \nbut: %q", want, got)
+	}
+
+	if got := FormatUnifiedDiff("revision/1", "revision/1", nil); got != "" {
+		t.Errorf("差分がなければ空文字列になるべき: %q", got)
+	}
+}
+
+func TestProjectLineRange(t *testing.T) {
+	tests := []struct {
+		name      string
+		oldCode   string
+		newCode   string
+		firstLine int
+		lastLine  int
+		wantFirst int
+		wantLast  int
+		wantOK    bool
+	}{
+		{
+			name:      "前に行が追加されると範囲がずれる",
+			oldCode:   "a\nb\nc",
+			newCode:   "x\ny\na\nb\nc",
+			firstLine: 2,
+			lastLine:  3,
+			wantFirst: 4,
+			wantLast:  5,
+			wantOK:    true,
+		},
+		{
+			name:      "範囲内に行が追加されると範囲が広がる",
+			oldCode:   "a\nb\nc",
+			newCode:   "a\nb\nx\nc",
+			firstLine: 2,
+			lastLine:  3,
+			wantFirst: 2,
+			wantLast:  4,
+			wantOK:    true,
+		},
+		{
+			name:      "範囲の一部が削除されると残った行に縮む",
+			oldCode:   "a\nb\nc\nd",
+			newCode:   "a\nd",
+			firstLine: 1,
+			lastLine:  3,
+			wantFirst: 1,
+			wantLast:  1,
+			wantOK:    true,
+		},
+		{
+			name:      "範囲の行が全て書き換えられると投影できない",
+			oldCode:   "a\nb\nc",
+			newCode:   "a\nB\nc",
+			firstLine: 2,
+			lastLine:  2,
+			wantOK:    false,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			first, last, ok, err := ProjectLineRange(tt.oldCode, tt.newCode, tt.firstLine, tt.lastLine)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != tt.wantOK || first != tt.wantFirst || last != tt.wantLast {
+				t.Errorf("got = (%d, %d, %v), want = (%d, %d, %v)", first, last, ok, tt.wantFirst, tt.wantLast, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestLineMapping_Project(t *testing.T) {
+	mapping, err := NewLineMapping("a\nb\nc\nd", "x\na\nc\nd\ny")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		firstLine, lastLine int
+		wantFirst, wantLast int
+		wantOK              bool
+	}{
+		{firstLine: 1, lastLine: 1, wantFirst: 2, wantLast: 2, wantOK: true},
+		{firstLine: 2, lastLine: 2, wantOK: false},
+		{firstLine: 2, lastLine: 4, wantFirst: 3, wantLast: 4, wantOK: true},
+		// 古いコードの範囲外の行は無視する
+		{firstLine: 4, lastLine: 10, wantFirst: 4, wantLast: 4, wantOK: true},
+	}
+	for _, tt := range tests {
+		first, last, ok := mapping.Project(tt.firstLine, tt.lastLine)
+		if ok != tt.wantOK || first != tt.wantFirst || last != tt.wantLast {
+			t.Errorf("Project(%d, %d) = (%d, %d, %v), want = (%d, %d, %v)",
+				tt.firstLine, tt.lastLine, first, last, ok, tt.wantFirst, tt.wantLast, tt.wantOK)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package service
+
+import "github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+
+// ProjectHighlights はhighlight, suggestionコメントの行範囲をtargetのリビジョンのコードに投影し，各コメントのProjectionにセットします
+// BaseRevisionが未設定のコメントは投稿時のリビジョンに対するものとして扱います
+// 複数のファイルからなる投稿では，コメントのFilenameのファイルのコード同士で行の対応を取ります
+// 同じリビジョンの同じファイルを指すコメントの間では，行の対応を1度だけ求めて使い回します
+// revisionsはNewRevisionsで生成されたリビジョンの一覧を想定しています
+func ProjectHighlights(comments []*entity.Comment, revisions []*entity.Revision, target *entity.Revision) {
+	mappings := map[lineMappingKey]*LineMapping{}
+	for _, comment := range comments {
+		if !comment.HasLineRange() || comment.Deleted {
+			continue
+		}
+
+		baseNumber := comment.BaseRevision
+		if baseNumber == 0 {
+			baseNumber = entity.OriginalRevision
+		}
+		projection := &entity.HighlightProjection{Revision: target.Number}
+		base, err := entity.FindRevision(revisions, baseNumber)
+		if err != nil {
+			// 元になったリビジョンが削除されていれば行の対応が取れない
+			projection.Outdated = true
+			comment.Projection = projection
+			continue
+		}
+
+		baseCode, baseOK := base.FileCode(comment.Filename)
+		targetCode, targetOK := target.FileCode(comment.Filename)
+		if !baseOK || !targetOK {
+			// どちらかのリビジョンにファイルが存在しなければ行の対応が取れない
+			projection.Outdated = true
+			comment.Projection = projection
+			continue
+		}
+
+		if base.Number == target.Number {
+			projection.FirstLine, projection.LastLine = comment.FirstLine, comment.LastLine
+			comment.Projection = projection
+			continue
+		}
+
+		key := lineMappingKey{baseRevision: base.Number, filename: comment.Filename}
+		mapping, cached := mappings[key]
+		if !cached {
+			// 差分が大きすぎて行の対応が取れない場合はnilを記録し，ハイライトした行は残っていないものとして扱う
+			mapping, _ = NewLineMapping(baseCode, targetCode)
+			mappings[key] = mapping
+		}
+		if mapping == nil {
+			projection.Outdated = true
+		} else if first, last, ok := mapping.Project(comment.FirstLine, comment.LastLine); ok {
+			projection.FirstLine, projection.LastLine = first, last
+		} else {
+			projection.Outdated = true
+		}
+		comment.Projection = projection
+	}
+}
+
+// lineMappingKey はProjectHighlightsで行の対応を使い回す単位です
+type lineMappingKey struct {
+	baseRevision int
+	filename     string
+}
+
+// ValidateHighlightRange はhighlightコメントの範囲がcodeに収まっているかを検証します
+// 範囲が逆転している場合やcodeの行数・行の文字数を超えている場合はErrOutOfRangeを返します
+func ValidateHighlightRange(comment *entity.Comment, code string) error {
+	lines := SplitLines(code)
+	if comment.FirstLine <= 0 {
+		return entity.NewErrorOutOfRange("comment FirstLine")
+	}
+	if comment.FirstLine > comment.LastLine {
+		return entity.NewErrorOutOfRange("comment LastLine")
+	}
+	if comment.FirstLine > len(lines) {
+		return entity.NewErrorOutOfRange("comment FirstLine")
+	}
+	if comment.LastLine > len(lines) {
+		return entity.NewErrorOutOfRange("comment LastLine")
+	}
+	if comment.FirstColumn > len([]rune(lines[comment.FirstLine-1])) {
+		return entity.NewErrorOutOfRange("comment FirstColumn")
+	}
+	if comment.LastColumn > len([]rune(lines[comment.LastLine-1])) {
+		return entity.NewErrorOutOfRange("comment LastColumn")
+	}
+	if comment.FirstLine == comment.LastLine && comment.LastColumn != 0 && comment.FirstColumn > comment.LastColumn {
+		return entity.NewErrorOutOfRange("comment LastColumn")
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestValidateHighlightRange(t *testing.T) {
+	code := "package main\n\nfunc main() {}\n"
+	tests := []struct {
+		name    string
+		comment *entity.Comment
+		wantErr error
+	}{
+		{
+			name:    "コードの行数に収まっていればnilを返す",
+			comment: &entity.Comment{Type: "highlight", FirstLine: 1, LastLine: 3},
+			wantErr: nil,
+		},
+		{
+			name:    "行内の列に収まっていればnilを返す",
+			comment: &entity.Comment{Type: "highlight", FirstLine: 3, LastLine: 3, FirstColumn: 1, LastColumn: 14},
+			wantErr: nil,
+		},
+		{
+			name:    "範囲が逆転していればエラー",
+			comment: &entity.Comment{Type: "highlight", FirstLine: 3, LastLine: 1},
+			wantErr: entity.NewErrorOutOfRange("comment LastLine"),
+		},
+		{
+			name:    "LastLineがコードの行数を超えていればエラー",
+			comment: &entity.Comment{Type: "highlight", FirstLine: 1, LastLine: 4},
+			wantErr: entity.NewErrorOutOfRange("comment LastLine"),
+		},
+		{
+			name:    "FirstLineがコードの行数を超えていればエラー",
+			comment: &entity.Comment{Type: "highlight", FirstLine: 4, LastLine: 4},
+			wantErr: entity.NewErrorOutOfRange("comment FirstLine"),
+		},
+		{
+			name:    "空行に列を指定するとエラー",
+			comment: &entity.Comment{Type: "highlight", FirstLine: 2, LastLine: 3, FirstColumn: 1},
+			wantErr: entity.NewErrorOutOfRange("comment FirstColumn"),
+		},
+		{
+			name:    "LastColumnが行の文字数を超えていればエラー",
+			comment: &entity.Comment{Type: "highlight", FirstLine: 1, LastLine: 1, LastColumn: 13},
+			wantErr: entity.NewErrorOutOfRange("comment LastColumn"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateHighlightRange(tt.comment, code); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestProjectHighlights_Files(t *testing.T) {
+	revisions := []*entity.Revision{
+		{Number: 1, Code: "a\nb\n", Files: []*entity.PostFile{
+			{Filename: "main.go", Content: "a\nb\n"},
+			{Filename: "main_test.go", Content: "x\ny\n"},
+		}},
+		{Number: 2, Code: "a\nb\n", Files: []*entity.PostFile{
+			{Filename: "main.go", Content: "a\nb\n"},
+			{Filename: "main_test.go", Content: "added\nx\ny\n"},
+		}},
+	}
+	comments := []*entity.Comment{
+		{ID: 1, Type: "highlight", Filename: "main.go", FirstLine: 2, LastLine: 2, BaseRevision: 1},
+		{ID: 2, Type: "highlight", Filename: "main_test.go", FirstLine: 2, LastLine: 2, BaseRevision: 1},
+		{ID: 3, Type: "highlight", Filename: "unknown.go", FirstLine: 1, LastLine: 1, BaseRevision: 1},
+	}
+	want := []*entity.HighlightProjection{
+		{Revision: 2, FirstLine: 2, LastLine: 2},
+		// 同じファイルの中での行のずれだけを反映する
+		{Revision: 2, FirstLine: 3, LastLine: 3},
+		{Revision: 2, Outdated: true},
+	}
+
+	ProjectHighlights(comments, revisions, revisions[1])
+	for i, comment := range comments {
+		if *comment.Projection != *want[i] {
+			t.Errorf("comment %d Projection = %+v, want = %+v", comment.ID, *comment.Projection, *want[i])
+		}
+	}
+}
+
+func TestProjectHighlights_SharedMapping(t *testing.T) {
+	revisions := []*entity.Revision{
+		{Number: 1, Code: "a\nb\nc\n"},
+		{Number: 2, Code: "x\na\nb\nc\n"},
+		{Number: 3, Code: "y\nx\na\nb\n"},
+	}
+	comments := []*entity.Comment{
+		{ID: 1, Type: "highlight", FirstLine: 1, LastLine: 1, BaseRevision: 1},
+		{ID: 2, Type: "highlight", FirstLine: 2, LastLine: 3, BaseRevision: 1},
+		// 同じファイルでも基準のリビジョンが違えば別の行の対応を使う
+		{ID: 3, Type: "highlight", FirstLine: 1, LastLine: 1, BaseRevision: 2},
+		{ID: 4, Type: "highlight", FirstLine: 3, LastLine: 3, BaseRevision: 1},
+		{ID: 5, Type: "highlight", FirstLine: 1, LastLine: 1, BaseRevision: 3},
+	}
+	want := []*entity.HighlightProjection{
+		{Revision: 3, FirstLine: 3, LastLine: 3},
+		{Revision: 3, FirstLine: 4, LastLine: 4},
+		{Revision: 3, FirstLine: 2, LastLine: 2},
+		{Revision: 3, Outdated: true},
+		{Revision: 3, FirstLine: 1, LastLine: 1},
+	}
+
+	ProjectHighlights(comments, revisions, revisions[2])
+	for i, comment := range comments {
+		if *comment.Projection != *want[i] {
+			t.Errorf("comment %d Projection = %+v, want = %+v", comment.ID, *comment.Projection, *want[i])
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package service
+
+import (
+	"math"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// languageHint はコードに含まれているとその言語らしいと判断できるパターンとその重みです
+type languageHint struct {
+	pattern *regexp.Regexp
+	weight  float64
+}
+
+// confidentScore はこのスコア以上であれば他の言語と競合していない限り確信度を1とみなすスコアです
+const confidentScore = 6
+
+// languageHints は言語のIDごとのパターンの一覧です
+// 1つのパターンはコード中に何度現れても1回分として数えます
+var languageHints = map[string][]languageHint{
+	"c": {
+		hint(`#include\s*<\w+\.h>`, 3),
+		hint(`\bprintf\(`, 2),
+		hint(`\bint\s+main\s*\(`, 2),
+		hint(`\b(malloc|free)\(`, 2),
+	},
+	"cpp": {
+		hint(`#include\s*<\w+>`, 3),
+		hint(`\bstd::`, 3),
+		hint(`\b(cout|cin)\s*(<<|>>)`, 3),
+		hint(`\busing\s+namespace\s+std\b`, 3),
+		hint(`\btemplate\s*<`, 2),
+		hint(`\bint\s+main\s*\(`, 1),
+	},
+	"csharp": {
+		hint(`(?m)^using\s+System(\.\w+)*;`, 4),
+		hint(`\bConsole\.Write(Line)?\(`, 3),
+		hint(`\bnamespace\s+[\w.]+`, 1),
+		hint(`\bpublic\s+(static\s+)?void\s+Main\(`, 3),
+	},
+	"css": {
+		hint(`(?m)^\s*[\w-]+\s*:\s*[^;{}]+;\s*$`, 2),
+		hint(`(?m)^\s*[.#]?[\w-]+(\s*[,>+~]?\s*[.#]?[\w-]+)*\s*\{\s*$`, 1),
+		hint(`@(media|import|keyframes)\b`, 3),
+	},
+	"dart": {
+		hint(`\bimport\s+'package:`, 4),
+		hint(`\bvoid\s+main\(\)`, 2),
+		hint(`\bfinal\s+\w+\s*=`, 1),
+	},
+	"go": {
+		hint(`(?m)^package\s+\w+\s*$`, 3),
+		hint(`\bfunc\s+(\(\w+\s+\*?\w+\)\s*)?\w+\(`, 3),
+		hint(`:=`, 1),
+		hint(`\bfmt\.\w+\(`, 2),
+		hint(`(?m)^import\s+\(`, 2),
+		hint(`\bgo\s+func\b`, 2),
+		hint(`\bchan\b`, 1),
+	},
+	"haskell": {
+		hint(`(?m)^\w+\s*::\s*\S`, 3),
+		hint(`(?m)^module\s+[\w.]+\s+where\b`, 3),
+		hint(`\bputStrLn\b`, 3),
+	},
+	"html": {
+		hint(`(?i)<!DOCTYPE\s+html>`, 5),
+		hint(`<(html|head|body|div|span|p|a|ul|li)(\s[^>]*)?>`, 2),
+		hint(`</\w+>`, 1),
+	},
+	"java": {
+		hint(`\bpublic\s+(final\s+)?class\s+\w+`, 2),
+		hint(`\bpublic\s+static\s+void\s+main\s*\(\s*String`, 4),
+		hint(`\bSystem\.out\.print`, 3),
+		hint(`(?m)^import\s+java\.`, 3),
+	},
+	"javascript": {
+		hint(`\bconsole\.log\(`, 3),
+		hint(`\bfunction\s*\w*\s*\(`, 2),
+		hint(`=>`, 1),
+		hint(`\b(const|let)\s+\w+\s*=`, 1),
+		hint(`\brequire\(['"]`, 2),
+		hint(`\b(document|window)\.`, 2),
+	},
+	"kotlin": {
+		hint(`\bfun\s+\w+\(`, 3),
+		hint(`\bval\s+\w+\s*[:=]`, 1),
+		hint(`\bprintln\(`, 1),
+	},
+	"php": {
+		hint(`<\?php`, 6),
+		hint(`\$\w+\s*=`, 1),
+		hint(`\becho\s+`, 1),
+	},
+	"python": {
+		hint(`(?m)^\s*def\s+\w+\(.*\)\s*(->\s*[\w\[\], .]+)?:\s*$`, 3),
+		hint(`(?m)^\s*(from\s+[\w.]+\s+)?import\s+[\w.]+(\s+as\s+\w+)?\s*$`, 1),
+		hint(`if\s+__name__\s*==\s*['"]__main__['"]`, 4),
+		hint(`\bprint\(`, 1),
+		hint(`\belif\b`, 2),
+		hint(`\bself\.`, 1),
+		hint(`\b(None|True|False)\b`, 1),
+	},
+	"ruby": {
+		hint(`(?m)^\s*def\s+\w+[?!]?(\(.*\))?\s*$`, 2),
+		hint(`(?m)^\s*end\s*$`, 2),
+		hint(`\bputs\b`, 2),
+		hint(`\brequire\s+['"]`, 2),
+		hint(`\.each\s+do\b`, 3),
+		hint(`\battr_(accessor|reader|writer)\b`, 3),
+	},
+	"rust": {
+		hint(`\bfn\s+\w+\s*(<[^>]*>)?\(`, 3),
+		hint(`\blet\s+mut\b`, 3),
+		hint(`\bprintln!\(`, 3),
+		hint(`\buse\s+std::`, 3),
+		hint(`\bimpl\b`, 2),
+	},
+	"scala": {
+		hint(`\bobject\s+\w+`, 2),
+		hint(`\bdef\s+\w+\(.*\)\s*:\s*\w+\s*=`, 3),
+		hint(`\bcase\s+class\b`, 3),
+	},
+	"shell": {
+		hint(`(?m)^\s*(if|while)\s+\[.*\]\s*;\s*then\b`, 3),
+		hint(`(?m)^\s*for\s+\w+\s+in\s+.*;\s*do\b`, 3),
+		hint(`(?m)^\s*(fi|esac|done)\s*$`, 2),
+		hint(`(?m)^\s*echo\s+`, 1),
+		hint(`\$\{?\w+\}?`, 1),
+	},
+	"sql": {
+		hint(`(?is)\bSELECT\b.+\bFROM\b`, 3),
+		hint(`(?i)\bCREATE\s+TABLE\b`, 4),
+		hint(`(?i)\bINSERT\s+INTO\b`, 4),
+		hint(`(?i)\bUPDATE\s+\w+\s+SET\b`, 4),
+		hint(`(?i)\bWHERE\b`, 1),
+	},
+	"swift": {
+		hint(`(?m)^import\s+(Foundation|UIKit|SwiftUI)\b`, 4),
+		hint(`\bfunc\s+\w+\(.*\)\s*->`, 2),
+		hint(`\bguard\s+let\b`, 3),
+		hint(`\bvar\s+\w+\s*:\s*\w+`, 1),
+	},
+	"typescript": {
+		hint(`\b\w+\s*:\s*(string|number|boolean|any|void)\b`, 3),
+		hint(`\binterface\s+\w+\s*\{`, 2),
+		hint(`\btype\s+\w+\s*=`, 2),
+		hint(`\bimport\s+.*\s+from\s+['"]`, 1),
+		hint(`\b(const|let)\s+\w+\s*=`, 1),
+	},
+}
+
+func hint(pattern string, weight float64) languageHint {
+	return languageHint{pattern: regexp.MustCompile(pattern), weight: weight}
+}
+
+// DetectLanguage はコードの内容から言語を推定し，その言語のIDと0から1の確信度を返します
+// 1行目にshebangがあればそれを優先し，なければ言語ごとの構文のパターンの一致度で推定します
+// どの言語らしさも見つからなければ確信度0のplaintextを返します
+func DetectLanguage(code string) *entity.LanguageDetection {
+	if lang, ok := detectShebang(code); ok {
+		return &entity.LanguageDetection{Language: lang.ID, Confidence: 1}
+	}
+
+	var best string
+	var bestScore, total float64
+	// mapの走査順に結果が左右されないように登録順に調べる
+	for _, lang := range entity.Languages() {
+		var score float64
+		for _, h := range languageHints[lang.ID] {
+			if h.pattern.MatchString(code) {
+				score += h.weight
+			}
+		}
+		total += score
+		if score > bestScore {
+			best, bestScore = lang.ID, score
+		}
+	}
+	if bestScore == 0 {
+		return &entity.LanguageDetection{Language: entity.PlainTextLanguageID, Confidence: 0}
+	}
+
+	// 他の言語のスコアが高いほど，また一致したパターンが少ないほど確信度を下げる
+	confidence := bestScore / total * math.Min(1, bestScore/confidentScore)
+	return &entity.LanguageDetection{
+		Language:   best,
+		Confidence: math.Round(confidence*100) / 100,
+	}
+}
+
+// DetectFileLanguage はファイル名の拡張子から言語を求め，拡張子が登録されていなければファイルの内容から推定します
+// 拡張子から求めた場合の確信度は1です．拡張子が登録されておらず内容も空ならnilを返します
+func DetectFileLanguage(filename, content string) *entity.LanguageDetection {
+	if lang, ok := entity.FindLanguageByExtension(path.Ext(filename)); ok {
+		return &entity.LanguageDetection{Language: lang.ID, Confidence: 1}
+	}
+	if len(content) == 0 {
+		return nil
+	}
+	return DetectLanguage(content)
+}
+
+// detectShebang は1行目のshebangに書かれたインタプリタから言語を求めます
+func detectShebang(code string) (*entity.Language, bool) {
+	if !strings.HasPrefix(code, "#!") {
+		return nil, false
+	}
+	line := strings.TrimPrefix(code, "#!")
+	if idx := strings.IndexAny(line, "\r\n"); idx >= 0 {
+		line = line[:idx]
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return nil, false
+	}
+	interpreter := path.Base(fields[0])
+	// #!/usr/bin/env python3 のようにenvを経由している場合はその引数がインタプリタ
+	if interpreter == "env" {
+		args := fields[1:]
+		for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			return nil, false
+		}
+		interpreter = path.Base(args[0])
+	}
+
+	if lang, ok := entity.FindLanguage(interpreter); ok {
+		return lang, true
+	}
+	// python3.9のようなバージョンつきの名前はバージョンを取り除いて探す
+	return entity.FindLanguage(strings.TrimRight(interpreter, "0123456789."))
+}
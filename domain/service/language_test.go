@@ -0,0 +1,134 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name              string
+		code              string
+		wantLanguage      string
+		wantMinConfidence float64
+	}{
+		{
+			name:              "shebangがあればその言語になる",
+			code:              "#!/usr/bin/env python3\nx = 1\n",
+			wantLanguage:      "python",
+			wantMinConfidence: 1,
+		},
+		{
+			name:              "shebangのインタプリタが別名でも正規のIDになる",
+			code:              "#!/bin/bash\nls\n",
+			wantLanguage:      "shell",
+			wantMinConfidence: 1,
+		},
+		{
+			name:              "Go",
+			code:              "package main\n\nimport \"fmt\"\n\nfunc main() {\n\tmsg := \"hello\"\n\tfmt.Println(msg)\n}\n",
+			wantLanguage:      "go",
+			wantMinConfidence: 0.6,
+		},
+		{
+			name:              "Python",
+			code:              "import sys\n\ndef main():\n    if len(sys.argv) > 1:\n        print(sys.argv[1])\n    elif True:\n        print(None)\n\nif __name__ == '__main__':\n    main()\n",
+			wantLanguage:      "python",
+			wantMinConfidence: 0.6,
+		},
+		{
+			name:              "C++",
+			code:              "#include <iostream>\nusing namespace std;\n\nint main() {\n    cout << \"hello\" << endl;\n}\n",
+			wantLanguage:      "cpp",
+			wantMinConfidence: 0.6,
+		},
+		{
+			name:              "Rust",
+			code:              "use std::io;\n\nfn main() {\n    let mut s = String::new();\n    println!(\"{}\", s);\n}\n",
+			wantLanguage:      "rust",
+			wantMinConfidence: 0.6,
+		},
+		{
+			name:              "Java",
+			code:              "public class Main {\n    public static void main(String[] args) {\n        System.out.println(\"hello\");\n    }\n}\n",
+			wantLanguage:      "java",
+			wantMinConfidence: 0.6,
+		},
+		{
+			name:              "SQL",
+			code:              "SELECT id, name\nFROM users\nWHERE id = 1;\n",
+			wantLanguage:      "sql",
+			wantMinConfidence: 0.6,
+		},
+		{
+			name:              "PHP",
+			code:              "<?php\n$name = 'world';\necho \"hello $name\";\n",
+			wantLanguage:      "php",
+			wantMinConfidence: 0.6,
+		},
+		{
+			name:              "手がかりがなければ確信度0のplaintext",
+			code:              "hello world",
+			wantLanguage:      entity.PlainTextLanguageID,
+			wantMinConfidence: 0,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectLanguage(tt.code)
+			if got.Language != tt.wantLanguage {
+				t.Errorf("Language = %s, want = %s", got.Language, tt.wantLanguage)
+			}
+			if got.Confidence < tt.wantMinConfidence || got.Confidence > 1 {
+				t.Errorf("Confidence = %v, want >= %v", got.Confidence, tt.wantMinConfidence)
+			}
+		})
+	}
+}
+
+func TestDetectFileLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+		content  string
+		want     *entity.LanguageDetection
+	}{
+		{
+			name:     "拡張子が登録されていれば内容によらず確信度1でその言語",
+			filename: "src/Main.PY",
+			content:  "package main",
+			want:     &entity.LanguageDetection{Language: "python", Confidence: 1},
+		},
+		{
+			name:     "拡張子が登録されていなければ内容から推定する",
+			filename: "Makefile",
+			content:  "hello world",
+			want:     &entity.LanguageDetection{Language: entity.PlainTextLanguageID, Confidence: 0},
+		},
+		{
+			name:     "拡張子が登録されておらず内容も空ならnil",
+			filename: "EMPTY",
+			content:  "",
+			want:     nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := DetectFileLanguage(tt.filename, tt.content)
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("DetectFileLanguage(%q) = %+v, want = %+v", tt.filename, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectLanguage_HintsAreRegistered(t *testing.T) {
+	for id := range languageHints {
+		if _, ok := entity.FindLanguage(id); !ok {
+			t.Errorf("%sは言語の一覧に登録されていない", id)
+		}
+	}
+}
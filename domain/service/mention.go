@@ -0,0 +1,23 @@
+package service
+
+import "regexp"
+
+// mentionPattern は"@ユーザID"の形式のメンションにマッチします
+// メールアドレスなどを拾わないように，@の直前は行頭か英数字以外である必要があります
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w-]{1,128})`)
+
+// ExtractMentions は本文に含まれるメンションのユーザIDを出現順に重複なく返します
+// ユーザが実在するかどうかは検証しません
+func ExtractMentions(content string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, match := range mentionPattern.FindAllStringSubmatch(content, -1) {
+		id := match[1]
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	return ids
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExtractMentions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "出現順に重複なく取り出せる",
+			content: "@user-b thanks!\ncc @user_a, @user-b",
+			want:    []string{"user-b", "user_a"},
+		},
+		{
+			name:    "メールアドレスはメンションとして扱わない",
+			content: "contact: foo@example.com",
+			want:    nil,
+		},
+		{
+			name:    "@だけならメンションとして扱わない",
+			content: "@ @@",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if diff := cmp.Diff(tt.want, ExtractMentions(tt.content)); diff != "" {
+				t.Errorf("ExtractMentions (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
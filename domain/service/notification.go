@@ -0,0 +1,63 @@
+package service
+
+import "github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+
+// NewCommentNotifications はコメントの作成によって発生する通知を受け取るユーザごとに生成します
+// parentは返信先のコメントで，返信でなければnilです
+// 1人のユーザには1つだけ通知し，メンション，返信，投稿へのコメントの順に優先します
+// コメントをした本人には通知しません
+func NewCommentNotifications(post *entity.Post, parent, comment *entity.Comment, mentionedIDs []string) []*entity.Notification {
+	var notifications []*entity.Notification
+	notified := map[string]bool{comment.UserID: true}
+	add := func(userID, notificationType string) {
+		if notified[userID] {
+			return
+		}
+		notified[userID] = true
+		notifications = append(notifications, &entity.Notification{
+			UserID:    userID,
+			ActorID:   comment.UserID,
+			Type:      notificationType,
+			PostID:    comment.PostID,
+			CommentID: comment.ID,
+		})
+	}
+
+	for _, id := range mentionedIDs {
+		add(id, entity.NotificationMention)
+	}
+	if parent != nil {
+		add(parent.UserID, entity.NotificationReply)
+	}
+	if comment.Type == "highlight" {
+		add(post.UserID, entity.NotificationHighlight)
+	} else {
+		add(post.UserID, entity.NotificationComment)
+	}
+	return notifications
+}
+
+// NewMentionNotifications は本文でメンションされたユーザのうち，previousに含まれていないユーザへの通知を生成します
+// previousは更新前の本文でのメンションで，作成時はnilです
+// commentIDが0なら投稿の本文でのメンションです．本文を書いた本人には通知しません
+func NewMentionNotifications(actorID string, postID, commentID int, mentions, previous []*entity.Mention) []*entity.Notification {
+	var notifications []*entity.Notification
+	notified := map[string]bool{actorID: true}
+	for _, mention := range previous {
+		notified[mention.UserID] = true
+	}
+	for _, mention := range mentions {
+		if notified[mention.UserID] {
+			continue
+		}
+		notified[mention.UserID] = true
+		notifications = append(notifications, &entity.Notification{
+			UserID:    mention.UserID,
+			ActorID:   actorID,
+			Type:      entity.NotificationMention,
+			PostID:    postID,
+			CommentID: commentID,
+		})
+	}
+	return notifications
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestNewCommentNotifications(t *testing.T) {
+	post := &entity.Post{ID: 1, UserID: "owner"}
+	tests := []struct {
+		name         string
+		parent       *entity.Comment
+		comment      *entity.Comment
+		mentionedIDs []string
+		want         []*entity.Notification
+	}{
+		{
+			name:    "highlightコメントは投稿のオーナーにhighlightとして通知する",
+			comment: &entity.Comment{ID: 10, UserID: "reviewer", PostID: 1, Type: "highlight"},
+			want: []*entity.Notification{
+				{UserID: "owner", ActorID: "reviewer", Type: entity.NotificationHighlight, PostID: 1, CommentID: 10},
+			},
+		},
+		{
+			name:    "返信は返信先のコメントの作者と投稿のオーナーに通知する",
+			parent:  &entity.Comment{ID: 5, UserID: "author", PostID: 1},
+			comment: &entity.Comment{ID: 10, UserID: "reviewer", PostID: 1, Type: "none", ParentID: 5},
+			want: []*entity.Notification{
+				{UserID: "author", ActorID: "reviewer", Type: entity.NotificationReply, PostID: 1, CommentID: 10},
+				{UserID: "owner", ActorID: "reviewer", Type: entity.NotificationComment, PostID: 1, CommentID: 10},
+			},
+		},
+		{
+			name:         "1人のユーザにはメンションを優先して1つだけ通知する",
+			parent:       &entity.Comment{ID: 5, UserID: "owner", PostID: 1},
+			comment:      &entity.Comment{ID: 10, UserID: "reviewer", PostID: 1, Type: "none", ParentID: 5},
+			mentionedIDs: []string{"owner"},
+			want: []*entity.Notification{
+				{UserID: "owner", ActorID: "reviewer", Type: entity.NotificationMention, PostID: 1, CommentID: 10},
+			},
+		},
+		{
+			name:         "コメントをした本人には通知しない",
+			parent:       &entity.Comment{ID: 5, UserID: "owner", PostID: 1},
+			comment:      &entity.Comment{ID: 10, UserID: "owner", PostID: 1, Type: "commit", ParentID: 5},
+			mentionedIDs: []string{"owner"},
+			want:         nil,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewCommentNotifications(post, tt.parent, tt.comment, tt.mentionedIDs)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewCommentNotifications (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
+
+func TestNewMentionNotifications(t *testing.T) {
+	tests := []struct {
+		name      string
+		commentID int
+		mentions  []*entity.Mention
+		previous  []*entity.Mention
+		want      []*entity.Notification
+	}{
+		{
+			name:      "メンションされたユーザに通知する",
+			commentID: 10,
+			mentions:  []*entity.Mention{{UserID: "user1"}, {UserID: "user2"}},
+			want: []*entity.Notification{
+				{UserID: "user1", ActorID: "author", Type: entity.NotificationMention, PostID: 1, CommentID: 10},
+				{UserID: "user2", ActorID: "author", Type: entity.NotificationMention, PostID: 1, CommentID: 10},
+			},
+		},
+		{
+			name:     "更新前からメンションされていたユーザと本人には通知しない",
+			mentions: []*entity.Mention{{UserID: "user1"}, {UserID: "author"}, {UserID: "user2"}},
+			previous: []*entity.Mention{{UserID: "user1"}},
+			want: []*entity.Notification{
+				{UserID: "user2", ActorID: "author", Type: entity.NotificationMention, PostID: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewMentionNotifications("author", 1, tt.commentID, tt.mentions, tt.previous)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("NewMentionNotifications (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// ApplySuggestion はsuggestionコメントの行範囲を最新のリビジョンのコードに投影し，その行をsuggestionのCodeで置き換えたコードを返します
+// 提案した行が最新のリビジョンまでに書き換えられていれば，提案の意図が保てないのでErrSuggestionOutdatedを返します
+// 全ての行を削除する提案で適用後のコードが空になる場合は，commitとして保存できないのでErrSuggestionEmptiesCodeを返します
+// 複数のファイルからなる投稿では，suggestionコメントのFilenameのファイルのコードを返します
+// revisionsはNewRevisionsで生成されたリビジョンの一覧を想定しています
+func ApplySuggestion(suggestion *entity.Comment, revisions []*entity.Revision) (string, error) {
+	baseNumber := suggestion.BaseRevision
+	if baseNumber == 0 {
+		baseNumber = entity.OriginalRevision
+	}
+	base, err := entity.FindRevision(revisions, baseNumber)
+	if err != nil {
+		// 元になったリビジョンが削除されていれば行の対応が取れない
+		return "", entity.ErrSuggestionOutdated
+	}
+	latest := entity.LatestRevision(revisions)
+	baseCode, ok := base.FileCode(suggestion.Filename)
+	if !ok {
+		return "", entity.ErrSuggestionOutdated
+	}
+	latestCode, ok := latest.FileCode(suggestion.Filename)
+	if !ok {
+		return "", entity.ErrSuggestionOutdated
+	}
+
+	baseLines, latestLines := SplitLines(baseCode), SplitLines(latestCode)
+	if suggestion.LastLine > len(baseLines) {
+		return "", entity.NewErrorOutOfRange("comment LastLine")
+	}
+	first, last := suggestion.FirstLine, suggestion.LastLine
+	if base.Number != latest.Number {
+		if first, last, ok, err = ProjectLineRange(baseCode, latestCode, suggestion.FirstLine, suggestion.LastLine); err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", entity.ErrSuggestionOutdated
+		}
+	}
+	if !equalLines(baseLines[suggestion.FirstLine-1:suggestion.LastLine], latestLines[first-1:last]) {
+		return "", entity.ErrSuggestionOutdated
+	}
+
+	lines := make([]string, 0, len(latestLines))
+	lines = append(lines, latestLines[:first-1]...)
+	lines = append(lines, SplitLines(suggestion.Code)...)
+	lines = append(lines, latestLines[last:]...)
+	code := strings.Join(lines, "\n")
+	if len(code) == 0 {
+		return "", entity.ErrSuggestionEmptiesCode
+	}
+	// 末尾の改行の有無は元のコードに合わせる
+	if len(lines) > 0 && strings.HasSuffix(latestCode, "\n") {
+		code += "\n"
+	}
+	return code, nil
+}
+
+// equalLines は2つの行の並びが同じかどうかを返します
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestApplySuggestion(t *testing.T) {
+	original := &entity.Revision{Number: 1, Code: "a\nb\nc\nd\n"}
+	inserted := &entity.Revision{Number: 2, Code: "a\nadded\nb\nc\nd\n"}
+	rewritten := &entity.Revision{Number: 2, Code: "a\nB\nc\nd\n"}
+	multiFile := &entity.Revision{Number: 1, Code: "a\nb\n", Files: []*entity.PostFile{
+		{Filename: "main.go", Content: "a\nb\n"},
+		{Filename: "main_test.go", Content: "x\ny\n"},
+	}}
+	tests := []struct {
+		name       string
+		suggestion *entity.Comment
+		revisions  []*entity.Revision
+		want       string
+		wantErr    error
+	}{
+		{
+			name:       "指定した行を置き換える",
+			suggestion: &entity.Comment{Type: "suggestion", FirstLine: 2, LastLine: 3, Code: "x\ny\nz", BaseRevision: 1},
+			revisions:  []*entity.Revision{original},
+			want:       "a\nx\ny\nz\nd\n",
+		},
+		{
+			name:       "Codeが空なら行を削除する",
+			suggestion: &entity.Comment{Type: "suggestion", FirstLine: 1, LastLine: 1, BaseRevision: 1},
+			revisions:  []*entity.Revision{original},
+			want:       "b\nc\nd\n",
+		},
+		{
+			name:       "全ての行を削除してコードが空になるなら適用できない",
+			suggestion: &entity.Comment{Type: "suggestion", FirstLine: 1, LastLine: 4, BaseRevision: 1},
+			revisions:  []*entity.Revision{original},
+			wantErr:    entity.ErrSuggestionEmptiesCode,
+		},
+		{
+			name:       "BaseRevisionが未設定なら投稿時のリビジョンに対する提案として扱う",
+			suggestion: &entity.Comment{Type: "suggestion", FirstLine: 4, LastLine: 4, Code: "D"},
+			revisions:  []*entity.Revision{original},
+			want:       "a\nb\nc\nD\n",
+		},
+		{
+			name:       "提案後に行がずれていれば最新のリビジョンに投影して置き換える",
+			suggestion: &entity.Comment{Type: "suggestion", FirstLine: 2, LastLine: 2, Code: "B", BaseRevision: 1},
+			revisions:  []*entity.Revision{original, inserted},
+			want:       "a\nadded\nB\nc\nd\n",
+		},
+		{
+			name:       "提案した行が書き換えられていれば適用できない",
+			suggestion: &entity.Comment{Type: "suggestion", FirstLine: 2, LastLine: 3, Code: "x", BaseRevision: 1},
+			revisions:  []*entity.Revision{original, rewritten},
+			wantErr:    entity.ErrSuggestionOutdated,
+		},
+		{
+			name:       "複数のファイルからなる投稿ではFilenameのファイルを置き換える",
+			suggestion: &entity.Comment{Type: "suggestion", Filename: "main_test.go", FirstLine: 2, LastLine: 2, Code: "Y", BaseRevision: 1},
+			revisions:  []*entity.Revision{multiFile},
+			want:       "x\nY\n",
+		},
+		{
+			name:       "Filenameのファイルが存在しなければ適用できない",
+			suggestion: &entity.Comment{Type: "suggestion", Filename: "unknown.go", FirstLine: 1, LastLine: 1, Code: "x", BaseRevision: 1},
+			revisions:  []*entity.Revision{multiFile},
+			wantErr:    entity.ErrSuggestionOutdated,
+		},
+		{
+			name:       "元になったリビジョンがなければ適用できない",
+			suggestion: &entity.Comment{Type: "suggestion", FirstLine: 1, LastLine: 1, Code: "x", BaseRevision: 3},
+			revisions:  []*entity.Revision{original},
+			wantErr:    entity.ErrSuggestionOutdated,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ApplySuggestion(tt.suggestion, tt.revisions)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ApplySuggestion() = %q, want = %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -44,18 +44,37 @@ func (r *CommentRepository) FindByID(ctx context.Context, postID, commentID int)
 			return nil, err
 		}
 
-		return &entity.Comment{
-			ID:        commentDTO.ID,
-			UserID:    commentDTO.UserID,
-			PostID:    commentDTO.PostID,
-			Type:      commentDTO.Type,
-			Content:   commentDTO.Content,
-			FirstLine: commentDTO.FirstLine,
-			LastLine:  commentDTO.LastLine,
-			Code:      commentDTO.Code,
-			CreatedAt: service.ConvertTimeToStr(commentDTO.CreatedAt),
-			UpdatedAt: service.ConvertTimeToStr(commentDTO.UpdatedAt),
-		}, nil
+		comment := &entity.Comment{
+			ID:              commentDTO.ID,
+			UserID:          commentDTO.UserID,
+			PostID:          commentDTO.PostID,
+			ParentID:        int(commentDTO.ParentID.Int64),
+			Type:            commentDTO.Type,
+			Filename:        commentDTO.Filename,
+			Content:         commentDTO.Content,
+			FirstLine:       commentDTO.FirstLine,
+			LastLine:        commentDTO.LastLine,
+			FirstColumn:     commentDTO.FirstColumn,
+			LastColumn:      commentDTO.LastColumn,
+			Code:            commentDTO.Code,
+			Revision:        int(commentDTO.Revision.Int64),
+			BaseRevision:    int(commentDTO.BaseRevision.Int64),
+			Resolved:        commentDTO.ResolvedAt.Valid,
+			AppliedRevision: int(commentDTO.AppliedRevision.Int64),
+			AuthorID:        commentDTO.AuthorID.String,
+			ResolvedBy:      commentDTO.ResolvedBy.String,
+			ResolvedAt:      convertNullTimeToStr(commentDTO.ResolvedAt),
+			Deleted:         commentDTO.Deleted,
+			CreatedAt:       service.ConvertTimeToStr(commentDTO.CreatedAt),
+			UpdatedAt:       service.ConvertTimeToStr(commentDTO.UpdatedAt),
+		}
+		if err := loadCommentMentions(r.dbMap, []*entity.Comment{comment}); err != nil {
+			return nil, fmt.Errorf("failed CommentRepository.FindByID: %w", err)
+		}
+		if err := loadCommentReactionCounts(r.dbMap, []*entity.Comment{comment}); err != nil {
+			return nil, fmt.Errorf("failed CommentRepository.FindByID: %w", err)
+		}
+		return comment, nil
 	}
 }
 
@@ -71,56 +90,94 @@ func (r *CommentRepository) FindByPostID(ctx context.Context, postID int) (comme
 		}
 		for _, commentDTO := range commentDTOs {
 			comment := &entity.Comment{
-				ID:        commentDTO.ID,
-				UserID:    commentDTO.UserID,
-				PostID:    commentDTO.PostID,
-				Type:      commentDTO.Type,
-				Content:   commentDTO.Content,
-				FirstLine: commentDTO.FirstLine,
-				LastLine:  commentDTO.LastLine,
-				Code:      commentDTO.Code,
-				CreatedAt: service.ConvertTimeToStr(commentDTO.CreatedAt),
-				UpdatedAt: service.ConvertTimeToStr(commentDTO.UpdatedAt),
+				ID:              commentDTO.ID,
+				UserID:          commentDTO.UserID,
+				PostID:          commentDTO.PostID,
+				ParentID:        int(commentDTO.ParentID.Int64),
+				Type:            commentDTO.Type,
+				Filename:        commentDTO.Filename,
+				Content:         commentDTO.Content,
+				FirstLine:       commentDTO.FirstLine,
+				LastLine:        commentDTO.LastLine,
+				FirstColumn:     commentDTO.FirstColumn,
+				LastColumn:      commentDTO.LastColumn,
+				Code:            commentDTO.Code,
+				Revision:        int(commentDTO.Revision.Int64),
+				BaseRevision:    int(commentDTO.BaseRevision.Int64),
+				Resolved:        commentDTO.ResolvedAt.Valid,
+				AppliedRevision: int(commentDTO.AppliedRevision.Int64),
+				AuthorID:        commentDTO.AuthorID.String,
+				ResolvedBy:      commentDTO.ResolvedBy.String,
+				ResolvedAt:      convertNullTimeToStr(commentDTO.ResolvedAt),
+				Deleted:         commentDTO.Deleted,
+				CreatedAt:       service.ConvertTimeToStr(commentDTO.CreatedAt),
+				UpdatedAt:       service.ConvertTimeToStr(commentDTO.UpdatedAt),
 			}
 			comments = append(comments, comment)
 		}
 		if comments == nil {
 			return nil, entity.NewErrorNotFound("comment")
 		}
+		if err = loadCommentMentions(r.dbMap, comments); err != nil {
+			return nil, fmt.Errorf("failed CommentRepository.FindByPostID: %w", err)
+		}
+		if err = loadCommentReactionCounts(r.dbMap, comments); err != nil {
+			return nil, fmt.Errorf("failed CommentRepository.FindByPostID: %w", err)
+		}
 		return
 	}
 }
 
-// FindByUserID は該当IDのユーザのコメントをDBから取得して返す
+// FindByUserID は該当IDのユーザが公開された投稿にしたコメントをDBから取得して返す
 func (r *CommentRepository) FindByUserID(ctx context.Context, uid string) ([]*entity.Comment, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
 		var commentDTOs []CommentDTO
-		if _, err := r.dbMap.Select(&commentDTOs, "SELECT * FROM comments WHERE user_id = ?", uid); err != nil {
+		// 一覧に含めない投稿へのコメントは除く
+		if _, err := r.dbMap.Select(
+			&commentDTOs,
+			"SELECT c.* FROM comments AS c JOIN posts AS p ON p.id = c.post_id WHERE c.user_id = ? AND p.visibility = ? AND p.draft = FALSE",
+			uid, entity.PostVisibilityPublic,
+		); err != nil {
 			return nil, err
 		}
 
 		var comments []*entity.Comment
 		for _, commentDTO := range commentDTOs {
 			comment := &entity.Comment{
-				ID:        commentDTO.ID,
-				UserID:    commentDTO.UserID,
-				PostID:    commentDTO.PostID,
-				Type:      commentDTO.Type,
-				Content:   commentDTO.Content,
-				FirstLine: commentDTO.FirstLine,
-				LastLine:  commentDTO.LastLine,
-				Code:      commentDTO.Code,
-				CreatedAt: service.ConvertTimeToStr(commentDTO.CreatedAt),
-				UpdatedAt: service.ConvertTimeToStr(commentDTO.UpdatedAt),
+				ID:              commentDTO.ID,
+				UserID:          commentDTO.UserID,
+				PostID:          commentDTO.PostID,
+				ParentID:        int(commentDTO.ParentID.Int64),
+				Type:            commentDTO.Type,
+				Filename:        commentDTO.Filename,
+				Content:         commentDTO.Content,
+				FirstLine:       commentDTO.FirstLine,
+				LastLine:        commentDTO.LastLine,
+				FirstColumn:     commentDTO.FirstColumn,
+				LastColumn:      commentDTO.LastColumn,
+				Code:            commentDTO.Code,
+				Revision:        int(commentDTO.Revision.Int64),
+				BaseRevision:    int(commentDTO.BaseRevision.Int64),
+				Resolved:        commentDTO.ResolvedAt.Valid,
+				AppliedRevision: int(commentDTO.AppliedRevision.Int64),
+				AuthorID:        commentDTO.AuthorID.String,
+				ResolvedBy:      commentDTO.ResolvedBy.String,
+				ResolvedAt:      convertNullTimeToStr(commentDTO.ResolvedAt),
+				Deleted:         commentDTO.Deleted,
+				CreatedAt:       service.ConvertTimeToStr(commentDTO.CreatedAt),
+				UpdatedAt:       service.ConvertTimeToStr(commentDTO.UpdatedAt),
 			}
 			comments = append(comments, comment)
 		}
 		if comments == nil {
 			return nil, entity.NewErrorNotFound("comment")
 		}
+		if err := loadCommentMentions(r.dbMap, comments); err != nil {
+			return nil, fmt.Errorf("failed CommentRepository.FindByUserID: %w", err)
+		}
 		return comments, nil
 	}
 }
@@ -135,37 +192,48 @@ func (r *CommentRepository) Insert(ctx context.Context, comment *entity.Comment)
 			return fmt.Errorf("invalid Comment fields: %w", err)
 		}
 
-		commentDTO := &CommentInsertDTO{
-			ID:        0, // auto incrementされるのでこれで良い
-			UserID:    comment.UserID,
-			PostID:    comment.PostID,
-			Type:      comment.Type,
-			Content:   comment.Content,
-			FirstLine: comment.FirstLine,
-			LastLine:  comment.LastLine,
-			Code:      comment.Code,
-		}
-
-		if err := r.dbMap.Insert(commentDTO); err != nil {
-			if sqlerr, ok := err.(*mysql.MySQLError); ok {
-				// 存在しないPostIDで登録した時のエラー
-				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "post_id") {
-					return entity.NewErrorNotFound("post")
-				}
-				// 存在しないUserIDで登録した時のエラー
-				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "user_id") {
-					return entity.NewErrorNotFound("user")
-				}
+		// ファイルを指さない種類のコメントはファイル名を持たない
+		if !comment.ReferencesFile() {
+			comment.Filename = ""
+		}
+
+		tx, err := r.dbMap.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		// commitコメントには投稿ごとに連番のリビジョン番号を割り当てる
+		if comment.Type == "commit" {
+			revision, err := nextRevision(tx, comment.PostID)
+			if err != nil {
+				_ = tx.Rollback()
+				return err
 			}
+			comment.Revision = revision
+		}
+		// highlight, suggestionコメントは行範囲がどのリビジョンのコードを指しているかを記録する
+		if comment.HasLineRange() {
+			base, err := baseRevision(tx, comment.PostID, comment.BaseRevision)
+			if err != nil {
+				_ = tx.Rollback()
+				return err
+			}
+			comment.BaseRevision = base
+		}
+		if err := insertComment(tx, comment); err != nil {
+			_ = tx.Rollback()
 			return err
 		}
-		comment.ID = commentDTO.ID
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
 		return nil
 	}
 }
 
 // Update は引数で渡したエンティティのコメントでDBに保存されている情報を更新します
 // コメントした人以外が更新する場合、更新は行われません
+// commitのリビジョンは後のコメントが指す履歴なので，commitの種類，ファイル，コードを変えたり，他の種類からcommitにしたりするとErrCommitImmutableを返します
+// 適用済みのsuggestionも作られたcommitの元になった提案なので，種類，内容，行範囲，ファイル，コードを変えるとErrAppliedSuggestionImmutableを返します
 func (r *CommentRepository) Update(ctx context.Context, comment *entity.Comment) error {
 	select {
 	case <-ctx.Done():
@@ -186,26 +254,83 @@ func (r *CommentRepository) Update(ctx context.Context, comment *entity.Comment)
 		if gotComment.UserID != comment.UserID {
 			return entity.ErrIsNotAuthor
 		}
+		// 墓標になったコメントは更新できない
+		if gotComment.Deleted {
+			return entity.NewErrorNotFound("comment")
+		}
 
+		// 返信先は作成時から変更できないので，DBに保存されている値を引き継ぐ
+		comment.ParentID = gotComment.ParentID
+		if gotComment.Type == "commit" || comment.Type == "commit" {
+			if gotComment.Type != comment.Type || gotComment.Filename != comment.Filename || gotComment.Code != comment.Code {
+				return entity.ErrCommitImmutable
+			}
+		}
+		if gotComment.AppliedRevision != 0 {
+			if gotComment.Type != comment.Type || gotComment.Content != comment.Content ||
+				gotComment.FirstLine != comment.FirstLine || gotComment.LastLine != comment.LastLine ||
+				gotComment.Filename != comment.Filename || gotComment.Code != comment.Code {
+				return entity.ErrAppliedSuggestionImmutable
+			}
+		}
+		comment.Revision = gotComment.Revision
+		// 行範囲を持つコメントのままで指すリビジョンが指定されなければ元のリビジョンを引き継ぐ
+		switch {
+		case !comment.HasLineRange():
+			comment.BaseRevision = 0
+		case comment.BaseRevision == 0 && gotComment.HasLineRange() && gotComment.BaseRevision != 0:
+			comment.BaseRevision = gotComment.BaseRevision
+		default:
+			base, err := baseRevision(r.dbMap, comment.PostID, comment.BaseRevision)
+			if err != nil {
+				return err
+			}
+			comment.BaseRevision = base
+		}
+		// ファイルを指さない種類のコメントに変わった場合はファイル名を外す
+		if !comment.ReferencesFile() {
+			comment.Filename = ""
+		}
 		commentDTO := &CommentInsertDTO{
-			ID:        comment.ID,
-			UserID:    comment.UserID,
-			PostID:    comment.PostID,
-			Type:      comment.Type,
-			Content:   comment.Content,
-			FirstLine: comment.FirstLine,
-			LastLine:  comment.LastLine,
-			Code:      comment.Code,
+			ID:           comment.ID,
+			UserID:       comment.UserID,
+			PostID:       comment.PostID,
+			ParentID:     newNullID(comment.ParentID),
+			Type:         comment.Type,
+			Filename:     comment.Filename,
+			Content:      comment.Content,
+			FirstLine:    comment.FirstLine,
+			LastLine:     comment.LastLine,
+			FirstColumn:  comment.FirstColumn,
+			LastColumn:   comment.LastColumn,
+			Code:         comment.Code,
+			Revision:     newNullID(comment.Revision),
+			BaseRevision: newNullID(comment.BaseRevision),
 		}
 
-		if _, err := r.dbMap.Update(commentDTO); err != nil {
+		tx, err := r.dbMap.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		if _, err := tx.Update(commentDTO); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := saveCommentMentions(tx, comment.PostID, comment.ID, comment.Mentions); err != nil {
+			_ = tx.Rollback()
 			return err
 		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
 	}
 	return nil
 }
 
 // Delete は該当コメントをDBから削除する
+// 返信がついているコメントは削除せずに，内容を消した墓標として残す
+// commitコメントはリビジョンを後のコメントが指しているので，返信がなくてもコードとリビジョン番号を残した墓標にする
+// 削除によって返信がなくなった墓標は，返信を辿る必要がなくなるので合わせて削除する
 func (r *CommentRepository) Delete(ctx context.Context, comment *entity.Comment) error {
 	select {
 	case <-ctx.Done():
@@ -213,7 +338,7 @@ func (r *CommentRepository) Delete(ctx context.Context, comment *entity.Comment)
 	default:
 		// 該当するコメントが存在するか確認
 		gotComment, err := r.FindByID(ctx, comment.PostID, comment.ID)
-		if err != nil {
+		if err != nil || gotComment.Deleted {
 			return entity.NewErrorNotFound("comment")
 		}
 
@@ -222,54 +347,348 @@ func (r *CommentRepository) Delete(ctx context.Context, comment *entity.Comment)
 			return entity.ErrIsNotAuthor
 		}
 
-		commentDTO := &CommentInsertDTO{
-			ID:     comment.ID,
-			PostID: comment.PostID,
-		}
-
-		if _, err := r.dbMap.Delete(commentDTO); err != nil {
-			if sqlerr, ok := err.(*mysql.MySQLError); ok {
-				// 存在しないPostIDで登録した時のエラー
-				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "post_id") {
-					return entity.NewErrorNotFound("post")
-				}
-				// 存在しないUserIDで登録した時のエラー
-				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "user_id") {
-					return entity.NewErrorNotFound("user")
-				}
-			}
+		tx, err := r.dbMap.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		if err := deleteComment(tx, gotComment); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+	}
+	return nil
+}
+
+// deleteComment はコメントを削除するか墓標にします
+func deleteComment(exec gorp.SqlExecutor, comment *entity.Comment) error {
+	// 回答として採用されていれば，内容が残らないので採用も取り消す
+	if _, err := exec.Exec(
+		"UPDATE posts SET accepted_comment_id = NULL, accepted_post_id = NULL, updated_at = updated_at WHERE id = ? AND accepted_comment_id = ?",
+		comment.PostID, comment.ID,
+	); err != nil {
+		return fmt.Errorf("failed to clear accepted comment: %w", err)
+	}
+
+	replies, err := countReplies(exec, comment.PostID, comment.ID)
+	if err != nil {
+		return err
+	}
+	if replies > 0 || comment.Type == "commit" {
+		return leaveTombstone(exec, comment)
+	}
+
+	commentDTO := &CommentInsertDTO{
+		ID:     comment.ID,
+		PostID: comment.PostID,
+	}
+	if _, err := exec.Delete(commentDTO); err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+	return pruneTombstones(exec, comment.PostID, comment.ParentID)
+}
+
+// leaveTombstone はコメントの内容を消して墓標にします
+// commitコメントはリビジョンを残すためにコードとリビジョン番号を消しません
+func leaveTombstone(exec gorp.SqlExecutor, comment *entity.Comment) error {
+	query := "UPDATE comments SET content = '', code = '', base_revision = NULL, deleted = TRUE WHERE post_id = ? AND id = ?"
+	if comment.Type == "commit" {
+		query = "UPDATE comments SET content = '', deleted = TRUE WHERE post_id = ? AND id = ?"
+	}
+	if _, err := exec.Exec(query, comment.PostID, comment.ID); err != nil {
+		return fmt.Errorf("failed to leave tombstone: %w", err)
+	}
+	// 内容を消したのでメンションとリアクションも残さない
+	if err := saveCommentMentions(exec, comment.PostID, comment.ID, nil); err != nil {
+		return err
+	}
+	if _, err := exec.Exec(
+		"DELETE FROM reactions WHERE post_id = ? AND comment_id = ?",
+		comment.PostID, comment.ID,
+	); err != nil {
+		return fmt.Errorf("failed to delete reactions: %w", err)
+	}
+	return nil
+}
+
+// pruneTombstones はcommentIDのコメントから返信先を遡り，返信がなくなった墓標を削除します
+// commitコメントの墓標はリビジョンを残すために削除しません
+func pruneTombstones(exec gorp.SqlExecutor, postID, commentID int) error {
+	for commentID != 0 {
+		var commentDTO CommentDTO
+		if err := exec.SelectOne(&commentDTO, "SELECT * FROM comments WHERE post_id = ? AND id = ?", postID, commentID); err != nil {
+			return fmt.Errorf("failed to get parent comment: %w", err)
+		}
+		if !commentDTO.Deleted || commentDTO.Type == "commit" {
+			return nil
+		}
+		replies, err := countReplies(exec, postID, commentID)
+		if err != nil {
 			return err
 		}
+		if replies > 0 {
+			return nil
+		}
+		if _, err := exec.Exec("DELETE FROM comments WHERE post_id = ? AND id = ?", postID, commentID); err != nil {
+			return fmt.Errorf("failed to delete tombstone: %w", err)
+		}
+		commentID = int(commentDTO.ParentID.Int64)
 	}
 	return nil
 }
 
+// countReplies はコメントについている返信の数を返します
+func countReplies(exec gorp.SqlExecutor, postID, commentID int) (int64, error) {
+	replies, err := exec.SelectInt(
+		"SELECT COUNT(*) FROM comments WHERE post_id = ? AND parent_id = ?",
+		postID, commentID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count replies: %w", err)
+	}
+	return replies, nil
+}
+
+// Resolve はスレッドの最初のコメントを解決済みにします
+// 既に解決済みの場合は解決したユーザと日時を変えません
+func (r *CommentRepository) Resolve(ctx context.Context, postID, commentID int, resolvedBy string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		// 解決済みにしてもコメントの内容は変わらないので，updated_atは更新しない
+		if _, err := r.dbMap.Exec(
+			"UPDATE comments SET resolved_by = ?, resolved_at = CURRENT_TIMESTAMP, updated_at = updated_at WHERE post_id = ? AND id = ? AND resolved_at IS NULL",
+			resolvedBy, postID, commentID,
+		); err != nil {
+			return fmt.Errorf("failed CommentRepository.Resolve: %w", err)
+		}
+		return nil
+	}
+}
+
+// Reopen は解決済みのスレッドの最初のコメントを未解決に戻します
+func (r *CommentRepository) Reopen(ctx context.Context, postID, commentID int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if _, err := r.dbMap.Exec(
+			"UPDATE comments SET resolved_by = NULL, resolved_at = NULL, updated_at = updated_at WHERE post_id = ? AND id = ?",
+			postID, commentID,
+		); err != nil {
+			return fmt.Errorf("failed CommentRepository.Reopen: %w", err)
+		}
+		return nil
+	}
+}
+
+// ApplySuggestion はsuggestionコメントを適用したcommitコメントを保存し，suggestionコメントに適用したリビジョン番号を記録します
+// commitコメントには変更を提案したユーザとしてAuthorIDを記録し，既に適用されたsuggestionならErrSuggestionAppliedを返します
+func (r *CommentRepository) ApplySuggestion(ctx context.Context, suggestion, commit *entity.Comment) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if err := commit.IsValid(); err != nil {
+			return fmt.Errorf("invalid Comment fields: %w", err)
+		}
+		tx, err := r.dbMap.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		revision, err := nextRevision(tx, commit.PostID)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		commit.Revision = revision
+		if err := insertComment(tx, commit); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if _, err := tx.Exec(
+			"UPDATE comments SET author_id = ? WHERE post_id = ? AND id = ?",
+			sql.NullString{String: commit.AuthorID, Valid: len(commit.AuthorID) > 0}, commit.PostID, commit.ID,
+		); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to record author: %w", err)
+		}
+		result, err := tx.Exec(
+			"UPDATE comments SET applied_revision = ?, updated_at = updated_at WHERE post_id = ? AND id = ? AND type = 'suggestion' AND applied_revision IS NULL",
+			revision, suggestion.PostID, suggestion.ID,
+		)
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to record applied revision: %w", err)
+		}
+		// 同時に適用された場合は後から適用した方を取り消す
+		if affected, err := result.RowsAffected(); err != nil || affected == 0 {
+			_ = tx.Rollback()
+			return entity.ErrSuggestionApplied
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		suggestion.AppliedRevision = revision
+		return nil
+	}
+}
+
+// insertComment はコメントをメンションとともに保存し，割り当てられたIDをcommentにセットします
+func insertComment(exec gorp.SqlExecutor, comment *entity.Comment) error {
+	commentDTO := &CommentInsertDTO{
+		ID:           0, // auto incrementされるのでこれで良い
+		UserID:       comment.UserID,
+		PostID:       comment.PostID,
+		ParentID:     newNullID(comment.ParentID),
+		Type:         comment.Type,
+		Filename:     comment.Filename,
+		Content:      comment.Content,
+		FirstLine:    comment.FirstLine,
+		LastLine:     comment.LastLine,
+		FirstColumn:  comment.FirstColumn,
+		LastColumn:   comment.LastColumn,
+		Code:         comment.Code,
+		Revision:     newNullID(comment.Revision),
+		BaseRevision: newNullID(comment.BaseRevision),
+	}
+
+	if err := exec.Insert(commentDTO); err != nil {
+		if sqlerr, ok := err.(*mysql.MySQLError); ok {
+			// 存在しないPostIDで登録した時のエラー
+			if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "post_id") {
+				return entity.NewErrorNotFound("post")
+			}
+			// 存在しないUserIDで登録した時のエラー
+			if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "user_id") {
+				return entity.NewErrorNotFound("user")
+			}
+			// 同時にcommitされてリビジョン番号が重複した時のエラー
+			if sqlerr.Number == mysqlerr.ER_DUP_ENTRY && strings.Contains(sqlerr.Message, "comments_revision") {
+				return entity.NewErrorDuplicated("comment Revision")
+			}
+			// 存在しないコメントに返信した時のエラー
+			if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "parent_id") {
+				return entity.NewErrorNotFound("parent comment")
+			}
+		}
+		return err
+	}
+	if err := saveCommentMentions(exec, comment.PostID, commentDTO.ID, comment.Mentions); err != nil {
+		return err
+	}
+	comment.ID = commentDTO.ID
+	return nil
+}
+
+// nextRevision は投稿に次に割り当てるリビジョン番号を返します
+// 同時にcommitされても同じ番号を割り当てないように，トランザクションの中で投稿のコメントをロックして読みます
+func nextRevision(exec gorp.SqlExecutor, postID int) (int, error) {
+	latest, err := exec.SelectInt(
+		"SELECT COALESCE(MAX(revision), ?) FROM comments WHERE post_id = ? FOR UPDATE",
+		entity.OriginalRevision, postID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest revision: %w", err)
+	}
+	return int(latest) + 1, nil
+}
+
+// latestRevision は投稿の最新のリビジョン番号を返します
+func latestRevision(exec gorp.SqlExecutor, postID int) (int, error) {
+	latest, err := exec.SelectInt(
+		"SELECT COALESCE(MAX(revision), ?) FROM comments WHERE post_id = ?",
+		entity.OriginalRevision, postID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest revision: %w", err)
+	}
+	return int(latest), nil
+}
+
+// baseRevision はhighlightコメントが指すリビジョン番号を決めます
+// 指定されていなければ最新のリビジョン番号を返し，指定されたリビジョンが存在しなければErrNotFoundを返します
+func baseRevision(exec gorp.SqlExecutor, postID, revision int) (int, error) {
+	if revision == 0 {
+		return latestRevision(exec, postID)
+	}
+	if revision == entity.OriginalRevision {
+		return revision, nil
+	}
+	count, err := exec.SelectInt(
+		"SELECT COUNT(*) FROM comments WHERE post_id = ? AND revision = ?",
+		postID, revision,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find revision: %w", err)
+	}
+	if count == 0 {
+		return 0, entity.NewErrorNotFound("revision")
+	}
+	return revision, nil
+}
+
+// newNullID は0を未設定として扱うIDをNULL許容のカラムに保存できる形に変換します
+func newNullID(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
+
+// convertNullTimeToStr はNULL許容の日時を文字列に変換します．NULLなら空文字列を返します
+func convertNullTimeToStr(t sql.NullTime) string {
+	if !t.Valid {
+		return ""
+	}
+	return service.ConvertTimeToStr(t.Time)
+}
+
 // CommentDTO はDBとやり取りするためのDataTransferObject
+// ref: migrations/20210319143039-CreateComments.sql
 type CommentDTO struct {
-	ID        int       `db:"id"`
-	UserID    string    `db:"user_id"`
-	PostID    int       `db:"post_id"`
-	Type      string    `db:"type"`
-	Content   string    `db:"content"`
-	FirstLine int       `db:"first_line"`
-	LastLine  int       `db:"last_line"`
-	Code      string    `db:"code"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID              int            `db:"id"`
+	UserID          string         `db:"user_id"`
+	PostID          int            `db:"post_id"`
+	ParentID        sql.NullInt64  `db:"parent_id"`
+	Type            string         `db:"type"`
+	Filename        string         `db:"filename"`
+	Content         string         `db:"content"`
+	FirstLine       int            `db:"first_line"`
+	LastLine        int            `db:"last_line"`
+	FirstColumn     int            `db:"first_column"`
+	LastColumn      int            `db:"last_column"`
+	Code            string         `db:"code"`
+	Revision        sql.NullInt64  `db:"revision"`
+	BaseRevision    sql.NullInt64  `db:"base_revision"`
+	AppliedRevision sql.NullInt64  `db:"applied_revision"`
+	AuthorID        sql.NullString `db:"author_id"`
+	ResolvedBy      sql.NullString `db:"resolved_by"`
+	ResolvedAt      sql.NullTime   `db:"resolved_at"`
+	Deleted         bool           `db:"deleted"`
+	CreatedAt       time.Time      `db:"created_at"`
+	UpdatedAt       time.Time      `db:"updated_at"`
 }
 
 // CommentInsertDTO はInsert用のDataTransferObject
 // timestamp系は参照しないようにしています
 // ref: https://github.com/go-gorp/gorp/issues/125
 type CommentInsertDTO struct {
-	ID        int       `db:"id"`
-	UserID    string    `db:"user_id"`
-	PostID    int       `db:"post_id"`
-	Type      string    `db:"type"`
-	Content   string    `db:"content"`
-	FirstLine int       `db:"first_line"`
-	LastLine  int       `db:"last_line"`
-	Code      string    `db:"code"`
-	CreatedAt time.Time `db:"-"`
-	UpdatedAt time.Time `db:"-"`
+	ID           int           `db:"id"`
+	UserID       string        `db:"user_id"`
+	PostID       int           `db:"post_id"`
+	ParentID     sql.NullInt64 `db:"parent_id"`
+	Type         string        `db:"type"`
+	Filename     string        `db:"filename"`
+	Content      string        `db:"content"`
+	FirstLine    int           `db:"first_line"`
+	LastLine     int           `db:"last_line"`
+	FirstColumn  int           `db:"first_column"`
+	LastColumn   int           `db:"last_column"`
+	Code         string        `db:"code"`
+	Revision     sql.NullInt64 `db:"revision"`
+	BaseRevision sql.NullInt64 `db:"base_revision"`
+	Deleted      bool          `db:"deleted"`
+	CreatedAt    time.Time     `db:"-"`
+	UpdatedAt    time.Time     `db:"-"`
 }
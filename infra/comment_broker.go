@@ -0,0 +1,78 @@
+package infra
+
+import (
+	"context"
+	"sync"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+// commentEventBufferSize は購読者ごとに溜めておけるイベントの数です
+// 受信が追いつかない購読者へのイベントは溜まっている分を超えると捨てられます
+const commentEventBufferSize = 16
+
+var _ repository.CommentBroker = (*InProcessCommentBroker)(nil)
+
+// InProcessCommentBroker はプロセス内でコメントの変更イベントを配信するブローカーです
+// 同じプロセスで購読しているクライアントにしか届かないので，単一インスタンスでの運用を前提とします
+type InProcessCommentBroker struct {
+	mu          sync.RWMutex
+	subscribers map[int]map[chan *entity.CommentEvent]struct{}
+}
+
+// NewInProcessCommentBroker はプロセス内で配信するブローカーのポインタを生成する関数です
+func NewInProcessCommentBroker() *InProcessCommentBroker {
+	return &InProcessCommentBroker{
+		subscribers: make(map[int]map[chan *entity.CommentEvent]struct{}),
+	}
+}
+
+// Publish はイベントをその投稿の購読者全員に配信します
+// 受信が追いついていない購読者を待たないように，バッファが一杯の購読者にはイベントを捨てます
+func (b *InProcessCommentBroker) Publish(ctx context.Context, event *entity.CommentEvent) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		b.mu.RLock()
+		defer b.mu.RUnlock()
+		for ch := range b.subscribers[event.PostID] {
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+		return nil
+	}
+}
+
+// Subscribe は投稿のイベントを受け取るチャネルを返します
+// ctxが終了すると購読が解除され，チャネルが閉じられます
+func (b *InProcessCommentBroker) Subscribe(ctx context.Context, postID int) (<-chan *entity.CommentEvent, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		ch := make(chan *entity.CommentEvent, commentEventBufferSize)
+
+		b.mu.Lock()
+		if b.subscribers[postID] == nil {
+			b.subscribers[postID] = make(map[chan *entity.CommentEvent]struct{})
+		}
+		b.subscribers[postID][ch] = struct{}{}
+		b.mu.Unlock()
+
+		go func() {
+			<-ctx.Done()
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subscribers[postID], ch)
+			if len(b.subscribers[postID]) == 0 {
+				delete(b.subscribers, postID)
+			}
+			close(ch)
+		}()
+		return ch, nil
+	}
+}
@@ -0,0 +1,56 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestInProcessCommentBroker(t *testing.T) {
+	broker := NewInProcessCommentBroker()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := broker.Subscribe(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherCtx, otherCancel := context.WithCancel(context.Background())
+	defer otherCancel()
+	otherEvents, err := broker.Subscribe(otherCtx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event := entity.NewCommentEvent(entity.CommentEventCreated, &entity.Comment{ID: 1, PostID: 1, Type: "none", Content: "hello"})
+	if err := broker.Publish(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case got := <-events:
+		if diff := cmp.Diff(event, got); diff != "" {
+			t.Errorf("event (-want +got) =\n%s\n", diff)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("購読している投稿のイベントが届かない")
+	}
+	select {
+	case got := <-otherEvents:
+		t.Errorf("別の投稿のイベントが届いた: %+v", got)
+	default:
+	}
+
+	// 購読を解除するとチャネルが閉じられる
+	cancel()
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Error("購読を解除したチャネルにイベントが届いた")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("購読を解除してもチャネルが閉じられない")
+	}
+}
@@ -33,15 +33,17 @@ func TestCommentRepository_FindByID(t *testing.T) {
 	truncateTable(t, dbMap, "posts")
 
 	if err := dbMap.Insert(&PostDTO{
-		ID:        1,
-		UserID:    "user-id",
-		Title:     "test title",
-		Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-		Language:  "Go",
-		Content:   "Test code",
-		Source:    "github.com",
-		CreatedAt: time.Unix(100, 0),
-		UpdatedAt: time.Unix(100, 0),
+		ID:         1,
+		UserID:     "user-id",
+		Title:      "test title",
+		Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+		Language:   "Go",
+		Content:    "Test code",
+		Source:     "github.com",
+		Status:     entity.PostStatusOpen,
+		Visibility: entity.PostVisibilityPublic,
+		CreatedAt:  time.Unix(100, 0),
+		UpdatedAt:  time.Unix(100, 0),
 	}); err != nil {
 		t.Fatal(err)
 	}
@@ -141,26 +143,30 @@ func TestCommentRepository_FindByPostID(t *testing.T) {
 
 	postDTOs := []*PostDTO{
 		{
-			ID:        1,
-			UserID:    "user-id",
-			Title:     "test title",
-			Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language:  "Go",
-			Content:   "Test code",
-			Source:    "github.com",
-			CreatedAt: time.Unix(100, 0),
-			UpdatedAt: time.Unix(100, 0),
+			ID:         1,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Status:     entity.PostStatusOpen,
+			Visibility: entity.PostVisibilityPublic,
+			CreatedAt:  time.Unix(100, 0),
+			UpdatedAt:  time.Unix(100, 0),
 		},
 		{
-			ID:        2,
-			UserID:    "user-id",
-			Title:     "test title",
-			Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language:  "Go",
-			Content:   "Test code",
-			Source:    "github.com",
-			CreatedAt: time.Unix(100, 0),
-			UpdatedAt: time.Unix(100, 0),
+			ID:         2,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Status:     entity.PostStatusOpen,
+			Visibility: entity.PostVisibilityPublic,
+			CreatedAt:  time.Unix(100, 0),
+			UpdatedAt:  time.Unix(100, 0),
 		},
 	}
 	for _, postDTO := range postDTOs {
@@ -304,22 +310,24 @@ func TestUserRepository_GetByUserID(t *testing.T) {
 
 	postDTOs := []*PostInsertDTO{
 		{
-			ID:       1,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         1,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 		{
-			ID:       2,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         2,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 	}
 	for _, postDTO := range postDTOs {
@@ -446,15 +454,17 @@ func TestCommentRepository_Insert(t *testing.T) {
 	truncateTable(t, dbMap, "posts")
 
 	if err := dbMap.Insert(&PostDTO{
-		ID:        1,
-		UserID:    "user-id",
-		Title:     "test title",
-		Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-		Language:  "Go",
-		Content:   "Test code",
-		Source:    "github.com",
-		CreatedAt: time.Unix(100, 0),
-		UpdatedAt: time.Unix(100, 0),
+		ID:         1,
+		UserID:     "user-id",
+		Title:      "test title",
+		Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+		Language:   "Go",
+		Content:    "Test code",
+		Source:     "github.com",
+		Status:     entity.PostStatusOpen,
+		Visibility: entity.PostVisibilityPublic,
+		CreatedAt:  time.Unix(100, 0),
+		UpdatedAt:  time.Unix(100, 0),
 	}); err != nil {
 		t.Fatal(err)
 	}
@@ -582,31 +592,34 @@ func TestCommentRepository_Update(t *testing.T) {
 
 	validPosts := []*PostInsertDTO{
 		{
-			ID:       1,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         1,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 		{
-			ID:       2,
-			UserID:   "user-id2",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         2,
+			UserID:     "user-id2",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 		{
-			ID:       3,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         3,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 	}
 	// デフォルトの投稿追加
@@ -629,6 +642,31 @@ func TestCommentRepository_Update(t *testing.T) {
 	}); err != nil {
 		t.Fatal(err)
 	}
+	if err := dbMap.Insert(&CommentInsertDTO{
+		ID:       2,
+		UserID:   "user-id",
+		PostID:   1,
+		Type:     "commit",
+		Code:     "package main",
+		Revision: newNullID(2),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dbMap.Insert(&CommentInsertDTO{
+		ID:        3,
+		UserID:    "user-id",
+		PostID:    1,
+		Type:      "suggestion",
+		Content:   "suggestion",
+		FirstLine: 1,
+		LastLine:  1,
+		Code:      "package foo",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dbMap.Exec("UPDATE comments SET applied_revision = 2 WHERE id = 3"); err != nil {
+		t.Fatal(err)
+	}
 
 	commentRepo := NewCommentRepository(dbMap)
 
@@ -681,6 +719,76 @@ func TestCommentRepository_Update(t *testing.T) {
 			},
 			wantErr: entity.NewErrorNotFound("commnent"),
 		},
+		{
+			name: "commitのコメントの内容は更新できる",
+			comment: &entity.Comment{
+				ID:      2,
+				UserID:  "user-id",
+				PostID:  1,
+				Type:    "commit",
+				Content: "updated content",
+				Code:    "package main",
+			},
+			wantErr: nil,
+		},
+		{
+			name: "commitのコードを変えるとErrCommitImmutable",
+			comment: &entity.Comment{
+				ID:     2,
+				UserID: "user-id",
+				PostID: 1,
+				Type:   "commit",
+				Code:   "package main\n\nfunc main() {}",
+			},
+			wantErr: entity.ErrCommitImmutable,
+		},
+		{
+			name: "commitを他の種類に変えるとErrCommitImmutable",
+			comment: &entity.Comment{
+				ID:      2,
+				UserID:  "user-id",
+				PostID:  1,
+				Type:    "none",
+				Content: "type none",
+			},
+			wantErr: entity.ErrCommitImmutable,
+		},
+		{
+			name: "他の種類のコメントをcommitに変えるとErrCommitImmutable",
+			comment: &entity.Comment{
+				ID:     1,
+				UserID: "user-id",
+				PostID: 1,
+				Type:   "commit",
+				Code:   "package main",
+			},
+			wantErr: entity.ErrCommitImmutable,
+		},
+		{
+			name: "適用済みのsuggestionの内容を変えるとErrAppliedSuggestionImmutable",
+			comment: &entity.Comment{
+				ID:        3,
+				UserID:    "user-id",
+				PostID:    1,
+				Type:      "suggestion",
+				Content:   "updated suggestion",
+				FirstLine: 1,
+				LastLine:  1,
+				Code:      "package foo",
+			},
+			wantErr: entity.ErrAppliedSuggestionImmutable,
+		},
+		{
+			name: "適用済みのsuggestionを他の種類に変えるとErrAppliedSuggestionImmutable",
+			comment: &entity.Comment{
+				ID:      3,
+				UserID:  "user-id",
+				PostID:  1,
+				Type:    "none",
+				Content: "suggestion",
+			},
+			wantErr: entity.ErrAppliedSuggestionImmutable,
+		},
 	}
 	for _, tt := range tests {
 		tt := tt
@@ -724,15 +832,17 @@ func TestCommentRepository_Delete(t *testing.T) {
 	truncateTable(t, dbMap, "posts")
 
 	if err := dbMap.Insert(&PostDTO{
-		ID:        1,
-		UserID:    "user-id",
-		Title:     "test title",
-		Code:      "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-		Language:  "Go",
-		Content:   "Test code",
-		Source:    "github.com",
-		CreatedAt: time.Unix(100, 0),
-		UpdatedAt: time.Unix(100, 0),
+		ID:         1,
+		UserID:     "user-id",
+		Title:      "test title",
+		Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+		Language:   "Go",
+		Content:    "Test code",
+		Source:     "github.com",
+		Status:     entity.PostStatusOpen,
+		Visibility: entity.PostVisibilityPublic,
+		CreatedAt:  time.Unix(100, 0),
+		UpdatedAt:  time.Unix(100, 0),
 	}); err != nil {
 		t.Fatal(err)
 	}
@@ -815,3 +925,352 @@ func TestCommentRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestCommentRepository_Delete_Tombstone(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+
+	if err := dbMap.Insert(&UserDTO{
+		ID:        "user-id",
+		Name:      "test user",
+		Profile:   "test profile",
+		TwitterID: "twitter",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	dbMap.AddTableWithName(PostDTO{}, "posts").SetKeys(true, "id")
+	truncateTable(t, dbMap, "posts")
+
+	if err := dbMap.Insert(&PostDTO{
+		ID:         1,
+		UserID:     "user-id",
+		Title:      "test title",
+		Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+		Language:   "Go",
+		Content:    "Test code",
+		Source:     "github.com",
+		Status:     entity.PostStatusOpen,
+		Visibility: entity.PostVisibilityPublic,
+		CreatedAt:  time.Unix(100, 0),
+		UpdatedAt:  time.Unix(100, 0),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	dbMap.AddTableWithName(CommentDTO{}, "comments").SetKeys(true, "id")
+	truncateTable(t, dbMap, "comments")
+
+	commentDTOs := []*CommentDTO{
+		{
+			ID:        1,
+			UserID:    "user-id",
+			PostID:    1,
+			Type:      "none",
+			Content:   "parent",
+			CreatedAt: time.Unix(100, 0),
+			UpdatedAt: time.Unix(100, 0),
+		},
+		{
+			ID:        2,
+			UserID:    "user-id",
+			PostID:    1,
+			ParentID:  newNullID(1),
+			Type:      "none",
+			Content:   "reply",
+			CreatedAt: time.Unix(100, 0),
+			UpdatedAt: time.Unix(100, 0),
+		},
+	}
+	for _, commentDTO := range commentDTOs {
+		if err := dbMap.Insert(commentDTO); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	commentRepo := NewCommentRepository(dbMap)
+	ctx := context.Background()
+
+	if err := commentRepo.Delete(ctx, &entity.Comment{ID: 1, PostID: 1, UserID: "user-id"}); err != nil {
+		t.Fatal(err)
+	}
+
+	gotComments, err := commentRepo.FindByPostID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantComments := []*entity.Comment{
+		{
+			ID:      1,
+			UserID:  "user-id",
+			PostID:  1,
+			Type:    "none",
+			Deleted: true,
+		},
+		{
+			ID:       2,
+			UserID:   "user-id",
+			PostID:   1,
+			ParentID: 1,
+			Type:     "none",
+			Content:  "reply",
+		},
+	}
+	opt := cmpopts.IgnoreFields(entity.Comment{}, "CreatedAt", "UpdatedAt")
+	if diff := cmp.Diff(wantComments, gotComments, opt); diff != "" {
+		t.Errorf("Data (-want +got) =\n%s\n", diff)
+	}
+
+	// 墓標になったコメントは再度削除できない
+	err = commentRepo.Delete(ctx, &entity.Comment{ID: 1, PostID: 1, UserID: "user-id"})
+	if !errors.Is(err, entity.NewErrorNotFound("comment")) {
+		t.Errorf("error = %v, wantErr = %v", err, entity.NewErrorNotFound("comment"))
+	}
+
+	// 最後の返信を削除すると返信のなくなった墓標も削除される
+	if err := commentRepo.Delete(ctx, &entity.Comment{ID: 2, PostID: 1, UserID: "user-id"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := commentRepo.FindByID(ctx, 1, 1); !errors.Is(err, entity.NewErrorNotFound("comment")) {
+		t.Errorf("error = %v, wantErr = %v", err, entity.NewErrorNotFound("comment"))
+	}
+
+	// commitコメントは返信がなくてもコードとリビジョン番号を残した墓標になる
+	commit := &entity.Comment{UserID: "user-id", PostID: 1, Type: "commit", Content: "commit", Code: "package main"}
+	if err := commentRepo.Insert(ctx, commit); err != nil {
+		t.Fatal(err)
+	}
+	if err := commentRepo.Delete(ctx, &entity.Comment{ID: commit.ID, PostID: 1, UserID: "user-id"}); err != nil {
+		t.Fatal(err)
+	}
+	gotCommit, err := commentRepo.FindByID(ctx, 1, commit.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gotCommit.Deleted || gotCommit.Content != "" || gotCommit.Code != "package main" || gotCommit.Revision != 2 {
+		t.Errorf("commit tombstone = %+v, want deleted with code and revision 2", gotCommit)
+	}
+}
+
+func TestCommentRepository_Insert_Revision(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+
+	if err := dbMap.Insert(&UserDTO{
+		ID:        "user-id",
+		Name:      "test user",
+		Profile:   "test profile",
+		TwitterID: "twitter",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	dbMap.AddTableWithName(PostDTO{}, "posts").SetKeys(true, "id")
+	truncateTable(t, dbMap, "posts")
+
+	if err := dbMap.Insert(&PostDTO{
+		ID:         1,
+		UserID:     "user-id",
+		Title:      "test title",
+		Code:       "package main",
+		Language:   "Go",
+		Status:     entity.PostStatusOpen,
+		Visibility: entity.PostVisibilityPublic,
+		CreatedAt:  time.Unix(100, 0),
+		UpdatedAt:  time.Unix(100, 0),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	commentRepo := NewCommentRepository(dbMap)
+	truncateTable(t, dbMap, "comments")
+
+	tests := []struct {
+		name             string
+		comment          *entity.Comment
+		wantRevision     int
+		wantBaseRevision int
+		wantErr          error
+	}{
+		{
+			name:         "最初のcommitはリビジョン2になる",
+			comment:      &entity.Comment{UserID: "user-id", PostID: 1, Type: "commit", Code: "second"},
+			wantRevision: 2,
+		},
+		{
+			name:         "commit以外にはリビジョン番号を割り当てない",
+			comment:      &entity.Comment{UserID: "user-id", PostID: 1, Type: "none", Content: "comment"},
+			wantRevision: 0,
+		},
+		{
+			name:         "次のcommitは連番になる",
+			comment:      &entity.Comment{UserID: "user-id", PostID: 1, Type: "commit", Code: "third"},
+			wantRevision: 3,
+		},
+		{
+			name:             "highlightはリビジョンを指定しなければ最新のリビジョンを指す",
+			comment:          &entity.Comment{UserID: "user-id", PostID: 1, Type: "highlight", FirstLine: 1, LastLine: 1},
+			wantBaseRevision: 3,
+		},
+		{
+			name:             "highlightは指定したリビジョンを指す",
+			comment:          &entity.Comment{UserID: "user-id", PostID: 1, Type: "highlight", FirstLine: 1, LastLine: 1, BaseRevision: 2},
+			wantBaseRevision: 2,
+		},
+		{
+			name:    "存在しないリビジョンを指すhighlightはErrNotFound",
+			comment: &entity.Comment{UserID: "user-id", PostID: 1, Type: "highlight", FirstLine: 1, LastLine: 1, BaseRevision: 4},
+			wantErr: entity.NewErrorNotFound("revision"),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if err := commentRepo.Insert(ctx, tt.comment); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, wantErr = %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			got, err := commentRepo.FindByID(ctx, tt.comment.PostID, tt.comment.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Revision != tt.wantRevision {
+				t.Errorf("revision = %d, want = %d", got.Revision, tt.wantRevision)
+			}
+			if got.BaseRevision != tt.wantBaseRevision {
+				t.Errorf("base revision = %d, want = %d", got.BaseRevision, tt.wantBaseRevision)
+			}
+		})
+	}
+}
+
+func TestCommentRepository_Resolve(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"user1", "user2"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	commentRepo := NewCommentRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	truncateTable(t, dbMap, "comments")
+	if err := postRepo.Insert(ctx, &entity.Post{UserID: "user1", Title: "title", Code: "code", Language: "go"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := commentRepo.Insert(ctx, &entity.Comment{UserID: "user2", PostID: 1, Type: "highlight", FirstLine: 1, LastLine: 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := commentRepo.Resolve(ctx, 1, 1, "user1"); err != nil {
+		t.Fatal(err)
+	}
+	// 解決済みのスレッドを再度解決済みにしても解決したユーザは変わらない
+	if err := commentRepo.Resolve(ctx, 1, 1, "user2"); err != nil {
+		t.Fatal(err)
+	}
+	comment, err := commentRepo.FindByID(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !comment.Resolved || comment.ResolvedBy != "user1" || comment.ResolvedAt == "" {
+		t.Errorf("Resolved = %v, ResolvedBy = %s, ResolvedAt = %s", comment.Resolved, comment.ResolvedBy, comment.ResolvedAt)
+	}
+
+	if err := commentRepo.Reopen(ctx, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	comment, err = commentRepo.FindByID(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if comment.Resolved || comment.ResolvedBy != "" || comment.ResolvedAt != "" {
+		t.Errorf("Resolved = %v, ResolvedBy = %s, ResolvedAt = %s", comment.Resolved, comment.ResolvedBy, comment.ResolvedAt)
+	}
+}
+
+func TestCommentRepository_ApplySuggestion(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"user1", "user2"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	commentRepo := NewCommentRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	truncateTable(t, dbMap, "comments")
+	if err := postRepo.Insert(ctx, &entity.Post{UserID: "user1", Title: "title", Code: "a\nb", Language: "go"}); err != nil {
+		t.Fatal(err)
+	}
+	suggestion := &entity.Comment{UserID: "user2", PostID: 1, Type: "suggestion", FirstLine: 2, LastLine: 2, Code: "B"}
+	if err := commentRepo.Insert(ctx, suggestion); err != nil {
+		t.Fatal(err)
+	}
+
+	commit := &entity.Comment{UserID: "user1", PostID: 1, ParentID: suggestion.ID, Type: "commit", Code: "a\nB", AuthorID: "user2"}
+	if err := commentRepo.ApplySuggestion(ctx, suggestion, commit); err != nil {
+		t.Fatal(err)
+	}
+	// 同じsuggestionは2回適用できない
+	again := &entity.Comment{UserID: "user1", PostID: 1, ParentID: suggestion.ID, Type: "commit", Code: "a\nB", AuthorID: "user2"}
+	if err := commentRepo.ApplySuggestion(ctx, suggestion, again); !errors.Is(err, entity.ErrSuggestionApplied) {
+		t.Errorf("error = %v, wantErr = %v", err, entity.ErrSuggestionApplied)
+	}
+
+	got, err := commentRepo.FindByID(ctx, 1, commit.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Revision != 2 || got.AuthorID != "user2" {
+		t.Errorf("Revision = %d, AuthorID = %s", got.Revision, got.AuthorID)
+	}
+	got, err = commentRepo.FindByID(ctx, 1, suggestion.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AppliedRevision != 2 {
+		t.Errorf("AppliedRevision = %d, want = %d", got.AppliedRevision, 2)
+	}
+
+	// 適用したcommitを削除すればもう一度適用できる
+	if err := commentRepo.Delete(ctx, &entity.Comment{ID: commit.ID, PostID: 1, UserID: "user1"}); err != nil {
+		t.Fatal(err)
+	}
+	got, err = commentRepo.FindByID(ctx, 1, suggestion.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.AppliedRevision != 0 {
+		t.Errorf("AppliedRevision = %d, want = %d", got.AppliedRevision, 0)
+	}
+}
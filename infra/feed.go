@@ -0,0 +1,207 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+var _ repository.Feed = (*FeedRepository)(nil)
+
+// FeedRepository はフォローしているユーザの投稿とcommitコメントからフィードを再構成するためのリポジトリです
+type FeedRepository struct {
+	dbMap *gorp.DbMap
+}
+
+// NewFeedRepository はフィードのリポジトリのポインタを生成する関数です
+func NewFeedRepository(dbMap *gorp.DbMap) *FeedRepository {
+	return &FeedRepository{dbMap: dbMap}
+}
+
+// FindByFollowerID はuidのユーザがフォローしているユーザの新しい投稿とcommitコメントを
+// cursorの位置からcreated_at, type, idの降順でlimit件まで取得します
+// 削除されたcommitコメントは含みません
+func (r *FeedRepository) FindByFollowerID(ctx context.Context, uid string, cursor *entity.FeedCursor, limit int) ([]*entity.FeedItem, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		args := []interface{}{
+			entity.FeedItemPost, uid, entity.PostVisibilityPublic,
+			entity.FeedItemCommit, uid, entity.PostVisibilityPublic,
+		}
+		query := `SELECT type, id, post_id, created_at FROM (
+	SELECT ? AS type, p.id, p.id AS post_id, p.created_at
+	FROM posts AS p
+	JOIN follows AS f ON f.followee_id = p.user_id
+	WHERE f.follower_id = ? AND p.visibility = ? AND p.draft = FALSE
+	UNION ALL
+	SELECT ? AS type, c.id, c.post_id, c.created_at
+	FROM comments AS c
+	JOIN follows AS f ON f.followee_id = c.user_id
+	JOIN posts AS p ON p.id = c.post_id
+	WHERE f.follower_id = ? AND c.type = 'commit' AND c.deleted = FALSE AND p.visibility = ? AND p.draft = FALSE
+) AS feed`
+		if cursor != nil {
+			createdAt, err := service.ConvertStrToTime(cursor.CreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cursor time: %w", entity.ErrInvalidCursor)
+			}
+			query += " WHERE (created_at, type, id) < (?, ?, ?)"
+			args = append(args, createdAt, cursor.Type, cursor.ID)
+		}
+		query += " ORDER BY created_at DESC, type DESC, id DESC LIMIT ?"
+		args = append(args, limit)
+
+		var feedItemDTOs []FeedItemDTO
+		if _, err := r.dbMap.Select(&feedItemDTOs, query, args...); err != nil {
+			return nil, fmt.Errorf("failed FeedRepository.FindByFollowerID: %w", err)
+		}
+
+		var postIDs, commentIDs []int
+		for _, dto := range feedItemDTOs {
+			postIDs = append(postIDs, dto.PostID)
+			if dto.Type == entity.FeedItemCommit {
+				commentIDs = append(commentIDs, dto.ID)
+			}
+		}
+		posts, err := r.selectPostsByID(postIDs)
+		if err != nil {
+			return nil, err
+		}
+		comments, err := r.selectCommentsByID(commentIDs)
+		if err != nil {
+			return nil, err
+		}
+
+		items := make([]*entity.FeedItem, 0, len(feedItemDTOs))
+		for _, dto := range feedItemDTOs {
+			item := &entity.FeedItem{
+				Type:      dto.Type,
+				Post:      posts[dto.PostID],
+				CreatedAt: service.ConvertTimeToStr(dto.CreatedAt),
+			}
+			if dto.Type == entity.FeedItemCommit {
+				item.Comment = comments[dto.ID]
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+}
+
+// selectPostsByID はpostIDsの投稿をタグ，メンション，スターの数とともに取得し，IDをキーとするmapで返します
+func (r *FeedRepository) selectPostsByID(postIDs []int) (map[int]*entity.Post, error) {
+	posts := make(map[int]*entity.Post, len(postIDs))
+	if len(postIDs) == 0 {
+		return posts, nil
+	}
+
+	placeholders, args := inPlaceholders(postIDs)
+	var postDTOs []PostDTO
+	if _, err := r.dbMap.Select(&postDTOs, "SELECT * FROM posts WHERE id IN ("+placeholders+")", args...); err != nil {
+		return nil, fmt.Errorf("failed to select feed posts: %w", err)
+	}
+
+	postList := make([]*entity.Post, 0, len(postDTOs))
+	for _, dto := range postDTOs {
+		post := &entity.Post{
+			ID:                dto.ID,
+			UserID:            dto.UserID,
+			Title:             dto.Title,
+			Code:              dto.Code,
+			Language:          dto.Language,
+			Content:           dto.Content,
+			Source:            dto.Source,
+			Status:            dto.Status,
+			Visibility:        dto.Visibility,
+			Draft:             dto.Draft,
+			ForkedFrom:        int(dto.ForkedFrom.Int64),
+			AcceptedCommentID: int(dto.AcceptedCommentID.Int64),
+			CreatedAt:         service.ConvertTimeToStr(dto.CreatedAt),
+			UpdatedAt:         service.ConvertTimeToStr(dto.UpdatedAt),
+		}
+		posts[post.ID] = post
+		postList = append(postList, post)
+	}
+	if err := loadPostRelations(r.dbMap, postList); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// selectCommentsByID はcommentIDsのコメントをメンションとともに取得し，IDをキーとするmapで返します
+func (r *FeedRepository) selectCommentsByID(commentIDs []int) (map[int]*entity.Comment, error) {
+	comments := make(map[int]*entity.Comment, len(commentIDs))
+	if len(commentIDs) == 0 {
+		return comments, nil
+	}
+
+	placeholders, args := inPlaceholders(commentIDs)
+	var commentDTOs []CommentDTO
+	if _, err := r.dbMap.Select(&commentDTOs, "SELECT * FROM comments WHERE id IN ("+placeholders+")", args...); err != nil {
+		return nil, fmt.Errorf("failed to select feed comments: %w", err)
+	}
+
+	for _, commentDTO := range commentDTOs {
+		comments[commentDTO.ID] = &entity.Comment{
+			ID:              commentDTO.ID,
+			UserID:          commentDTO.UserID,
+			PostID:          commentDTO.PostID,
+			ParentID:        int(commentDTO.ParentID.Int64),
+			Type:            commentDTO.Type,
+			Filename:        commentDTO.Filename,
+			Content:         commentDTO.Content,
+			FirstLine:       commentDTO.FirstLine,
+			LastLine:        commentDTO.LastLine,
+			FirstColumn:     commentDTO.FirstColumn,
+			LastColumn:      commentDTO.LastColumn,
+			Code:            commentDTO.Code,
+			Revision:        int(commentDTO.Revision.Int64),
+			BaseRevision:    int(commentDTO.BaseRevision.Int64),
+			Resolved:        commentDTO.ResolvedAt.Valid,
+			AppliedRevision: int(commentDTO.AppliedRevision.Int64),
+			AuthorID:        commentDTO.AuthorID.String,
+			ResolvedBy:      commentDTO.ResolvedBy.String,
+			ResolvedAt:      convertNullTimeToStr(commentDTO.ResolvedAt),
+			Deleted:         commentDTO.Deleted,
+			CreatedAt:       service.ConvertTimeToStr(commentDTO.CreatedAt),
+			UpdatedAt:       service.ConvertTimeToStr(commentDTO.UpdatedAt),
+		}
+	}
+
+	commentList := make([]*entity.Comment, 0, len(comments))
+	for _, comment := range comments {
+		commentList = append(commentList, comment)
+	}
+	if err := loadCommentMentions(r.dbMap, commentList); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// inPlaceholders はIN句に渡すプレースホルダの文字列と引数を生成します
+func inPlaceholders(ids []int) (string, []interface{}) {
+	placeholders := make([]string, 0, len(ids))
+	args := make([]interface{}, 0, len(ids))
+	for _, id := range ids {
+		placeholders = append(placeholders, "?")
+		args = append(args, id)
+	}
+	return strings.Join(placeholders, ", "), args
+}
+
+// FeedItemDTO はフィードの項目の種類と位置をDBから受け取るためのDataTransferObjectです
+// IDはTypeがpostなら投稿のID，commitならコメントのIDです
+type FeedItemDTO struct {
+	Type      string    `db:"type"`
+	ID        int       `db:"id"`
+	PostID    int       `db:"post_id"`
+	CreatedAt time.Time `db:"created_at"`
+}
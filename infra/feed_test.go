@@ -0,0 +1,118 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestFeedRepository_FindByFollowerID(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"user1", "user2", "user3"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	userRepo := NewUserRepository(dbMap)
+	postRepo := NewPostRepository(dbMap)
+	followRepo := NewFollowRepository(dbMap)
+	feedRepo := NewFeedRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	truncateTable(t, dbMap, "comments")
+	truncateTable(t, dbMap, "follows")
+
+	for _, uid := range []string{"user2", "user2", "user3"} {
+		if err := postRepo.Insert(ctx, &entity.Post{UserID: uid, Title: "title", Code: "code", Language: "go"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dbMap.AddTableWithName(CommentInsertDTO{}, "comments").SetKeys(true, "id")
+	commentDTOs := []*CommentInsertDTO{
+		{UserID: "user2", PostID: 1, Type: "commit", Content: "commit", Code: "fixed"},
+		// commit以外のコメントはフィードに含まない
+		{UserID: "user2", PostID: 1, Type: "none", Content: "none"},
+	}
+	for _, commentDTO := range commentDTOs {
+		if err := dbMap.Insert(commentDTO); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	follows := []*entity.Follow{
+		{FollowerID: "user1", FolloweeID: "user2"},
+		// 同じユーザを2回フォローしても1つとして数える
+		{FollowerID: "user1", FolloweeID: "user2"},
+		{FollowerID: "user3", FolloweeID: "user2"},
+	}
+	for _, follow := range follows {
+		if err := followRepo.Insert(ctx, follow); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := followRepo.Insert(ctx, &entity.Follow{FollowerID: "user1", FolloweeID: "invalid"}); !errors.Is(err, entity.ErrUserNotFound) {
+		t.Errorf("存在しないユーザのフォローはErrUserNotFoundになるべき: %v", err)
+	}
+
+	user, err := userRepo.FindProfile(ctx, "user2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user.FollowerCount != 2 || user.FollowingCount != 0 {
+		t.Errorf("FollowerCount = %d, FollowingCount = %d, want = 2, 0", user.FollowerCount, user.FollowingCount)
+	}
+
+	firstPage, err := feedRepo.FindByFollowerID(ctx, "user1", nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(firstPage) != 2 {
+		t.Fatalf("len(firstPage) = %d, want = 2", len(firstPage))
+	}
+	secondPage, err := feedRepo.FindByFollowerID(ctx, "user1", entity.NewFeedCursor(firstPage[1]), 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(secondPage) != 1 {
+		t.Fatalf("len(secondPage) = %d, want = 1", len(secondPage))
+	}
+
+	var postCount, commitCount int
+	for _, item := range append(firstPage, secondPage...) {
+		if item.Post == nil || item.Post.UserID != "user2" {
+			t.Errorf("フォローしているユーザの投稿ではない: %+v", item.Post)
+		}
+		switch item.Type {
+		case entity.FeedItemPost:
+			postCount++
+		case entity.FeedItemCommit:
+			commitCount++
+			if item.Comment == nil || item.Comment.Content != "commit" {
+				t.Errorf("commitコメントがセットされていない: %+v", item.Comment)
+			}
+		}
+	}
+	if postCount != 2 || commitCount != 1 {
+		t.Errorf("postCount = %d, commitCount = %d, want = 2, 1", postCount, commitCount)
+	}
+
+	if err := followRepo.Delete(ctx, &entity.Follow{FollowerID: "user1", FolloweeID: "user2"}); err != nil {
+		t.Fatal(err)
+	}
+	items, err := feedRepo.FindByFollowerID(ctx, "user1", nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 0 {
+		t.Errorf("フォローを外したユーザの活動が残っている: %+v", items)
+	}
+}
@@ -0,0 +1,73 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-gorp/gorp"
+	"github.com/go-sql-driver/mysql"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+var _ repository.Follow = (*FollowRepository)(nil)
+
+// FollowRepository はユーザのフォロー関係の永続化と再構成のためのリポジトリです
+type FollowRepository struct {
+	dbMap *gorp.DbMap
+}
+
+// NewFollowRepository はフォロー関係のリポジトリのポインタを生成する関数です
+func NewFollowRepository(dbMap *gorp.DbMap) *FollowRepository {
+	return &FollowRepository{dbMap: dbMap}
+}
+
+// Insert はフォロー関係をDBに保存します
+// 既にフォローしている場合は何もしません
+func (r *FollowRepository) Insert(ctx context.Context, follow *entity.Follow) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if err := follow.IsValid(); err != nil {
+			return fmt.Errorf("invalid follow fields: %w", err)
+		}
+
+		if _, err := r.dbMap.Exec(
+			"INSERT INTO follows (follower_id, followee_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE follower_id = follower_id",
+			follow.FollowerID, follow.FolloweeID,
+		); err != nil {
+			if sqlerr, ok := err.(*mysql.MySQLError); ok {
+				// 存在しないユーザをフォローしようとした時のエラー
+				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "followee_id") {
+					return entity.ErrUserNotFound
+				}
+				// 存在しないユーザがフォローしようとした時のエラー
+				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "follower_id") {
+					return entity.ErrUserNotFound
+				}
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// Delete はフォロー関係をDBから削除します
+// フォローしていない場合は何もしません
+func (r *FollowRepository) Delete(ctx context.Context, follow *entity.Follow) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if _, err := r.dbMap.Exec(
+			"DELETE FROM follows WHERE follower_id = ? AND followee_id = ?",
+			follow.FollowerID, follow.FolloweeID,
+		); err != nil {
+			return err
+		}
+		return nil
+	}
+}
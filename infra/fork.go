@@ -0,0 +1,67 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// FindForks は投稿をフォークした投稿のうち公開されていてcursorより古いものを新しい順にlimit件まで取得します
+// 該当する投稿が存在しない場合は空のスライスを返します
+func (p *PostRepository) FindForks(ctx context.Context, postID int, cursor *entity.Cursor, limit int) ([]*entity.Post, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		posts, err := p.selectPage(
+			"forked_from = ? AND visibility = ? AND draft = FALSE",
+			[]interface{}{postID, entity.PostVisibilityPublic},
+			cursor, limit,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed PostRepository.FindForks: %w", err)
+		}
+		return posts, nil
+	}
+}
+
+// loadPostForkCounts は投稿をフォークした投稿のうち公開されているものの数をまとめて取得し，それぞれのForkCountにセットします
+func loadPostForkCounts(exec gorp.SqlExecutor, posts []*entity.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(posts))
+	args := make([]interface{}, 0, len(posts)+1)
+	for _, post := range posts {
+		placeholders = append(placeholders, "?")
+		args = append(args, post.ID)
+	}
+	args = append(args, entity.PostVisibilityPublic)
+	query := "SELECT forked_from, COUNT(*) AS count FROM posts WHERE forked_from IN (" +
+		strings.Join(placeholders, ", ") + ") AND visibility = ? AND draft = FALSE GROUP BY forked_from"
+
+	var forkCountDTOs []ForkCountDTO
+	if _, err := exec.Select(&forkCountDTOs, query, args...); err != nil {
+		return fmt.Errorf("failed to select fork counts: %w", err)
+	}
+
+	counts := make(map[int]int, len(forkCountDTOs))
+	for _, dto := range forkCountDTOs {
+		counts[dto.ForkedFrom] = dto.Count
+	}
+	for _, post := range posts {
+		post.ForkCount = counts[post.ID]
+	}
+	return nil
+}
+
+// ForkCountDTO は投稿ごとのフォークの数をDBから受け取るためのDataTransferObjectです
+// ref: migrations/20210420120000-AddPostsForkedFrom.sql
+type ForkCountDTO struct {
+	ForkedFrom int `db:"forked_from"`
+	Count      int `db:"count"`
+}
@@ -0,0 +1,76 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestPostRepository_Fork(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"owner", "forker"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	if err := postRepo.Insert(ctx, &entity.Post{UserID: "owner", Title: "original", Code: "code", Language: "go"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, visibility := range []string{entity.PostVisibilityPublic, entity.PostVisibilityPrivate} {
+		fork := &entity.Post{UserID: "forker", Title: "fork", Code: "code", Language: "go", Visibility: visibility, ForkedFrom: 1}
+		if err := postRepo.Insert(ctx, fork); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// フォークの数と一覧には公開されているフォークだけが含まれる
+	original, err := postRepo.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if original.ForkCount != 1 {
+		t.Errorf("ForkCount = %d, want = 1", original.ForkCount)
+	}
+	forks, err := postRepo.FindForks(ctx, 1, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(forks) != 1 || forks[0].ID != 2 || forks[0].ForkedFrom != 1 {
+		t.Errorf("FindForks = %v, want = [post 2 forked from 1]", forks)
+	}
+
+	// 更新してもフォーク元は変わらない
+	if err := postRepo.Update(ctx, &entity.Post{ID: 2, UserID: "forker", Title: "updated", Code: "code", Language: "go"}); err != nil {
+		t.Fatal(err)
+	}
+	fork, err := postRepo.FindByID(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fork.ForkedFrom != 1 {
+		t.Errorf("ForkedFrom after Update = %d, want = 1", fork.ForkedFrom)
+	}
+
+	// フォーク元を削除してもフォークは残る
+	if err := postRepo.Delete(ctx, &entity.Post{ID: 1, UserID: "owner"}); err != nil {
+		t.Fatal(err)
+	}
+	fork, err = postRepo.FindByID(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fork.ForkedFrom != 0 {
+		t.Errorf("ForkedFrom after deleting original = %d, want = 0", fork.ForkedFrom)
+	}
+}
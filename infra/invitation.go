@@ -0,0 +1,93 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-sql-driver/mysql"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// Invite はユーザを非公開の投稿に招待します
+// 既に招待している場合は何もしません
+func (p *PostRepository) Invite(ctx context.Context, postID int, userID string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if _, err := p.dbMap.Exec(
+			"INSERT INTO post_invitations (post_id, user_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE user_id = user_id",
+			postID, userID,
+		); err != nil {
+			if sqlerr, ok := err.(*mysql.MySQLError); ok {
+				// 存在しないPostIDで登録した時のエラー
+				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "post_id") {
+					return entity.NewErrorNotFound("post")
+				}
+				// 存在しないUserIDで登録した時のエラー
+				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "user_id") {
+					return entity.NewErrorNotFound("user")
+				}
+			}
+			return fmt.Errorf("failed PostRepository.Invite: %w", err)
+		}
+		return nil
+	}
+}
+
+// Uninvite は投稿への招待を取り消します
+// 招待していない場合は何もしません
+func (p *PostRepository) Uninvite(ctx context.Context, postID int, userID string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if _, err := p.dbMap.Exec(
+			"DELETE FROM post_invitations WHERE post_id = ? AND user_id = ?",
+			postID, userID,
+		); err != nil {
+			return fmt.Errorf("failed PostRepository.Uninvite: %w", err)
+		}
+		return nil
+	}
+}
+
+// FindInvitedUserIDs は投稿に招待されているユーザのIDを招待した順に返します
+func (p *PostRepository) FindInvitedUserIDs(ctx context.Context, postID int) ([]string, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		var userIDs []string
+		if _, err := p.dbMap.Select(
+			&userIDs,
+			"SELECT user_id FROM post_invitations WHERE post_id = ? ORDER BY created_at, user_id",
+			postID,
+		); err != nil {
+			return nil, fmt.Errorf("failed PostRepository.FindInvitedUserIDs: %w", err)
+		}
+		if userIDs == nil {
+			userIDs = []string{}
+		}
+		return userIDs, nil
+	}
+}
+
+// IsInvited はユーザが投稿に招待されているかを返します
+func (p *PostRepository) IsInvited(ctx context.Context, postID int, userID string) (bool, error) {
+	select {
+	case <-ctx.Done():
+		return false, ctx.Err()
+	default:
+		count, err := p.dbMap.SelectInt(
+			"SELECT COUNT(*) FROM post_invitations WHERE post_id = ? AND user_id = ?",
+			postID, userID,
+		)
+		if err != nil {
+			return false, fmt.Errorf("failed PostRepository.IsInvited: %w", err)
+		}
+		return count > 0, nil
+	}
+}
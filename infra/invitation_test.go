@@ -0,0 +1,93 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestPostRepository_Invitation(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"owner", "user1", "user2"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	truncateTable(t, dbMap, "post_invitations")
+	for _, visibility := range []string{entity.PostVisibilityPublic, entity.PostVisibilityUnlisted, entity.PostVisibilityPrivate} {
+		post := &entity.Post{UserID: "owner", Title: visibility, Code: "code", Language: "go", Visibility: visibility}
+		if err := postRepo.Insert(ctx, post); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 一覧には公開されている投稿だけが含まれ，本人の投稿一覧にだけ全ての投稿が含まれる
+	posts, err := postRepo.GetAll(ctx, &entity.PostFilter{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 1 || posts[0].Visibility != entity.PostVisibilityPublic {
+		t.Errorf("GetAll returned non-public posts: %v", posts)
+	}
+	posts, err = postRepo.FindByUserID(ctx, "owner", false, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 1 {
+		t.Errorf("len(FindByUserID(includeHidden=false)) = %d, want = 1", len(posts))
+	}
+	posts, err = postRepo.FindByUserID(ctx, "owner", true, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 3 {
+		t.Errorf("len(FindByUserID(includeHidden=true)) = %d, want = 3", len(posts))
+	}
+
+	for _, id := range []string{"user1", "user2", "user1"} {
+		if err := postRepo.Invite(ctx, 3, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	errNF := &entity.ErrNotFound{}
+	if err := postRepo.Invite(ctx, 3, "unknown"); !errors.As(err, errNF) {
+		t.Errorf("存在しないユーザの招待はNotFoundになるべき: %v", err)
+	}
+	if err := postRepo.Invite(ctx, 100, "user1"); !errors.As(err, errNF) {
+		t.Errorf("存在しない投稿への招待はNotFoundになるべき: %v", err)
+	}
+
+	userIDs, err := postRepo.FindInvitedUserIDs(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"user1", "user2"}, userIDs); diff != "" {
+		t.Errorf("FindInvitedUserIDs (-want +got):\n%s", diff)
+	}
+
+	if err := postRepo.Uninvite(ctx, 3, "user2"); err != nil {
+		t.Fatal(err)
+	}
+	for id, want := range map[string]bool{"user1": true, "user2": false} {
+		invited, err := postRepo.IsInvited(ctx, 3, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if invited != want {
+			t.Errorf("IsInvited(%s) = %v, want = %v", id, invited, want)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+var _ repository.Search = (*MemorySearchRepository)(nil)
+
+// memorySearchTitleWeight はタイトルに含まれるキーワードの関連度の重みです
+const memorySearchTitleWeight = 3
+
+// MemorySearchRepository はプロセス内に保持した投稿とコメントを検索するリポジトリです
+// DBを用意できないテストでSearchRepositoryの代わりに使います
+type MemorySearchRepository struct {
+	mu       sync.RWMutex
+	posts    map[int]*entity.Post
+	comments map[int][]*entity.Comment
+}
+
+// NewMemorySearchRepository はプロセス内で全文検索するリポジトリのポインタを生成する関数です
+func NewMemorySearchRepository() *MemorySearchRepository {
+	return &MemorySearchRepository{
+		posts:    map[int]*entity.Post{},
+		comments: map[int][]*entity.Comment{},
+	}
+}
+
+// AddPost は投稿を検索対象に加えます
+// 同じIDの投稿が既にあれば置き換えます
+func (r *MemorySearchRepository) AddPost(post *entity.Post) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.posts[post.ID] = post
+}
+
+// AddComment はコメントを検索対象に加えます
+func (r *MemorySearchRepository) AddComment(comment *entity.Comment) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.comments[comment.PostID] = append(r.comments[comment.PostID], comment)
+}
+
+// SearchPosts は空白で区切ったキーワードの投稿，ファイル，コメントでの出現回数を関連度として，関連度の高い順に投稿を返します
+// 大文字と小文字は区別しません．SearchRepositoryと同じく公開されている下書きでない投稿だけを返します
+func (r *MemorySearchRepository) SearchPosts(ctx context.Context, query *entity.SearchQuery) ([]*entity.SearchResult, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		if err := query.IsValid(); err != nil {
+			return nil, fmt.Errorf("invalid SearchQuery: %w", err)
+		}
+
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		terms := strings.Fields(strings.ToLower(query.Keyword))
+		results := []*entity.SearchResult{}
+		for _, post := range r.posts {
+			if post.Visibility != entity.PostVisibilityPublic || post.Draft {
+				continue
+			}
+			if len(query.Language) > 0 && !strings.EqualFold(post.Language, query.Language) {
+				continue
+			}
+			if len(query.UserID) > 0 && post.UserID != query.UserID {
+				continue
+			}
+
+			score := memorySearchTitleWeight*countTerms(post.Title, terms) +
+				countTerms(post.Content, terms) + countTerms(post.Code, terms)
+			// 先頭のファイルの内容はCodeと同じなので，ファイルは2つ目以降だけを数える
+			for i, file := range post.Files {
+				if i > 0 {
+					score += countTerms(file.Content, terms)
+				}
+			}
+			for _, comment := range r.comments[post.ID] {
+				score += countTerms(comment.Content, terms)
+			}
+			if score == 0 {
+				continue
+			}
+			results = append(results, &entity.SearchResult{Post: post, Score: float64(score)})
+		}
+
+		sort.Slice(results, func(i, j int) bool {
+			if results[i].Score != results[j].Score {
+				return results[i].Score > results[j].Score
+			}
+			return results[i].Post.ID > results[j].Post.ID
+		})
+
+		if query.Offset >= len(results) {
+			return []*entity.SearchResult{}, nil
+		}
+		results = results[query.Offset:]
+		if query.Limit > 0 && len(results) > query.Limit {
+			results = results[:query.Limit]
+		}
+		return results, nil
+	}
+}
+
+// countTerms はtextに含まれるtermsの出現回数の合計を返します
+func countTerms(text string, terms []string) int {
+	text = strings.ToLower(text)
+	count := 0
+	for _, term := range terms {
+		count += strings.Count(text, term)
+	}
+	return count
+}
@@ -0,0 +1,123 @@
+package infra
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/go-gorp/gorp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// savePostMentions は投稿の本文でのメンションを全て置き換えます
+// execにはトランザクションを渡すことで投稿の保存と一緒にロールバックできます
+func savePostMentions(exec gorp.SqlExecutor, postID int, mentions []*entity.Mention) error {
+	if _, err := exec.Exec("DELETE FROM mentions WHERE post_id = ? AND comment_id IS NULL", postID); err != nil {
+		return fmt.Errorf("failed to delete post mentions: %w", err)
+	}
+	for _, mention := range mentions {
+		if _, err := exec.Exec(
+			"INSERT INTO mentions (post_id, user_id) VALUES (?, ?)",
+			postID, mention.UserID,
+		); err != nil {
+			return fmt.Errorf("failed to insert post mention: %w", err)
+		}
+	}
+	return nil
+}
+
+// saveCommentMentions はコメントの本文でのメンションを全て置き換えます
+// execにはトランザクションを渡すことでコメントの保存と一緒にロールバックできます
+func saveCommentMentions(exec gorp.SqlExecutor, postID, commentID int, mentions []*entity.Mention) error {
+	if _, err := exec.Exec("DELETE FROM mentions WHERE post_id = ? AND comment_id = ?", postID, commentID); err != nil {
+		return fmt.Errorf("failed to delete comment mentions: %w", err)
+	}
+	for _, mention := range mentions {
+		if _, err := exec.Exec(
+			"INSERT INTO mentions (post_id, comment_id, user_id) VALUES (?, ?, ?)",
+			postID, commentID, mention.UserID,
+		); err != nil {
+			return fmt.Errorf("failed to insert comment mention: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadPostMentions は投稿の本文でメンションされたユーザをまとめて取得し，それぞれのMentionsに本文での順にセットします
+func loadPostMentions(exec gorp.SqlExecutor, posts []*entity.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(posts))
+	for _, post := range posts {
+		ids = append(ids, post.ID)
+	}
+	placeholders, args := inPlaceholders(ids)
+	query := `SELECT m.post_id, m.comment_id, m.user_id, u.name
+FROM mentions AS m
+JOIN users AS u ON u.id = m.user_id
+WHERE m.comment_id IS NULL AND m.post_id IN (` + placeholders + `)
+ORDER BY m.id`
+
+	var mentionDTOs []MentionDTO
+	if _, err := exec.Select(&mentionDTOs, query, args...); err != nil {
+		return fmt.Errorf("failed to select post mentions: %w", err)
+	}
+
+	mentionsByPostID := make(map[int][]*entity.Mention)
+	for _, dto := range mentionDTOs {
+		mentionsByPostID[dto.PostID] = append(mentionsByPostID[dto.PostID], &entity.Mention{
+			UserID: dto.UserID,
+			Name:   dto.Name,
+		})
+	}
+	for _, post := range posts {
+		post.Mentions = mentionsByPostID[post.ID]
+	}
+	return nil
+}
+
+// loadCommentMentions はコメントの本文でメンションされたユーザをまとめて取得し，それぞれのMentionsに本文での順にセットします
+func loadCommentMentions(exec gorp.SqlExecutor, comments []*entity.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	placeholders, args := inPlaceholders(ids)
+	query := `SELECT m.post_id, m.comment_id, m.user_id, u.name
+FROM mentions AS m
+JOIN users AS u ON u.id = m.user_id
+WHERE m.comment_id IN (` + placeholders + `)
+ORDER BY m.id`
+
+	var mentionDTOs []MentionDTO
+	if _, err := exec.Select(&mentionDTOs, query, args...); err != nil {
+		return fmt.Errorf("failed to select comment mentions: %w", err)
+	}
+
+	mentionsByCommentID := make(map[int][]*entity.Mention)
+	for _, dto := range mentionDTOs {
+		commentID := int(dto.CommentID.Int64)
+		mentionsByCommentID[commentID] = append(mentionsByCommentID[commentID], &entity.Mention{
+			UserID: dto.UserID,
+			Name:   dto.Name,
+		})
+	}
+	for _, comment := range comments {
+		comment.Mentions = mentionsByCommentID[comment.ID]
+	}
+	return nil
+}
+
+// MentionDTO はメンションされたユーザをDBから受け取るためのDataTransferObjectです
+// ref: migrations/20210412120000-CreateMentions.sql
+type MentionDTO struct {
+	PostID    int           `db:"post_id"`
+	CommentID sql.NullInt64 `db:"comment_id"`
+	UserID    string        `db:"user_id"`
+	Name      string        `db:"name"`
+}
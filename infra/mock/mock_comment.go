@@ -35,6 +35,20 @@ func (m *MockComment) EXPECT() *MockCommentMockRecorder {
 	return m.recorder
 }
 
+// ApplySuggestion mocks base method.
+func (m *MockComment) ApplySuggestion(ctx context.Context, suggestion, commit *entity.Comment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplySuggestion", ctx, suggestion, commit)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplySuggestion indicates an expected call of ApplySuggestion.
+func (mr *MockCommentMockRecorder) ApplySuggestion(ctx, suggestion, commit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplySuggestion", reflect.TypeOf((*MockComment)(nil).ApplySuggestion), ctx, suggestion, commit)
+}
+
 // Delete mocks base method.
 func (m *MockComment) Delete(ctx context.Context, comment *entity.Comment) error {
 	m.ctrl.T.Helper()
@@ -108,6 +122,34 @@ func (mr *MockCommentMockRecorder) Insert(ctx, comment interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockComment)(nil).Insert), ctx, comment)
 }
 
+// Reopen mocks base method.
+func (m *MockComment) Reopen(ctx context.Context, postID, commentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reopen", ctx, postID, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reopen indicates an expected call of Reopen.
+func (mr *MockCommentMockRecorder) Reopen(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reopen", reflect.TypeOf((*MockComment)(nil).Reopen), ctx, postID, commentID)
+}
+
+// Resolve mocks base method.
+func (m *MockComment) Resolve(ctx context.Context, postID, commentID int, resolvedBy string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Resolve", ctx, postID, commentID, resolvedBy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Resolve indicates an expected call of Resolve.
+func (mr *MockCommentMockRecorder) Resolve(ctx, postID, commentID, resolvedBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Resolve", reflect.TypeOf((*MockComment)(nil).Resolve), ctx, postID, commentID, resolvedBy)
+}
+
 // Update mocks base method.
 func (m *MockComment) Update(ctx context.Context, comment *entity.Comment) error {
 	m.ctrl.T.Helper()
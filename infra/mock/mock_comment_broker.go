@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: comment_broker.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// MockCommentBroker is a mock of CommentBroker interface.
+type MockCommentBroker struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentBrokerMockRecorder
+}
+
+// MockCommentBrokerMockRecorder is the mock recorder for MockCommentBroker.
+type MockCommentBrokerMockRecorder struct {
+	mock *MockCommentBroker
+}
+
+// NewMockCommentBroker creates a new mock instance.
+func NewMockCommentBroker(ctrl *gomock.Controller) *MockCommentBroker {
+	mock := &MockCommentBroker{ctrl: ctrl}
+	mock.recorder = &MockCommentBrokerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCommentBroker) EXPECT() *MockCommentBrokerMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockCommentBroker) Publish(ctx context.Context, event *entity.CommentEvent) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockCommentBrokerMockRecorder) Publish(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockCommentBroker)(nil).Publish), ctx, event)
+}
+
+// Subscribe mocks base method.
+func (m *MockCommentBroker) Subscribe(ctx context.Context, postID int) (<-chan *entity.CommentEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", ctx, postID)
+	ret0, _ := ret[0].(<-chan *entity.CommentEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockCommentBrokerMockRecorder) Subscribe(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockCommentBroker)(nil).Subscribe), ctx, postID)
+}
@@ -0,0 +1,64 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: follow.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// MockFollow is a mock of Follow interface.
+type MockFollow struct {
+	ctrl     *gomock.Controller
+	recorder *MockFollowMockRecorder
+}
+
+// MockFollowMockRecorder is the mock recorder for MockFollow.
+type MockFollowMockRecorder struct {
+	mock *MockFollow
+}
+
+// NewMockFollow creates a new mock instance.
+func NewMockFollow(ctrl *gomock.Controller) *MockFollow {
+	mock := &MockFollow{ctrl: ctrl}
+	mock.recorder = &MockFollowMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFollow) EXPECT() *MockFollowMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockFollow) Delete(ctx context.Context, follow *entity.Follow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, follow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFollowMockRecorder) Delete(ctx, follow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFollow)(nil).Delete), ctx, follow)
+}
+
+// Insert mocks base method.
+func (m *MockFollow) Insert(ctx context.Context, follow *entity.Follow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, follow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockFollowMockRecorder) Insert(ctx, follow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockFollow)(nil).Insert), ctx, follow)
+}
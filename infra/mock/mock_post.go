@@ -65,33 +65,63 @@ func (mr *MockPostMockRecorder) FindByID(ctx, postID interface{}) *gomock.Call {
 }
 
 // FindByUserID mocks base method.
-func (m *MockPost) FindByUserID(ctx context.Context, uid string) ([]*entity.Post, error) {
+func (m *MockPost) FindByUserID(ctx context.Context, uid string, includeHidden bool, cursor *entity.Cursor, limit int) ([]*entity.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindByUserID", ctx, uid)
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, uid, includeHidden, cursor, limit)
 	ret0, _ := ret[0].([]*entity.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindByUserID indicates an expected call of FindByUserID.
-func (mr *MockPostMockRecorder) FindByUserID(ctx, uid interface{}) *gomock.Call {
+func (mr *MockPostMockRecorder) FindByUserID(ctx, uid, includeHidden, cursor, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockPost)(nil).FindByUserID), ctx, uid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockPost)(nil).FindByUserID), ctx, uid, includeHidden, cursor, limit)
+}
+
+// FindForks mocks base method.
+func (m *MockPost) FindForks(ctx context.Context, postID int, cursor *entity.Cursor, limit int) ([]*entity.Post, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindForks", ctx, postID, cursor, limit)
+	ret0, _ := ret[0].([]*entity.Post)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindForks indicates an expected call of FindForks.
+func (mr *MockPostMockRecorder) FindForks(ctx, postID, cursor, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindForks", reflect.TypeOf((*MockPost)(nil).FindForks), ctx, postID, cursor, limit)
+}
+
+// FindInvitedUserIDs mocks base method.
+func (m *MockPost) FindInvitedUserIDs(ctx context.Context, postID int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindInvitedUserIDs", ctx, postID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindInvitedUserIDs indicates an expected call of FindInvitedUserIDs.
+func (mr *MockPostMockRecorder) FindInvitedUserIDs(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindInvitedUserIDs", reflect.TypeOf((*MockPost)(nil).FindInvitedUserIDs), ctx, postID)
 }
 
 // GetAll mocks base method.
-func (m *MockPost) GetAll(ctx context.Context) ([]*entity.Post, error) {
+func (m *MockPost) GetAll(ctx context.Context, filter *entity.PostFilter, cursor *entity.Cursor, limit int) ([]*entity.Post, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret := m.ctrl.Call(m, "GetAll", ctx, filter, cursor, limit)
 	ret0, _ := ret[0].([]*entity.Post)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockPostMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockPostMockRecorder) GetAll(ctx, filter, cursor, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPost)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPost)(nil).GetAll), ctx, filter, cursor, limit)
 }
 
 // Insert mocks base method.
@@ -108,6 +138,63 @@ func (mr *MockPostMockRecorder) Insert(ctx, post interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockPost)(nil).Insert), ctx, post)
 }
 
+// Invite mocks base method.
+func (m *MockPost) Invite(ctx context.Context, postID int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", ctx, postID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockPostMockRecorder) Invite(ctx, postID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockPost)(nil).Invite), ctx, postID, userID)
+}
+
+// IsInvited mocks base method.
+func (m *MockPost) IsInvited(ctx context.Context, postID int, userID string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsInvited", ctx, postID, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsInvited indicates an expected call of IsInvited.
+func (mr *MockPostMockRecorder) IsInvited(ctx, postID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsInvited", reflect.TypeOf((*MockPost)(nil).IsInvited), ctx, postID, userID)
+}
+
+// Publish mocks base method.
+func (m *MockPost) Publish(ctx context.Context, postID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, postID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPostMockRecorder) Publish(ctx, postID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPost)(nil).Publish), ctx, postID)
+}
+
+// Uninvite mocks base method.
+func (m *MockPost) Uninvite(ctx context.Context, postID int, userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Uninvite", ctx, postID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Uninvite indicates an expected call of Uninvite.
+func (mr *MockPostMockRecorder) Uninvite(ctx, postID, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Uninvite", reflect.TypeOf((*MockPost)(nil).Uninvite), ctx, postID, userID)
+}
+
 // Update mocks base method.
 func (m *MockPost) Update(ctx context.Context, post *entity.Post) error {
 	m.ctrl.T.Helper()
@@ -121,3 +208,31 @@ func (mr *MockPostMockRecorder) Update(ctx, post interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPost)(nil).Update), ctx, post)
 }
+
+// UpdateAcceptedComment mocks base method.
+func (m *MockPost) UpdateAcceptedComment(ctx context.Context, postID, commentID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAcceptedComment", ctx, postID, commentID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAcceptedComment indicates an expected call of UpdateAcceptedComment.
+func (mr *MockPostMockRecorder) UpdateAcceptedComment(ctx, postID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAcceptedComment", reflect.TypeOf((*MockPost)(nil).UpdateAcceptedComment), ctx, postID, commentID)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockPost) UpdateStatus(ctx context.Context, postID int, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, postID, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockPostMockRecorder) UpdateStatus(ctx, postID, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockPost)(nil).UpdateStatus), ctx, postID, status)
+}
@@ -0,0 +1,79 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: star.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	entity "github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// MockStar is a mock of Star interface.
+type MockStar struct {
+	ctrl     *gomock.Controller
+	recorder *MockStarMockRecorder
+}
+
+// MockStarMockRecorder is the mock recorder for MockStar.
+type MockStarMockRecorder struct {
+	mock *MockStar
+}
+
+// NewMockStar creates a new mock instance.
+func NewMockStar(ctrl *gomock.Controller) *MockStar {
+	mock := &MockStar{ctrl: ctrl}
+	mock.recorder = &MockStarMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStar) EXPECT() *MockStarMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockStar) Delete(ctx context.Context, star *entity.Star) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, star)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockStarMockRecorder) Delete(ctx, star interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockStar)(nil).Delete), ctx, star)
+}
+
+// FindStarredPostIDs mocks base method.
+func (m *MockStar) FindStarredPostIDs(ctx context.Context, userID string, postIDs []int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindStarredPostIDs", ctx, userID, postIDs)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindStarredPostIDs indicates an expected call of FindStarredPostIDs.
+func (mr *MockStarMockRecorder) FindStarredPostIDs(ctx, userID, postIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindStarredPostIDs", reflect.TypeOf((*MockStar)(nil).FindStarredPostIDs), ctx, userID, postIDs)
+}
+
+// Insert mocks base method.
+func (m *MockStar) Insert(ctx context.Context, star *entity.Star) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, star)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockStarMockRecorder) Insert(ctx, star interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockStar)(nil).Insert), ctx, star)
+}
@@ -50,6 +50,21 @@ func (mr *MockUserMockRecorder) FindByID(ctx, uid interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockUser)(nil).FindByID), ctx, uid)
 }
 
+// FindProfile mocks base method.
+func (m *MockUser) FindProfile(ctx context.Context, uid string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindProfile", ctx, uid)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindProfile indicates an expected call of FindProfile.
+func (mr *MockUserMockRecorder) FindProfile(ctx, uid interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindProfile", reflect.TypeOf((*MockUser)(nil).FindProfile), ctx, uid)
+}
+
 // Insert mocks base method.
 func (m *MockUser) Insert(ctx context.Context, user *entity.User) error {
 	m.ctrl.T.Helper()
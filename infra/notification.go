@@ -0,0 +1,160 @@
+package infra
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-gorp/gorp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+var _ repository.Notification = (*NotificationRepository)(nil)
+
+// NotificationRepository はユーザへの通知の永続化と再構成のためのリポジトリです
+type NotificationRepository struct {
+	dbMap *gorp.DbMap
+}
+
+// NewNotificationRepository は通知のリポジトリのポインタを生成する関数です
+func NewNotificationRepository(dbMap *gorp.DbMap) *NotificationRepository {
+	return &NotificationRepository{dbMap: dbMap}
+}
+
+// FindByID はidの通知を取得します
+func (r *NotificationRepository) FindByID(ctx context.Context, id int) (*entity.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		var dto NotificationDTO
+		if err := r.dbMap.SelectOne(&dto, "SELECT * FROM notifications WHERE id = ?", id); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, entity.NewErrorNotFound("notification")
+			}
+			return nil, err
+		}
+		return &entity.Notification{
+			ID:        dto.ID,
+			UserID:    dto.UserID,
+			ActorID:   dto.ActorID,
+			Type:      dto.Type,
+			PostID:    dto.PostID,
+			CommentID: int(dto.CommentID.Int64),
+			Read:      dto.Read,
+			CreatedAt: service.ConvertTimeToStr(dto.CreatedAt),
+		}, nil
+	}
+}
+
+// FindByUserID はuidのユーザへの通知をcursorの位置からcreated_at, idの降順でlimit件まで取得します
+// 該当する通知が存在しない場合は空のスライスを返します
+func (r *NotificationRepository) FindByUserID(ctx context.Context, uid string, cursor *entity.Cursor, limit int) ([]*entity.Notification, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		query := "SELECT * FROM notifications WHERE user_id = ?"
+		args := []interface{}{uid}
+		if cursor != nil {
+			createdAt, err := service.ConvertStrToTime(cursor.CreatedAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid cursor time: %w", entity.ErrInvalidCursor)
+			}
+			query += " AND (created_at, id) < (?, ?)"
+			args = append(args, createdAt, cursor.ID)
+		}
+		query += " ORDER BY created_at DESC, id DESC LIMIT ?"
+		args = append(args, limit)
+
+		var dtos []NotificationDTO
+		if _, err := r.dbMap.Select(&dtos, query, args...); err != nil {
+			return nil, fmt.Errorf("failed NotificationRepository.FindByUserID: %w", err)
+		}
+
+		notifications := make([]*entity.Notification, 0, len(dtos))
+		for _, dto := range dtos {
+			notifications = append(notifications, &entity.Notification{
+				ID:        dto.ID,
+				UserID:    dto.UserID,
+				ActorID:   dto.ActorID,
+				Type:      dto.Type,
+				PostID:    dto.PostID,
+				CommentID: int(dto.CommentID.Int64),
+				Read:      dto.Read,
+				CreatedAt: service.ConvertTimeToStr(dto.CreatedAt),
+			})
+		}
+		return notifications, nil
+	}
+}
+
+// CountUnread はuidのユーザへの未読の通知の数を返します
+func (r *NotificationRepository) CountUnread(ctx context.Context, uid string) (int, error) {
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	default:
+		count, err := r.dbMap.SelectInt("SELECT COUNT(*) FROM notifications WHERE user_id = ? AND is_read = FALSE", uid)
+		if err != nil {
+			return 0, fmt.Errorf("failed NotificationRepository.CountUnread: %w", err)
+		}
+		return int(count), nil
+	}
+}
+
+// Insert は通知をまとめてDBに保存します
+func (r *NotificationRepository) Insert(ctx context.Context, notifications []*entity.Notification) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if len(notifications) == 0 {
+			return nil
+		}
+
+		values := make([]string, 0, len(notifications))
+		args := make([]interface{}, 0, len(notifications)*5)
+		for _, n := range notifications {
+			values = append(values, "(?, ?, ?, ?, ?)")
+			args = append(args, n.UserID, n.ActorID, n.Type, n.PostID, newNullID(n.CommentID))
+		}
+		query := "INSERT INTO notifications (user_id, actor_id, type, post_id, comment_id) VALUES " + strings.Join(values, ", ")
+		if _, err := r.dbMap.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed NotificationRepository.Insert: %w", err)
+		}
+		return nil
+	}
+}
+
+// MarkRead は通知を既読にします
+func (r *NotificationRepository) MarkRead(ctx context.Context, notification *entity.Notification) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if _, err := r.dbMap.Exec("UPDATE notifications SET is_read = TRUE WHERE id = ?", notification.ID); err != nil {
+			return fmt.Errorf("failed NotificationRepository.MarkRead: %w", err)
+		}
+		notification.Read = true
+		return nil
+	}
+}
+
+// NotificationDTO はDBとやり取りするためのDataTransferObjectです
+// ref: migrations/20210411120000-CreateNotifications.sql
+type NotificationDTO struct {
+	ID        int           `db:"id"`
+	UserID    string        `db:"user_id"`
+	ActorID   string        `db:"actor_id"`
+	Type      string        `db:"type"`
+	PostID    int           `db:"post_id"`
+	CommentID sql.NullInt64 `db:"comment_id"`
+	Read      bool          `db:"is_read"`
+	CreatedAt time.Time     `db:"created_at"`
+}
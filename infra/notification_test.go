@@ -0,0 +1,86 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestNotificationRepository(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"user1", "user2"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	notificationRepo := NewNotificationRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	truncateTable(t, dbMap, "comments")
+	truncateTable(t, dbMap, "notifications")
+	if err := postRepo.Insert(ctx, &entity.Post{UserID: "user1", Title: "title", Code: "code", Language: "go"}); err != nil {
+		t.Fatal(err)
+	}
+	dbMap.AddTableWithName(CommentInsertDTO{}, "comments").SetKeys(true, "id")
+	for i := 0; i < 2; i++ {
+		if err := dbMap.Insert(&CommentInsertDTO{UserID: "user2", PostID: 1, Type: "none", Content: "comment"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := notificationRepo.Insert(ctx, []*entity.Notification{
+		{UserID: "user1", ActorID: "user2", Type: entity.NotificationComment, PostID: 1, CommentID: 1},
+		{UserID: "user1", ActorID: "user2", Type: entity.NotificationComment, PostID: 1, CommentID: 2},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	unread, err := notificationRepo.CountUnread(ctx, "user1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unread != 2 {
+		t.Errorf("unread = %d, want = 2", unread)
+	}
+
+	notifications, err := notificationRepo.FindByUserID(ctx, "user1", nil, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notifications) != 1 || notifications[0].CommentID != 2 {
+		t.Fatalf("新しい通知から取得されていない: %+v", notifications)
+	}
+	rest, err := notificationRepo.FindByUserID(ctx, "user1", entity.NewNotificationCursor(notifications[0]), 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rest) != 1 || rest[0].CommentID != 1 {
+		t.Fatalf("cursorより後の通知が取得されていない: %+v", rest)
+	}
+
+	if err := notificationRepo.MarkRead(ctx, rest[0]); err != nil {
+		t.Fatal(err)
+	}
+	read, err := notificationRepo.FindByID(ctx, rest[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !read.Read {
+		t.Errorf("通知が既読になっていない: %+v", read)
+	}
+	if unread, err = notificationRepo.CountUnread(ctx, "user1"); err != nil {
+		t.Fatal(err)
+	}
+	if unread != 1 {
+		t.Errorf("unread = %d, want = 1", unread)
+	}
+}
@@ -30,33 +30,39 @@ func NewPostRepository(dbMap *gorp.DbMap) *PostRepository {
 	return &PostRepository{dbMap: dbMap}
 }
 
-// GetAll はMySQLサーバに接続して、全てのPostを取得して返すメソッドです
-func (p *PostRepository) GetAll(ctx context.Context) ([]*entity.Post, error) {
+// GetAll はMySQLサーバに接続して、filterを満たしcursorより古いPostを新しい順にlimit件まで取得して返すメソッドです
+// cursorがnilの場合は最新のPostから取得します．一覧には公開された投稿のみを含め，下書きはfilterのViewerIDのユーザのものだけを含めます
+func (p *PostRepository) GetAll(ctx context.Context, filter *entity.PostFilter, cursor *entity.Cursor, limit int) ([]*entity.Post, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
-		var postDTOs []PostDTO
-		if _, err := p.dbMap.Select(&postDTOs, "SELECT * FROM posts"); err != nil {
-			return nil, fmt.Errorf("failed PostRepository.GetAll: %w", err)
+		conds := []string{"visibility = ?"}
+		args := []interface{}{entity.PostVisibilityPublic}
+		if filter != nil && len(filter.ViewerID) > 0 {
+			conds = append(conds, "(draft = FALSE OR user_id = ?)")
+			args = append(args, filter.ViewerID)
+		} else {
+			conds = append(conds, "draft = FALSE")
 		}
-
-		var posts []*entity.Post
-		for _, dto := range postDTOs {
-			posts = append(posts, &entity.Post{
-				ID:        dto.ID,
-				UserID:    dto.UserID,
-				Title:     dto.Title,
-				Code:      dto.Code,
-				Language:  dto.Language,
-				Content:   dto.Content,
-				Source:    dto.Source,
-				CreatedAt: service.ConvertTimeToStr(dto.CreatedAt),
-				UpdatedAt: service.ConvertTimeToStr(dto.UpdatedAt),
-			})
+		if filter != nil && len(filter.Tag) > 0 {
+			conds = append(conds, `id IN (
+	SELECT pt.post_id FROM post_tags AS pt JOIN tags AS t ON t.id = pt.tag_id WHERE t.name = ?
+)`)
+			args = append(args, filter.Tag)
+		}
+		if filter != nil && len(filter.StarredBy) > 0 {
+			conds = append(conds, "id IN (SELECT post_id FROM stars WHERE user_id = ?)")
+			args = append(args, filter.StarredBy)
 		}
-		if posts == nil {
-			return nil, entity.NewErrorNotFound("post")
+		if filter != nil && len(filter.Status) > 0 {
+			conds = append(conds, "status = ?")
+			args = append(args, filter.Status)
+		}
+
+		posts, err := p.selectPage(strings.Join(conds, " AND "), args, cursor, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed PostRepository.GetAll: %w", err)
 		}
 		return posts, nil
 	}
@@ -76,55 +82,51 @@ func (p *PostRepository) FindByID(ctx context.Context, postID int) (*entity.Post
 			return nil, err
 		}
 
-		return &entity.Post{
-			ID:        postDTO.ID,
-			UserID:    postDTO.UserID,
-			Title:     postDTO.Title,
-			Code:      postDTO.Code,
-			Language:  postDTO.Language,
-			Content:   postDTO.Content,
-			Source:    postDTO.Source,
-			CreatedAt: service.ConvertTimeToStr(postDTO.CreatedAt),
-			UpdatedAt: service.ConvertTimeToStr(postDTO.UpdatedAt),
-		}, nil
+		post := &entity.Post{
+			ID:                postDTO.ID,
+			UserID:            postDTO.UserID,
+			Title:             postDTO.Title,
+			Code:              postDTO.Code,
+			Language:          postDTO.Language,
+			Content:           postDTO.Content,
+			Source:            postDTO.Source,
+			Status:            postDTO.Status,
+			Visibility:        postDTO.Visibility,
+			Draft:             postDTO.Draft,
+			ForkedFrom:        int(postDTO.ForkedFrom.Int64),
+			AcceptedCommentID: int(postDTO.AcceptedCommentID.Int64),
+			CreatedAt:         service.ConvertTimeToStr(postDTO.CreatedAt),
+			UpdatedAt:         service.ConvertTimeToStr(postDTO.UpdatedAt),
+		}
+		if err := loadPostRelations(p.dbMap, []*entity.Post{post}); err != nil {
+			return nil, fmt.Errorf("failed PostRepository.FindByID: %w", err)
+		}
+		return post, nil
 	}
 }
 
-// FindByUserID はユーザの投稿をDBから取得します
-func (p *PostRepository) FindByUserID(ctx context.Context, uid string) ([]*entity.Post, error) {
+// FindByUserID はユーザの投稿のうちcursorより古いものを新しい順にlimit件までDBから取得します
+// includeHiddenがfalseなら公開された投稿のみ，trueなら限定公開や非公開の投稿と下書きも含めます
+func (p *PostRepository) FindByUserID(ctx context.Context, uid string, includeHidden bool, cursor *entity.Cursor, limit int) ([]*entity.Post, error) {
 	select {
 	case <-ctx.Done():
 		return nil, ctx.Err()
 	default:
-		var postDTOs []PostDTO
-		if _, err := p.dbMap.Select(&postDTOs, "SELECT * FROM posts WHERE user_id = ?", uid); err != nil {
-			return nil, fmt.Errorf("failed PostRepository.FindByUserID: %w", err)
-		}
-
-		var posts []*entity.Post
-
-		for _, dto := range postDTOs {
-			posts = append(posts, &entity.Post{
-				ID:        dto.ID,
-				UserID:    dto.UserID,
-				Title:     dto.Title,
-				Code:      dto.Code,
-				Language:  dto.Language,
-				Content:   dto.Content,
-				Source:    dto.Source,
-				CreatedAt: service.ConvertTimeToStr(dto.CreatedAt),
-				UpdatedAt: service.ConvertTimeToStr(dto.UpdatedAt),
-			})
+		cond := "user_id = ?"
+		args := []interface{}{uid}
+		if !includeHidden {
+			cond += " AND visibility = ? AND draft = FALSE"
+			args = append(args, entity.PostVisibilityPublic)
 		}
-		if posts == nil {
-			return nil, entity.NewErrorNotFound("post")
+		posts, err := p.selectPage(cond, args, cursor, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed PostRepository.FindByUserID: %w", err)
 		}
-
 		return posts, nil
 	}
 }
 
-// Insert は引数で渡したエンティティの投稿をDBに保存します
+// Insert は引数で渡したエンティティの投稿をファイル，タグ，メンションとともにDBに保存します
 func (p *PostRepository) Insert(ctx context.Context, post *entity.Post) error {
 	select {
 	case <-ctx.Done():
@@ -134,19 +136,32 @@ func (p *PostRepository) Insert(ctx context.Context, post *entity.Post) error {
 			return fmt.Errorf("invalid post field: %w", err)
 		}
 
+		// 公開範囲が指定されなければ公開する
+		if len(post.Visibility) == 0 {
+			post.Visibility = entity.PostVisibilityPublic
+		}
+
 		// リクエストにAPI仕様にないフィールドidが含まれていたら任意のpostIDをフロントで
 		// セットできてしまうので，DTOに変換する時に0でIDを初期化しておく
 		postDTO := &PostInsertDTO{
-			ID:       0,
-			UserID:   post.UserID,
-			Title:    post.Title,
-			Code:     post.Code,
-			Language: post.Language,
-			Content:  post.Content,
-			Source:   post.Source,
+			ID:         0,
+			UserID:     post.UserID,
+			Title:      post.Title,
+			Code:       post.Code,
+			Language:   post.Language,
+			Content:    post.Content,
+			Source:     post.Source,
+			Visibility: post.Visibility,
+			Draft:      post.Draft,
+			ForkedFrom: sql.NullInt64{Int64: int64(post.ForkedFrom), Valid: post.ForkedFrom != 0},
 		}
 
-		if err := p.dbMap.Insert(postDTO); err != nil {
+		tx, err := p.dbMap.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		if err := tx.Insert(postDTO); err != nil {
+			_ = tx.Rollback()
 			if sqlerr, ok := err.(*mysql.MySQLError); ok {
 				// 存在しないユーザIDで登録した時のエラー
 				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "user_id") {
@@ -159,12 +174,27 @@ func (p *PostRepository) Insert(ctx context.Context, post *entity.Post) error {
 			}
 			return err
 		}
+		if err := savePostFiles(tx, postDTO.ID, post.Files); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := savePostTags(tx, postDTO.ID, post.Tags); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := savePostMentions(tx, postDTO.ID, post.Mentions); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
 		post.ID = postDTO.ID
 		return nil
 	}
 }
 
-// Update は引数で渡したエンティティの投稿でDBに保存されている情報を更新します
+// Update は引数で渡したエンティティの投稿でDBに保存されている情報とファイル，タグ，メンションを更新します
 // 投稿の所有者以外が更新する場合、更新は行われません
 func (p *PostRepository) Update(ctx context.Context, post *entity.Post) error {
 	select {
@@ -187,25 +217,117 @@ func (p *PostRepository) Update(ctx context.Context, post *entity.Post) error {
 		if getPost.UserID != post.UserID {
 			return entity.ErrIsNotAuthor
 		}
+		// 公開範囲が指定されなければ変更しない
+		if len(post.Visibility) == 0 {
+			post.Visibility = getPost.Visibility
+		}
+		// 下書きの公開はPublishでのみ行う
+		post.Draft = getPost.Draft
+		// フォーク元は変更できない
+		post.ForkedFrom = getPost.ForkedFrom
 
 		postDTO := &PostInsertDTO{
-			ID:       post.ID,
-			UserID:   post.UserID,
-			Title:    post.Title,
-			Code:     post.Code,
-			Language: post.Language,
-			Content:  post.Content,
-			Source:   post.Source,
+			ID:         post.ID,
+			UserID:     post.UserID,
+			Title:      post.Title,
+			Code:       post.Code,
+			Language:   post.Language,
+			Content:    post.Content,
+			Source:     post.Source,
+			Visibility: post.Visibility,
+			Draft:      post.Draft,
+			ForkedFrom: sql.NullInt64{Int64: int64(post.ForkedFrom), Valid: post.ForkedFrom != 0},
 		}
 
-		if _, err := p.dbMap.Update(postDTO); err != nil {
+		tx, err := p.dbMap.Begin()
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		if _, err := tx.Update(postDTO); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := savePostFiles(tx, post.ID, post.Files); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := savePostTags(tx, post.ID, post.Tags); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := savePostMentions(tx, post.ID, post.Mentions); err != nil {
+			_ = tx.Rollback()
 			return err
 		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// UpdateStatus は投稿の状態を更新します
+// 状態を変えても投稿の内容は変わらないので，updated_atは更新しません
+func (p *PostRepository) UpdateStatus(ctx context.Context, postID int, status string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if !entity.IsPostStatus(status) {
+			return entity.ErrInvalidPostStatus
+		}
+		if _, err := p.dbMap.Exec(
+			"UPDATE posts SET status = ?, updated_at = updated_at WHERE id = ?",
+			status, postID,
+		); err != nil {
+			return fmt.Errorf("failed PostRepository.UpdateStatus: %w", err)
+		}
+		return nil
+	}
+}
+
+// Publish は下書きの投稿を公開します
+// 一覧で公開した時点の投稿として並ぶように，created_atを公開した時刻にします
+// 既に公開されている場合はErrPostAlreadyPublishedを返します
+func (p *PostRepository) Publish(ctx context.Context, postID int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		res, err := p.dbMap.Exec(
+			"UPDATE posts SET draft = FALSE, created_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND draft = TRUE",
+			postID,
+		)
+		if err != nil {
+			return fmt.Errorf("failed PostRepository.Publish: %w", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed PostRepository.Publish: %w", err)
+		}
+		if affected == 0 {
+			return entity.ErrPostAlreadyPublished
+		}
+		return nil
+	}
+}
+
+// UpdateAcceptedComment は投稿のオーナーが回答として採用したコメントを更新します
+// commentIDが0の場合は採用を取り消します
+// 採用を変えても投稿の内容は変わらないので，updated_atは更新しません
+func (p *PostRepository) UpdateAcceptedComment(ctx context.Context, postID, commentID int) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if err := updateAcceptedComment(p.dbMap, postID, commentID); err != nil {
+			return fmt.Errorf("failed PostRepository.UpdateAcceptedComment: %w", err)
+		}
+		return nil
+	}
+}
+
 // Delete は引数で渡したIDの投稿を削除します
 // 投稿の所有者以外が削除する場合、削除は行われません
 func (p *PostRepository) Delete(ctx context.Context, post *entity.Post) error {
@@ -229,6 +351,10 @@ func (p *PostRepository) Delete(ctx context.Context, post *entity.Post) error {
 			ID: post.ID,
 		}
 
+		// 採用したコメントへの参照を残したままだと，コメントの削除が投稿自身の更新を引き起こすので先に外す
+		if err := updateAcceptedComment(p.dbMap, post.ID, 0); err != nil {
+			return err
+		}
 		if _, err := p.dbMap.Delete(postDTO); err != nil {
 			return err
 		}
@@ -237,31 +363,130 @@ func (p *PostRepository) Delete(ctx context.Context, post *entity.Post) error {
 	return nil
 }
 
+// selectPage はcondとcursorを満たすPostをファイル，タグ，メンション，スターとフォークの数とともにcreated_at, idの降順でlimit件まで取得します
+// 該当するPostが存在しない場合は空のスライスを返します
+func (p *PostRepository) selectPage(cond string, args []interface{}, cursor *entity.Cursor, limit int) ([]*entity.Post, error) {
+	var conds []string
+	if len(cond) > 0 {
+		conds = append(conds, cond)
+	}
+	if cursor != nil {
+		createdAt, err := service.ConvertStrToTime(cursor.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cursor time: %w", entity.ErrInvalidCursor)
+		}
+		conds = append(conds, "(created_at, id) < (?, ?)")
+		args = append(args, createdAt, cursor.ID)
+	}
+
+	query := "SELECT * FROM posts"
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id DESC LIMIT ?"
+	args = append(args, limit)
+
+	var postDTOs []PostDTO
+	if _, err := p.dbMap.Select(&postDTOs, query, args...); err != nil {
+		return nil, err
+	}
+
+	posts := make([]*entity.Post, 0, len(postDTOs))
+	for _, dto := range postDTOs {
+		posts = append(posts, &entity.Post{
+			ID:                dto.ID,
+			UserID:            dto.UserID,
+			Title:             dto.Title,
+			Code:              dto.Code,
+			Language:          dto.Language,
+			Content:           dto.Content,
+			Source:            dto.Source,
+			Status:            dto.Status,
+			Visibility:        dto.Visibility,
+			Draft:             dto.Draft,
+			ForkedFrom:        int(dto.ForkedFrom.Int64),
+			AcceptedCommentID: int(dto.AcceptedCommentID.Int64),
+			CreatedAt:         service.ConvertTimeToStr(dto.CreatedAt),
+			UpdatedAt:         service.ConvertTimeToStr(dto.UpdatedAt),
+		})
+	}
+	if err := loadPostRelations(p.dbMap, posts); err != nil {
+		return nil, err
+	}
+	return posts, nil
+}
+
+// updateAcceptedComment は投稿が採用したコメントを(id, post_id)の組で参照するように更新します
+// commentIDが0の場合は参照を外します
+func updateAcceptedComment(exec gorp.SqlExecutor, postID, commentID int) error {
+	var accepted sql.NullInt64
+	if commentID != 0 {
+		accepted = sql.NullInt64{Int64: int64(commentID), Valid: true}
+	}
+	acceptedPostID := sql.NullInt64{Int64: int64(postID), Valid: accepted.Valid}
+	if _, err := exec.Exec(
+		"UPDATE posts SET accepted_comment_id = ?, accepted_post_id = ?, updated_at = updated_at WHERE id = ?",
+		accepted, acceptedPostID, postID,
+	); err != nil {
+		if sqlerr, ok := err.(*mysql.MySQLError); ok && sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 {
+			return entity.NewErrorNotFound("comment")
+		}
+		return err
+	}
+	return nil
+}
+
+// loadPostRelations は投稿とは別のテーブルに保存されているファイル，タグ，メンション，スターの数と，フォークの数をまとめて取得してセットします
+func loadPostRelations(exec gorp.SqlExecutor, posts []*entity.Post) error {
+	if err := loadPostFiles(exec, posts); err != nil {
+		return err
+	}
+	if err := loadPostTags(exec, posts); err != nil {
+		return err
+	}
+	if err := loadPostMentions(exec, posts); err != nil {
+		return err
+	}
+	if err := loadPostStarCounts(exec, posts); err != nil {
+		return err
+	}
+	return loadPostForkCounts(exec, posts)
+}
+
 // PostDTO はDBとやりとりするためのDataTransferObjectです
 // ref: migrations/20210319141439-CreatePosts.sql
 type PostDTO struct {
-	ID        int       `db:"id"`
-	UserID    string    `db:"user_id"`
-	Title     string    `db:"title"`
-	Code      string    `db:"code"`
-	Language  string    `db:"language"`
-	Content   string    `db:"content"`
-	Source    string    `db:"source"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID                int           `db:"id"`
+	UserID            string        `db:"user_id"`
+	Title             string        `db:"title"`
+	Code              string        `db:"code"`
+	Language          string        `db:"language"`
+	Content           string        `db:"content"`
+	Source            string        `db:"source"`
+	Status            string        `db:"status"`
+	Visibility        string        `db:"visibility"`
+	Draft             bool          `db:"draft"`
+	ForkedFrom        sql.NullInt64 `db:"forked_from"`
+	AcceptedCommentID sql.NullInt64 `db:"accepted_comment_id"`
+	AcceptedPostID    sql.NullInt64 `db:"accepted_post_id"`
+	CreatedAt         time.Time     `db:"created_at"`
+	UpdatedAt         time.Time     `db:"updated_at"`
 }
 
 // PostInsertDTO はInsert用のDataTransferObjectです
 // timestamp系は参照しないようにしています
 // ref: https://github.com/go-gorp/gorp/issues/125
 type PostInsertDTO struct {
-	ID        int       `db:"id"`
-	UserID    string    `db:"user_id"`
-	Title     string    `db:"title"`
-	Code      string    `db:"code"`
-	Language  string    `db:"language"`
-	Content   string    `db:"content"`
-	Source    string    `db:"source"`
-	CreatedAt time.Time `db:"-"`
-	UpdatedAt time.Time `db:"-"`
+	ID         int           `db:"id"`
+	UserID     string        `db:"user_id"`
+	Title      string        `db:"title"`
+	Code       string        `db:"code"`
+	Language   string        `db:"language"`
+	Content    string        `db:"content"`
+	Source     string        `db:"source"`
+	Visibility string        `db:"visibility"`
+	Draft      bool          `db:"draft"`
+	ForkedFrom sql.NullInt64 `db:"forked_from"`
+	CreatedAt  time.Time     `db:"-"`
+	UpdatedAt  time.Time     `db:"-"`
 }
@@ -0,0 +1,71 @@
+package infra
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// savePostFiles は投稿のファイルをfilesで置き換えます
+// ファイルの並び順はpositionとして0から順に保存します
+func savePostFiles(exec gorp.SqlExecutor, postID int, files []*entity.PostFile) error {
+	if _, err := exec.Exec("DELETE FROM post_files WHERE post_id = ?", postID); err != nil {
+		return fmt.Errorf("failed to delete post files: %w", err)
+	}
+	for position, file := range files {
+		if _, err := exec.Exec(
+			"INSERT INTO post_files (post_id, position, filename, language, content) VALUES (?, ?, ?, ?, ?)",
+			postID, position, file.Filename, file.Language, file.Content,
+		); err != nil {
+			return fmt.Errorf("failed to insert post file: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadPostFiles は投稿のファイルをまとめて取得し，それぞれのFilesに並び順でセットします
+// 単一のファイルの投稿のFilesはnilのままです
+func loadPostFiles(exec gorp.SqlExecutor, posts []*entity.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(posts))
+	args := make([]interface{}, 0, len(posts))
+	for _, post := range posts {
+		placeholders = append(placeholders, "?")
+		args = append(args, post.ID)
+	}
+	query := "SELECT * FROM post_files WHERE post_id IN (" +
+		strings.Join(placeholders, ", ") + ") ORDER BY post_id, position"
+
+	var postFileDTOs []PostFileDTO
+	if _, err := exec.Select(&postFileDTOs, query, args...); err != nil {
+		return fmt.Errorf("failed to select post files: %w", err)
+	}
+
+	filesByPostID := make(map[int][]*entity.PostFile)
+	for _, dto := range postFileDTOs {
+		filesByPostID[dto.PostID] = append(filesByPostID[dto.PostID], &entity.PostFile{
+			Filename: dto.Filename,
+			Language: dto.Language,
+			Content:  dto.Content,
+		})
+	}
+	for _, post := range posts {
+		post.Files = filesByPostID[post.ID]
+	}
+	return nil
+}
+
+// PostFileDTO は投稿のファイルをDBとやりとりするためのDataTransferObjectです
+// ref: migrations/20210421120000-CreatePostFiles.sql
+type PostFileDTO struct {
+	PostID   int    `db:"post_id"`
+	Position int    `db:"position"`
+	Filename string `db:"filename"`
+	Language string `db:"language"`
+	Content  string `db:"content"`
+}
@@ -0,0 +1,57 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestPostRepository_Files(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	if err := dbMap.Insert(&UserDTO{ID: "owner", Name: "owner", TwitterID: "owner"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	files := []*entity.PostFile{
+		{Filename: "main.go", Language: "go", Content: "package main"},
+		{Filename: "main_test.go", Language: "go", Content: "package main_test"},
+	}
+	if err := postRepo.Insert(ctx, &entity.Post{UserID: "owner", Title: "title", Code: "package main", Language: "go", Files: files}); err != nil {
+		t.Fatal(err)
+	}
+
+	// ファイルは投稿したときの順に取得できる
+	post, err := postRepo.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(files, post.Files); diff != "" {
+		t.Errorf("Files (-want +got) =\n%s", diff)
+	}
+
+	// 更新するとファイルは置き換えられる
+	updated := []*entity.PostFile{
+		{Filename: "main_test.go", Language: "go", Content: "package main_test"},
+	}
+	if err := postRepo.Update(ctx, &entity.Post{ID: 1, UserID: "owner", Title: "title", Code: "package main_test", Language: "go", Files: updated}); err != nil {
+		t.Fatal(err)
+	}
+	post, err = postRepo.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(updated, post.Files); diff != "" {
+		t.Errorf("Files after Update (-want +got) =\n%s", diff)
+	}
+}
@@ -33,22 +33,24 @@ func TestPostRepository_GetAll(t *testing.T) {
 
 	validPosts := []*PostInsertDTO{
 		{
-			ID:       1,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         1,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 		{
-			ID:       2,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         2,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 	}
 
@@ -57,33 +59,85 @@ func TestPostRepository_GetAll(t *testing.T) {
 
 	for _, validPost := range validPosts {
 		wantPosts = append(wantPosts, &entity.Post{
-			ID:       validPost.ID,
-			UserID:   validPost.UserID,
-			Title:    validPost.Title,
-			Code:     validPost.Code,
-			Language: validPost.Language,
-			Content:  validPost.Content,
-			Source:   validPost.Source,
+			ID:         validPost.ID,
+			UserID:     validPost.UserID,
+			Title:      validPost.Title,
+			Code:       validPost.Code,
+			Language:   validPost.Language,
+			Content:    validPost.Content,
+			Source:     validPost.Source,
+			Status:     entity.PostStatusOpen,
+			Visibility: entity.PostVisibilityPublic,
 		})
 	}
+	wantPosts[0].Tags = []string{"go", "test"}
 
 	tests := []struct {
 		name      string
 		posts     []*entity.Post
+		filter    *entity.PostFilter
+		cursor    *entity.Cursor
+		limit     int
 		wantPosts []*entity.Post
 		wantErr   error
 	}{
 		{
-			name:      "正しく全ての投稿を取得できる",
+			name:      "正しく全ての投稿を新しい順に取得できる",
 			posts:     wantPosts,
-			wantPosts: wantPosts,
+			cursor:    nil,
+			limit:     10,
+			wantPosts: []*entity.Post{wantPosts[1], wantPosts[0]},
 			wantErr:   nil,
 		},
 		{
-			name:      "投稿が存在しなければNotFound",
+			name:      "limit件までしか取得しない",
+			posts:     wantPosts,
+			cursor:    nil,
+			limit:     1,
+			wantPosts: []*entity.Post{wantPosts[1]},
+			wantErr:   nil,
+		},
+		{
+			name:      "タグで絞り込める",
+			posts:     wantPosts,
+			filter:    &entity.PostFilter{Tag: "test"},
+			cursor:    nil,
+			limit:     10,
+			wantPosts: []*entity.Post{wantPosts[0]},
+			wantErr:   nil,
+		},
+		{
+			name:      "存在しないタグで絞り込むと空のスライスを返す",
+			posts:     wantPosts,
+			filter:    &entity.PostFilter{Tag: "rust"},
+			cursor:    nil,
+			limit:     10,
+			wantPosts: []*entity.Post{},
+			wantErr:   nil,
+		},
+		{
+			name:      "cursorより新しい投稿は取得しない",
+			posts:     wantPosts,
+			cursor:    &entity.Cursor{CreatedAt: "2100-01-01T00:00:00+09:00", ID: 0},
+			limit:     10,
+			wantPosts: []*entity.Post{wantPosts[1], wantPosts[0]},
+			wantErr:   nil,
+		},
+		{
+			name:      "cursorより古い投稿が存在しなければ空のスライスを返す",
+			posts:     wantPosts,
+			cursor:    &entity.Cursor{CreatedAt: "2000-01-01T00:00:00+09:00", ID: 0},
+			limit:     10,
+			wantPosts: []*entity.Post{},
+			wantErr:   nil,
+		},
+		{
+			name:      "投稿が存在しなければ空のスライスを返す",
 			posts:     nil,
-			wantPosts: nil,
-			wantErr:   entity.NewErrorNotFound("post"),
+			cursor:    nil,
+			limit:     10,
+			wantPosts: []*entity.Post{},
+			wantErr:   nil,
 		},
 	}
 
@@ -93,6 +147,8 @@ func TestPostRepository_GetAll(t *testing.T) {
 			ctx := context.Background()
 			// 初期化
 			truncateTable(t, dbMap, "posts")
+			truncateTable(t, dbMap, "post_tags")
+			truncateTable(t, dbMap, "tags")
 
 			for _, validPost := range tt.posts {
 				// デフォルトの投稿追加
@@ -100,18 +156,16 @@ func TestPostRepository_GetAll(t *testing.T) {
 					t.Fatal(err)
 				}
 			}
-			posts, err := postRepo.GetAll(ctx)
+			posts, err := postRepo.GetAll(ctx, tt.filter, tt.cursor, tt.limit)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
 				return
 			}
 
 			if tt.wantErr == nil {
-				for idx := range posts {
-					diff := cmp.Diff(tt.wantPosts[idx], posts[idx], cmpopts.IgnoreFields(entity.Post{}, "CreatedAt", "UpdatedAt"))
-					if diff != "" {
-						t.Errorf("Data (-want +got) =\n%s\n", diff)
-					}
+				diff := cmp.Diff(tt.wantPosts, posts, cmpopts.IgnoreFields(entity.Post{}, "CreatedAt", "UpdatedAt"))
+				if diff != "" {
+					t.Errorf("Data (-want +got) =\n%s\n", diff)
 				}
 			}
 		})
@@ -144,13 +198,14 @@ func TestPostRepository_FindByID(t *testing.T) {
 	truncateTable(t, dbMap, "posts")
 
 	validPost := &PostInsertDTO{
-		ID:       1,
-		UserID:   "user-id",
-		Title:    "test title",
-		Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-		Language: "Go",
-		Content:  "Test code",
-		Source:   "github.com",
+		ID:         1,
+		UserID:     "user-id",
+		Title:      "test title",
+		Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+		Language:   "Go",
+		Content:    "Test code",
+		Source:     "github.com",
+		Visibility: entity.PostVisibilityPublic,
 	}
 	// デフォルトの投稿追加
 	if err := dbMap.Insert(validPost); err != nil {
@@ -167,15 +222,17 @@ func TestPostRepository_FindByID(t *testing.T) {
 			name:   "正常に取得できる",
 			postID: 1,
 			wantPost: &entity.Post{
-				ID:        validPost.ID,
-				UserID:    validPost.UserID,
-				Title:     validPost.Title,
-				Code:      validPost.Code,
-				Language:  validPost.Language,
-				Content:   validPost.Content,
-				Source:    validPost.Source,
-				CreatedAt: service.ConvertTimeToStr(validPost.CreatedAt),
-				UpdatedAt: service.ConvertTimeToStr(validPost.UpdatedAt),
+				ID:         validPost.ID,
+				UserID:     validPost.UserID,
+				Title:      validPost.Title,
+				Code:       validPost.Code,
+				Language:   validPost.Language,
+				Content:    validPost.Content,
+				Source:     validPost.Source,
+				Status:     entity.PostStatusOpen,
+				Visibility: entity.PostVisibilityPublic,
+				CreatedAt:  service.ConvertTimeToStr(validPost.CreatedAt),
+				UpdatedAt:  service.ConvertTimeToStr(validPost.UpdatedAt),
 			},
 			wantErr: nil,
 		},
@@ -245,44 +302,49 @@ func TestUserRepository_FindByUserID(t *testing.T) {
 
 	validPosts := []*PostInsertDTO{
 		{
-			ID:       1,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         1,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 		{
-			ID:       2,
-			UserID:   "user-id2",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         2,
+			UserID:     "user-id2",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 		{
-			ID:       3,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         3,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 	}
 
 	var wantPosts []*entity.Post
 	for _, validPost := range validPosts {
 		wantPosts = append(wantPosts, &entity.Post{
-			ID:       validPost.ID,
-			UserID:   validPost.UserID,
-			Title:    validPost.Title,
-			Code:     validPost.Code,
-			Language: validPost.Language,
-			Content:  validPost.Content,
-			Source:   validPost.Source,
+			ID:         validPost.ID,
+			UserID:     validPost.UserID,
+			Title:      validPost.Title,
+			Code:       validPost.Code,
+			Language:   validPost.Language,
+			Content:    validPost.Content,
+			Source:     validPost.Source,
+			Status:     entity.PostStatusOpen,
+			Visibility: entity.PostVisibilityPublic,
 		})
 	}
 
@@ -294,21 +356,21 @@ func TestUserRepository_FindByUserID(t *testing.T) {
 		wantErr   error
 	}{
 		{
-			name:   "正しく全ての投稿を取得できる",
+			name:   "正しく全ての投稿を新しい順に取得できる",
 			userID: "user-id",
 			posts:  wantPosts,
 			wantPosts: []*entity.Post{
-				wantPosts[0],
 				wantPosts[2],
+				wantPosts[0],
 			},
 			wantErr: nil,
 		},
 		{
-			name:      "投稿が存在しなければNotFound",
+			name:      "投稿が存在しなければ空のスライスを返す",
 			userID:    "user-id3",
 			posts:     wantPosts,
-			wantPosts: nil,
-			wantErr:   entity.NewErrorNotFound("post"),
+			wantPosts: []*entity.Post{},
+			wantErr:   nil,
 		},
 	}
 
@@ -327,18 +389,16 @@ func TestUserRepository_FindByUserID(t *testing.T) {
 					t.Fatal(err)
 				}
 			}
-			posts, err := postRepo.FindByUserID(ctx, tt.userID)
+			posts, err := postRepo.FindByUserID(ctx, tt.userID, false, nil, 10)
 			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("error = %v, wantErr = %v", err, tt.wantErr)
 				return
 			}
 
 			if tt.wantErr == nil {
-				for idx := range posts {
-					diff := cmp.Diff(tt.wantPosts[idx], posts[idx], cmpopts.IgnoreFields(entity.Post{}, "CreatedAt", "UpdatedAt"))
-					if diff != "" {
-						t.Errorf("Data (-want +got) =\n%s\n", diff)
-					}
+				diff := cmp.Diff(tt.wantPosts, posts, cmpopts.IgnoreFields(entity.Post{}, "CreatedAt", "UpdatedAt"))
+				if diff != "" {
+					t.Errorf("Data (-want +got) =\n%s\n", diff)
 				}
 			}
 		})
@@ -369,13 +429,14 @@ func TestPostRepository_Insert(t *testing.T) {
 
 	// デフォルトの投稿追加
 	if err := dbMap.Insert(&PostInsertDTO{
-		ID:       1,
-		UserID:   "user-id",
-		Title:    "test title",
-		Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-		Language: "Go",
-		Content:  "Test code",
-		Source:   "github.com",
+		ID:         1,
+		UserID:     "user-id",
+		Title:      "test title",
+		Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+		Language:   "Go",
+		Content:    "Test code",
+		Source:     "github.com",
+		Visibility: entity.PostVisibilityPublic,
 	}); err != nil {
 		t.Fatal(err)
 	}
@@ -488,31 +549,34 @@ func TestPostRepository_Update(t *testing.T) {
 
 	validPosts := []*PostInsertDTO{
 		{
-			ID:       1,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         1,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 		{
-			ID:       2,
-			UserID:   "user-id2",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         2,
+			UserID:     "user-id2",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 		{
-			ID:       3,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         3,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 	}
 	// デフォルトの投稿追加
@@ -621,22 +685,24 @@ func TestPostRepository_Delete(t *testing.T) {
 
 	validPosts := []*PostInsertDTO{
 		{
-			ID:       1,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         1,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 		{
-			ID:       2,
-			UserID:   "user-id",
-			Title:    "test title",
-			Code:     "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
-			Language: "Go",
-			Content:  "Test code",
-			Source:   "github.com",
+			ID:         2,
+			UserID:     "user-id",
+			Title:      "test title",
+			Code:       "package main\n\nimport \"fmt\"\n\nfunc main(){fmt.Println(\"This is test.\")}",
+			Language:   "Go",
+			Content:    "Test code",
+			Source:     "github.com",
+			Visibility: entity.PostVisibilityPublic,
 		},
 	}
 	// デフォルトの投稿追加
@@ -696,3 +762,174 @@ func TestPostRepository_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestPostRepository_UpdateStatus(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	if err := dbMap.Insert(&UserDTO{ID: "user-id", Name: "test user", TwitterID: "twitter"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	for i := 0; i < 2; i++ {
+		if err := postRepo.Insert(ctx, &entity.Post{UserID: "user-id", Title: "title", Code: "code", Language: "go"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := postRepo.UpdateStatus(ctx, 1, entity.PostStatusArchived); err != nil {
+		t.Fatal(err)
+	}
+	if err := postRepo.UpdateStatus(ctx, 1, "closed"); !errors.Is(err, entity.ErrInvalidPostStatus) {
+		t.Errorf("error = %v, wantErr = %v", err, entity.ErrInvalidPostStatus)
+	}
+
+	posts, err := postRepo.GetAll(ctx, &entity.PostFilter{Status: entity.PostStatusArchived}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 1 || posts[0].ID != 1 || posts[0].Status != entity.PostStatusArchived {
+		t.Errorf("アーカイブした投稿だけを取得できていない: %+v", posts)
+	}
+	post, err := postRepo.FindByID(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.Status != entity.PostStatusOpen {
+		t.Errorf("Status = %s, want = %s", post.Status, entity.PostStatusOpen)
+	}
+}
+
+func TestPostRepository_UpdateAcceptedComment(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	if err := dbMap.Insert(&UserDTO{ID: "user-id", Name: "test user", TwitterID: "twitter"}); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	commentRepo := NewCommentRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	truncateTable(t, dbMap, "comments")
+	if err := postRepo.Insert(ctx, &entity.Post{UserID: "user-id", Title: "title", Code: "code", Language: "go"}); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := commentRepo.Insert(ctx, &entity.Comment{UserID: "user-id", PostID: 1, Type: "none", Content: "answer"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := postRepo.UpdateAcceptedComment(ctx, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+	errNF := &entity.ErrNotFound{}
+	if err := postRepo.UpdateAcceptedComment(ctx, 1, 100); !errors.As(err, errNF) {
+		t.Errorf("存在しないコメントを採用できてしまう: %v", err)
+	}
+	post, err := postRepo.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.AcceptedCommentID != 1 {
+		t.Errorf("AcceptedCommentID = %d, want = %d", post.AcceptedCommentID, 1)
+	}
+
+	// 採用されたコメントを削除すると採用も取り消される
+	if err := commentRepo.Delete(ctx, &entity.Comment{ID: 1, PostID: 1, UserID: "user-id"}); err != nil {
+		t.Fatal(err)
+	}
+	post, err = postRepo.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.AcceptedCommentID != 0 {
+		t.Errorf("AcceptedCommentID = %d, want = %d", post.AcceptedCommentID, 0)
+	}
+}
+
+func TestPostRepository_Publish(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"owner", "other"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	if err := postRepo.Insert(ctx, &entity.Post{UserID: "owner", Title: "draft", Code: "code", Language: "go", Draft: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := postRepo.Insert(ctx, &entity.Post{UserID: "owner", Title: "published", Code: "code", Language: "go"}); err != nil {
+		t.Fatal(err)
+	}
+	// 下書きのcreated_atを公開済みの投稿より古くしておき，公開で新しくなることを確かめる
+	if _, err := dbMap.Exec("UPDATE posts SET created_at = ? WHERE id = 1", "2021-01-01 00:00:00"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 下書きはオーナー以外の一覧には含まれない
+	for _, tt := range []struct {
+		name      string
+		filter    *entity.PostFilter
+		wantCount int
+	}{
+		{name: "未ログイン", filter: &entity.PostFilter{}, wantCount: 1},
+		{name: "オーナー以外", filter: &entity.PostFilter{ViewerID: "other"}, wantCount: 1},
+		{name: "オーナー", filter: &entity.PostFilter{ViewerID: "owner"}, wantCount: 2},
+	} {
+		posts, err := postRepo.GetAll(ctx, tt.filter, nil, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(posts) != tt.wantCount {
+			t.Errorf("%s: len(GetAll) = %d, want = %d", tt.name, len(posts), tt.wantCount)
+		}
+	}
+	posts, err := postRepo.FindByUserID(ctx, "owner", false, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 1 {
+		t.Errorf("len(FindByUserID(includeHidden=false)) = %d, want = 1", len(posts))
+	}
+
+	if err := postRepo.Publish(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := postRepo.Publish(ctx, 1); !errors.Is(err, entity.ErrPostAlreadyPublished) {
+		t.Errorf("公開済みの投稿はErrPostAlreadyPublishedになるべき: %v", err)
+	}
+	posts, err = postRepo.GetAll(ctx, &entity.PostFilter{}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 2 {
+		t.Fatalf("len(GetAll) = %d, want = 2", len(posts))
+	}
+	// 公開した時刻が作成日時になるので，公開した下書きが先頭に並ぶ
+	if posts[0].ID != 1 || posts[0].Draft {
+		t.Errorf("GetAll()[0] = {ID: %d, Draft: %v}, want = {ID: 1, Draft: false}", posts[0].ID, posts[0].Draft)
+	}
+}
@@ -0,0 +1,113 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-gorp/gorp"
+	"github.com/go-sql-driver/mysql"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+var _ repository.Reaction = (*ReactionRepository)(nil)
+
+// ReactionRepository はコメントへのリアクションの永続化と再構成のためのリポジトリです
+type ReactionRepository struct {
+	dbMap *gorp.DbMap
+}
+
+// NewReactionRepository はリアクションのリポジトリのポインタを生成する関数です
+func NewReactionRepository(dbMap *gorp.DbMap) *ReactionRepository {
+	return &ReactionRepository{dbMap: dbMap}
+}
+
+// Insert はリアクションをDBに保存します
+// 既に同じユーザが同じコメントに同じ絵文字のリアクションをつけている場合は何もしません
+func (r *ReactionRepository) Insert(ctx context.Context, reaction *entity.Reaction) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if err := reaction.IsValid(); err != nil {
+			return fmt.Errorf("invalid reaction fields: %w", err)
+		}
+
+		if _, err := r.dbMap.Exec(
+			"INSERT INTO reactions (user_id, post_id, comment_id, emoji) VALUES (?, ?, ?, ?) ON DUPLICATE KEY UPDATE user_id = user_id",
+			reaction.UserID, reaction.PostID, reaction.CommentID, reaction.Emoji,
+		); err != nil {
+			if sqlerr, ok := err.(*mysql.MySQLError); ok {
+				// 存在しないコメントにリアクションした時のエラー
+				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "comment_id") {
+					return entity.NewErrorNotFound("comment")
+				}
+				// 存在しないUserIDで登録した時のエラー
+				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "user_id") {
+					return entity.NewErrorNotFound("user")
+				}
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// Delete はリアクションをDBから削除します
+// リアクションをつけていない場合は何もしません
+func (r *ReactionRepository) Delete(ctx context.Context, reaction *entity.Reaction) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if _, err := r.dbMap.Exec(
+			"DELETE FROM reactions WHERE user_id = ? AND post_id = ? AND comment_id = ? AND emoji = ?",
+			reaction.UserID, reaction.PostID, reaction.CommentID, reaction.Emoji,
+		); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// loadCommentReactionCounts はコメントについている絵文字ごとのリアクションの数をまとめて取得し，それぞれのReactionsにセットします
+func loadCommentReactionCounts(exec gorp.SqlExecutor, comments []*entity.Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]int, 0, len(comments))
+	for _, comment := range comments {
+		ids = append(ids, comment.ID)
+	}
+	placeholders, args := inPlaceholders(ids)
+	query := "SELECT comment_id, emoji, COUNT(*) AS count FROM reactions WHERE comment_id IN (" +
+		placeholders + ") GROUP BY comment_id, emoji"
+
+	var reactionCountDTOs []ReactionCountDTO
+	if _, err := exec.Select(&reactionCountDTOs, query, args...); err != nil {
+		return fmt.Errorf("failed to select reaction counts: %w", err)
+	}
+
+	counts := make(map[int]map[string]int)
+	for _, dto := range reactionCountDTOs {
+		if counts[dto.CommentID] == nil {
+			counts[dto.CommentID] = make(map[string]int)
+		}
+		counts[dto.CommentID][dto.Emoji] = dto.Count
+	}
+	for _, comment := range comments {
+		comment.Reactions = entity.NewReactionCounts(counts[comment.ID])
+	}
+	return nil
+}
+
+// ReactionCountDTO はコメントと絵文字ごとのリアクションの数をDBから受け取るためのDataTransferObjectです
+// ref: migrations/20210413120000-CreateReactions.sql
+type ReactionCountDTO struct {
+	CommentID int    `db:"comment_id"`
+	Emoji     string `db:"emoji"`
+	Count     int    `db:"count"`
+}
@@ -0,0 +1,79 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestReactionRepository(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"user1", "user2"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	commentRepo := NewCommentRepository(dbMap)
+	reactionRepo := NewReactionRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	truncateTable(t, dbMap, "comments")
+	truncateTable(t, dbMap, "reactions")
+	if err := postRepo.Insert(ctx, &entity.Post{UserID: "user1", Title: "title", Code: "code", Language: "go"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := commentRepo.Insert(ctx, &entity.Comment{UserID: "user2", PostID: 1, Type: "none", Content: "comment"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reactions := []*entity.Reaction{
+		{UserID: "user1", PostID: 1, CommentID: 1, Emoji: "eyes"},
+		{UserID: "user1", PostID: 1, CommentID: 1, Emoji: "thumbsup"},
+		{UserID: "user2", PostID: 1, CommentID: 1, Emoji: "thumbsup"},
+		// 同じリアクションを2回つけても1つとして数える
+		{UserID: "user2", PostID: 1, CommentID: 1, Emoji: "thumbsup"},
+	}
+	for _, reaction := range reactions {
+		if err := reactionRepo.Insert(ctx, reaction); err != nil {
+			t.Fatal(err)
+		}
+	}
+	errNF := &entity.ErrNotFound{}
+	if err := reactionRepo.Insert(ctx, &entity.Reaction{UserID: "user1", PostID: 1, CommentID: 100, Emoji: "eyes"}); !errors.As(err, errNF) {
+		t.Errorf("存在しないコメントへのリアクションはNotFoundになるべき: %v", err)
+	}
+
+	comments, err := commentRepo.FindByPostID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*entity.ReactionCount{
+		{Emoji: "thumbsup", Count: 2},
+		{Emoji: "eyes", Count: 1},
+	}
+	if diff := cmp.Diff(want, comments[0].Reactions); diff != "" {
+		t.Errorf("Reactions (-want +got) =\n%s\n", diff)
+	}
+
+	if err := reactionRepo.Delete(ctx, &entity.Reaction{UserID: "user1", PostID: 1, CommentID: 1, Emoji: "eyes"}); err != nil {
+		t.Fatal(err)
+	}
+	comment, err := commentRepo.FindByID(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want[:1], comment.Reactions); diff != "" {
+		t.Errorf("Reactions (-want +got) =\n%s\n", diff)
+	}
+}
@@ -0,0 +1,111 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-gorp/gorp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+var _ repository.Search = (*SearchRepository)(nil)
+
+// SearchRepository はMySQLのFULLTEXTインデックスを使った全文検索のためのリポジトリです
+// ref: migrations/20210406120000-AddFulltextIndexes.sql, migrations/20210423120000-AddPostFilesFulltextIndex.sql
+type SearchRepository struct {
+	dbMap *gorp.DbMap
+}
+
+// NewSearchRepository は全文検索のリポジトリのポインタを生成する関数です
+func NewSearchRepository(dbMap *gorp.DbMap) *SearchRepository {
+	return &SearchRepository{dbMap: dbMap}
+}
+
+// SearchPosts は投稿，投稿のファイル，コメントをキーワードで検索し，投稿ごとの関連度の合計が高い順に返します
+// 該当する投稿が存在しない場合は空のスライスを返します
+func (r *SearchRepository) SearchPosts(ctx context.Context, query *entity.SearchQuery) ([]*entity.SearchResult, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		if err := query.IsValid(); err != nil {
+			return nil, fmt.Errorf("invalid SearchQuery: %w", err)
+		}
+
+		// ファイルとコメントの関連度は投稿ごとに合計してから投稿自体の関連度に足す
+		// 先頭のファイルの内容はposts.codeと同じなので，ファイルは2つ目以降だけを数える
+		sqlQuery := `SELECT p.*, MATCH (p.title, p.content, p.code) AGAINST (?) + COALESCE(f.score, 0) + COALESCE(c.score, 0) AS score
+FROM posts AS p
+LEFT JOIN (
+	SELECT post_id, SUM(MATCH (content) AGAINST (?)) AS score
+	FROM post_files
+	WHERE MATCH (content) AGAINST (?) AND position > 0
+	GROUP BY post_id
+) AS f ON f.post_id = p.id
+LEFT JOIN (
+	SELECT post_id, SUM(MATCH (content) AGAINST (?)) AS score
+	FROM comments
+	WHERE MATCH (content) AGAINST (?)
+	GROUP BY post_id
+) AS c ON c.post_id = p.id
+WHERE (MATCH (p.title, p.content, p.code) AGAINST (?) OR f.score IS NOT NULL OR c.score IS NOT NULL) AND p.visibility = ? AND p.draft = FALSE`
+		args := []interface{}{
+			query.Keyword, query.Keyword, query.Keyword, query.Keyword, query.Keyword, query.Keyword, entity.PostVisibilityPublic,
+		}
+		if len(query.Language) > 0 {
+			sqlQuery += " AND p.language = ?"
+			args = append(args, query.Language)
+		}
+		if len(query.UserID) > 0 {
+			sqlQuery += " AND p.user_id = ?"
+			args = append(args, query.UserID)
+		}
+		sqlQuery += " ORDER BY score DESC, p.id DESC LIMIT ? OFFSET ?"
+		args = append(args, query.Limit, query.Offset)
+
+		var dtos []SearchResultDTO
+		if _, err := r.dbMap.Select(&dtos, sqlQuery, args...); err != nil {
+			return nil, fmt.Errorf("failed SearchRepository.SearchPosts: %w", err)
+		}
+
+		results := make([]*entity.SearchResult, 0, len(dtos))
+		for _, dto := range dtos {
+			results = append(results, &entity.SearchResult{
+				Post: &entity.Post{
+					ID:                dto.ID,
+					UserID:            dto.UserID,
+					Title:             dto.Title,
+					Code:              dto.Code,
+					Language:          dto.Language,
+					Content:           dto.Content,
+					Source:            dto.Source,
+					Status:            dto.Status,
+					Visibility:        dto.Visibility,
+					Draft:             dto.Draft,
+					ForkedFrom:        int(dto.ForkedFrom.Int64),
+					AcceptedCommentID: int(dto.AcceptedCommentID.Int64),
+					CreatedAt:         service.ConvertTimeToStr(dto.CreatedAt),
+					UpdatedAt:         service.ConvertTimeToStr(dto.UpdatedAt),
+				},
+				Score: dto.Score,
+			})
+		}
+
+		posts := make([]*entity.Post, 0, len(results))
+		for _, result := range results {
+			posts = append(posts, result.Post)
+		}
+		if err := loadPostRelations(r.dbMap, posts); err != nil {
+			return nil, fmt.Errorf("failed SearchRepository.SearchPosts: %w", err)
+		}
+		return results, nil
+	}
+}
+
+// SearchResultDTO は検索結果をDBから受け取るためのDataTransferObjectです
+type SearchResultDTO struct {
+	PostDTO
+	Score float64 `db:"score"`
+}
@@ -0,0 +1,111 @@
+package infra
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestSearchRepository_SearchPosts(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"user1", "user2"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dbMap.AddTableWithName(PostDTO{}, "posts").SetKeys(true, "id")
+	truncateTable(t, dbMap, "posts")
+	posts := []*PostDTO{
+		{ID: 1, UserID: "user1", Title: "goroutine leak", Code: "go func() {}", Language: "Go"},
+		{ID: 2, UserID: "user2", Title: "hello", Code: "print('goroutine')", Language: "Python"},
+		{ID: 3, UserID: "user1", Title: "channel", Code: "ch := make(chan int)", Language: "Go"},
+	}
+	for _, post := range posts {
+		post.Status = entity.PostStatusOpen
+		post.Visibility = entity.PostVisibilityPublic
+		post.CreatedAt = time.Unix(100, 0)
+		post.UpdatedAt = time.Unix(100, 0)
+		if err := dbMap.Insert(post); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	truncateTable(t, dbMap, "post_files")
+	if err := savePostFiles(dbMap, 3, []*entity.PostFile{
+		{Filename: "main.go", Language: "Go", Content: "ch := make(chan int)"},
+		{Filename: "worker.go", Language: "Go", Content: "var wg sync.WaitGroup"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	commentRepo := NewCommentRepository(dbMap)
+	truncateTable(t, dbMap, "comments")
+	if err := commentRepo.Insert(context.Background(), &entity.Comment{
+		UserID:  "user2",
+		PostID:  3,
+		Type:    "none",
+		Content: "goroutineで書き直すと良さそう",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	searchRepo := NewSearchRepository(dbMap)
+
+	tests := []struct {
+		name        string
+		query       *entity.SearchQuery
+		wantPostIDs []int
+	}{
+		{
+			name:        "投稿とコメントの両方から検索できる",
+			query:       &entity.SearchQuery{Keyword: "goroutine", Limit: 10},
+			wantPostIDs: []int{1, 2, 3},
+		},
+		{
+			name:        "言語と投稿者で絞り込める",
+			query:       &entity.SearchQuery{Keyword: "goroutine", Language: "Go", UserID: "user1", Limit: 10},
+			wantPostIDs: []int{1, 3},
+		},
+		{
+			name:        "2つ目以降のファイルの内容でも検索できる",
+			query:       &entity.SearchQuery{Keyword: "WaitGroup", Limit: 10},
+			wantPostIDs: []int{3},
+		},
+		{
+			name:        "ヒットしなければ空のスライスを返す",
+			query:       &entity.SearchQuery{Keyword: "rust", Limit: 10},
+			wantPostIDs: []int{},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := searchRepo.SearchPosts(context.Background(), tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// 関連度の値はMySQLの実装に依存するので，ヒットした投稿の集合だけを確認する
+			got := map[int]bool{}
+			for _, result := range results {
+				got[result.Post.ID] = true
+			}
+			want := map[int]bool{}
+			for _, id := range tt.wantPostIDs {
+				want[id] = true
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("PostIDs (-want +got) =\n%s\n", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/VividCortex/mysqlerr"
+	"github.com/go-gorp/gorp"
+	"github.com/go-sql-driver/mysql"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+var _ repository.Star = (*StarRepository)(nil)
+
+// StarRepository は投稿へのスターの永続化と再構成のためのリポジトリです
+type StarRepository struct {
+	dbMap *gorp.DbMap
+}
+
+// NewStarRepository はスターのリポジトリのポインタを生成する関数です
+func NewStarRepository(dbMap *gorp.DbMap) *StarRepository {
+	return &StarRepository{dbMap: dbMap}
+}
+
+// Insert はスターをDBに保存します
+// 既に同じユーザが同じ投稿にスターをつけている場合は何もしません
+func (r *StarRepository) Insert(ctx context.Context, star *entity.Star) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if err := star.IsValid(); err != nil {
+			return fmt.Errorf("invalid star fields: %w", err)
+		}
+
+		if _, err := r.dbMap.Exec(
+			"INSERT INTO stars (user_id, post_id) VALUES (?, ?) ON DUPLICATE KEY UPDATE user_id = user_id",
+			star.UserID, star.PostID,
+		); err != nil {
+			if sqlerr, ok := err.(*mysql.MySQLError); ok {
+				// 存在しないPostIDで登録した時のエラー
+				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "post_id") {
+					return entity.NewErrorNotFound("post")
+				}
+				// 存在しないUserIDで登録した時のエラー
+				if sqlerr.Number == mysqlerr.ER_NO_REFERENCED_ROW_2 && strings.Contains(sqlerr.Message, "user_id") {
+					return entity.NewErrorNotFound("user")
+				}
+			}
+			return err
+		}
+		return nil
+	}
+}
+
+// Delete はスターをDBから削除します
+// スターをつけていない場合は何もしません
+func (r *StarRepository) Delete(ctx context.Context, star *entity.Star) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+		if _, err := r.dbMap.Exec(
+			"DELETE FROM stars WHERE user_id = ? AND post_id = ?",
+			star.UserID, star.PostID,
+		); err != nil {
+			return err
+		}
+		return nil
+	}
+}
+
+// FindStarredPostIDs はpostIDsのうちユーザがスターをつけている投稿のIDを返します
+func (r *StarRepository) FindStarredPostIDs(ctx context.Context, userID string, postIDs []int) ([]int, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		if len(postIDs) == 0 {
+			return []int{}, nil
+		}
+
+		placeholders := make([]string, 0, len(postIDs))
+		args := []interface{}{userID}
+		for _, postID := range postIDs {
+			placeholders = append(placeholders, "?")
+			args = append(args, postID)
+		}
+		query := "SELECT post_id FROM stars WHERE user_id = ? AND post_id IN (" + strings.Join(placeholders, ", ") + ")"
+
+		var starred []int
+		if _, err := r.dbMap.Select(&starred, query, args...); err != nil {
+			return nil, fmt.Errorf("failed StarRepository.FindStarredPostIDs: %w", err)
+		}
+		if starred == nil {
+			starred = []int{}
+		}
+		return starred, nil
+	}
+}
+
+// loadPostStarCounts は投稿についているスターの数をまとめて取得し，それぞれのStarCountにセットします
+func loadPostStarCounts(exec gorp.SqlExecutor, posts []*entity.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(posts))
+	args := make([]interface{}, 0, len(posts))
+	for _, post := range posts {
+		placeholders = append(placeholders, "?")
+		args = append(args, post.ID)
+	}
+	query := "SELECT post_id, COUNT(*) AS count FROM stars WHERE post_id IN (" +
+		strings.Join(placeholders, ", ") + ") GROUP BY post_id"
+
+	var starCountDTOs []StarCountDTO
+	if _, err := exec.Select(&starCountDTOs, query, args...); err != nil {
+		return fmt.Errorf("failed to select star counts: %w", err)
+	}
+
+	counts := make(map[int]int, len(starCountDTOs))
+	for _, dto := range starCountDTOs {
+		counts[dto.PostID] = dto.Count
+	}
+	for _, post := range posts {
+		post.StarCount = counts[post.ID]
+	}
+	return nil
+}
+
+// StarCountDTO は投稿ごとのスターの数をDBから受け取るためのDataTransferObjectです
+// ref: migrations/20210409120000-CreateStars.sql
+type StarCountDTO struct {
+	PostID int `db:"post_id"`
+	Count  int `db:"count"`
+}
@@ -0,0 +1,80 @@
+package infra
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestStarRepository(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	for _, id := range []string{"user1", "user2"} {
+		if err := dbMap.Insert(&UserDTO{ID: id, Name: id, TwitterID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx := context.Background()
+	postRepo := NewPostRepository(dbMap)
+	starRepo := NewStarRepository(dbMap)
+	truncateTable(t, dbMap, "posts")
+	truncateTable(t, dbMap, "stars")
+	for i := 0; i < 2; i++ {
+		if err := postRepo.Insert(ctx, &entity.Post{UserID: "user1", Title: "title", Code: "code", Language: "go"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stars := []*entity.Star{
+		{UserID: "user1", PostID: 1},
+		{UserID: "user2", PostID: 1},
+		// 同じスターを2回つけても1つとして数える
+		{UserID: "user2", PostID: 1},
+		{UserID: "user2", PostID: 2},
+	}
+	for _, star := range stars {
+		if err := starRepo.Insert(ctx, star); err != nil {
+			t.Fatal(err)
+		}
+	}
+	errNF := &entity.ErrNotFound{}
+	if err := starRepo.Insert(ctx, &entity.Star{UserID: "user1", PostID: 100}); !errors.As(err, errNF) {
+		t.Errorf("存在しない投稿へのスターはNotFoundになるべき: %v", err)
+	}
+
+	post, err := postRepo.FindByID(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if post.StarCount != 2 {
+		t.Errorf("StarCount = %d, want = 2", post.StarCount)
+	}
+
+	starred, err := starRepo.FindStarredPostIDs(ctx, "user1", []int{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]int{1}, starred); diff != "" {
+		t.Errorf("starred (-want +got) =\n%s\n", diff)
+	}
+
+	if err := starRepo.Delete(ctx, &entity.Star{UserID: "user2", PostID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	posts, err := postRepo.GetAll(ctx, &entity.PostFilter{StarredBy: "user2"}, nil, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(posts) != 1 || posts[0].ID != 2 || posts[0].StarCount != 1 {
+		t.Errorf("スターを外した投稿が残っている: %+v", posts)
+	}
+}
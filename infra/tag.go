@@ -0,0 +1,120 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-gorp/gorp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+var _ repository.Tag = (*TagRepository)(nil)
+
+// TagRepository はタグ情報を再構成するためのリポジトリです
+type TagRepository struct {
+	dbMap *gorp.DbMap
+}
+
+// NewTagRepository はタグ情報のリポジトリのポインタを生成する関数です
+func NewTagRepository(dbMap *gorp.DbMap) *TagRepository {
+	return &TagRepository{dbMap: dbMap}
+}
+
+// GetAll は公開された投稿に1つ以上つけられているタグを，ついている投稿の数が多い順に取得します
+// 投稿の数が同じタグは名前順に並べます
+func (t *TagRepository) GetAll(ctx context.Context) ([]*entity.Tag, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		query := `SELECT t.name, COUNT(*) AS count
+FROM tags AS t
+JOIN post_tags AS pt ON pt.tag_id = t.id
+JOIN posts AS p ON p.id = pt.post_id
+WHERE p.visibility = ? AND p.draft = FALSE
+GROUP BY t.id, t.name
+ORDER BY count DESC, t.name`
+
+		var tagDTOs []TagDTO
+		if _, err := t.dbMap.Select(&tagDTOs, query, entity.PostVisibilityPublic); err != nil {
+			return nil, fmt.Errorf("failed TagRepository.GetAll: %w", err)
+		}
+
+		tags := make([]*entity.Tag, 0, len(tagDTOs))
+		for _, dto := range tagDTOs {
+			tags = append(tags, &entity.Tag{
+				Name:  dto.Name,
+				Count: dto.Count,
+			})
+		}
+		return tags, nil
+	}
+}
+
+// savePostTags は投稿についているタグをtagsで置き換えます
+// まだ存在しないタグはtagsテーブルに追加します
+func savePostTags(exec gorp.SqlExecutor, postID int, tags []string) error {
+	if _, err := exec.Exec("DELETE FROM post_tags WHERE post_id = ?", postID); err != nil {
+		return fmt.Errorf("failed to delete post tags: %w", err)
+	}
+	for _, tag := range tags {
+		if _, err := exec.Exec("INSERT INTO tags (name) VALUES (?) ON DUPLICATE KEY UPDATE id = id", tag); err != nil {
+			return fmt.Errorf("failed to insert tag: %w", err)
+		}
+		if _, err := exec.Exec(
+			"INSERT INTO post_tags (post_id, tag_id) SELECT ?, id FROM tags WHERE name = ?",
+			postID, tag,
+		); err != nil {
+			return fmt.Errorf("failed to insert post tag: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadPostTags は投稿についているタグをまとめて取得し，それぞれのTagsに名前順でセットします
+func loadPostTags(exec gorp.SqlExecutor, posts []*entity.Post) error {
+	if len(posts) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(posts))
+	args := make([]interface{}, 0, len(posts))
+	for _, post := range posts {
+		placeholders = append(placeholders, "?")
+		args = append(args, post.ID)
+	}
+	query := `SELECT pt.post_id, t.name
+FROM post_tags AS pt
+JOIN tags AS t ON t.id = pt.tag_id
+WHERE pt.post_id IN (` + strings.Join(placeholders, ", ") + `)
+ORDER BY t.name`
+
+	var postTagDTOs []PostTagDTO
+	if _, err := exec.Select(&postTagDTOs, query, args...); err != nil {
+		return fmt.Errorf("failed to select post tags: %w", err)
+	}
+
+	tagsByPostID := make(map[int][]string)
+	for _, dto := range postTagDTOs {
+		tagsByPostID[dto.PostID] = append(tagsByPostID[dto.PostID], dto.Name)
+	}
+	for _, post := range posts {
+		post.Tags = tagsByPostID[post.ID]
+	}
+	return nil
+}
+
+// TagDTO はタグとその使用数をDBから受け取るためのDataTransferObjectです
+// ref: migrations/20210407120000-CreateTags.sql
+type TagDTO struct {
+	Name  string `db:"name"`
+	Count int    `db:"count"`
+}
+
+// PostTagDTO は投稿についているタグをDBから受け取るためのDataTransferObjectです
+type PostTagDTO struct {
+	PostID int    `db:"post_id"`
+	Name   string `db:"name"`
+}
@@ -0,0 +1,62 @@
+package infra
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+func TestTagRepository_GetAll(t *testing.T) {
+	dbMap, err := NewDB()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dbMap.AddTableWithName(UserDTO{}, "users")
+	truncateTable(t, dbMap, "users")
+	if err := dbMap.Insert(&UserDTO{ID: "user-id", Name: "test user", TwitterID: "twitter"}); err != nil {
+		t.Fatal(err)
+	}
+
+	postRepo := NewPostRepository(dbMap)
+	tagRepo := NewTagRepository(dbMap)
+	ctx := context.Background()
+	truncateTable(t, dbMap, "posts")
+	truncateTable(t, dbMap, "post_tags")
+	truncateTable(t, dbMap, "tags")
+
+	// 使われていないタグは一覧に含まれないことを確かめるため，最後の投稿はタグを付け替える
+	for _, tags := range [][]string{{"go", "test"}, {"go"}, {"rust"}} {
+		post := &entity.Post{
+			UserID:   "user-id",
+			Title:    "test title",
+			Code:     "code",
+			Language: "Go",
+			Tags:     tags,
+		}
+		if err := postRepo.Insert(ctx, post); err != nil {
+			t.Fatal(err)
+		}
+		if tags[0] == "rust" {
+			post.Tags = []string{"python"}
+			if err := postRepo.Update(ctx, post); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	tags, err := tagRepo.GetAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*entity.Tag{
+		{Name: "go", Count: 2},
+		{Name: "python", Count: 1},
+		{Name: "test", Count: 1},
+	}
+	if diff := cmp.Diff(want, tags); diff != "" {
+		t.Errorf("Tags (-want +got) =\n%s\n", diff)
+	}
+}
@@ -27,6 +27,7 @@ func NewUserRepository(dbMap *gorp.DbMap) *UserRepository {
 }
 
 // FindByID は該当IDのユーザーの情報をDBから取得して返す
+// フォロワー数とフォロー数はセットしないので，プロフィールとして返す場合はFindProfileを使う
 func (r *UserRepository) FindByID(ctx context.Context, uid string) (user *entity.User, err error) {
 	select {
 	case <-ctx.Done():
@@ -51,6 +52,27 @@ func (r *UserRepository) FindByID(ctx context.Context, uid string) (user *entity
 	}
 }
 
+// FindProfile は該当IDのユーザーの情報をフォロワー数，フォロー数とともにDBから取得して返す
+func (r *UserRepository) FindProfile(ctx context.Context, uid string) (user *entity.User, err error) {
+	user, err = r.FindByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	var followerCount, followingCount int64
+	followerCount, err = r.dbMap.SelectInt("SELECT COUNT(*) FROM follows WHERE followee_id = ?", uid)
+	if err != nil {
+		return nil, err
+	}
+	followingCount, err = r.dbMap.SelectInt("SELECT COUNT(*) FROM follows WHERE follower_id = ?", uid)
+	if err != nil {
+		return nil, err
+	}
+	user.FollowerCount = int(followerCount)
+	user.FollowingCount = int(followingCount)
+	return
+}
+
 // Insert は該当ユーザーをDBに保存する
 func (r *UserRepository) Insert(ctx context.Context, user *entity.User) error {
 	select {
@@ -42,19 +42,45 @@ func main() {
 	userRepo := infra.NewUserRepository(dbMap)
 	postRepo := infra.NewPostRepository(dbMap)
 	commentRepo := infra.NewCommentRepository(dbMap)
+	searchRepo := infra.NewSearchRepository(dbMap)
+	tagRepo := infra.NewTagRepository(dbMap)
+	starRepo := infra.NewStarRepository(dbMap)
+	reactionRepo := infra.NewReactionRepository(dbMap)
+	followRepo := infra.NewFollowRepository(dbMap)
+	feedRepo := infra.NewFeedRepository(dbMap)
+	notificationRepo := infra.NewNotificationRepository(dbMap)
+	commentBroker := infra.NewInProcessCommentBroker()
 
 	authUseCase := usecase.NewAuthUseCase(authRepo)
 	authMiddleware := controller.NewAuthMiddleware(authUseCase)
 
-	userUseCase := usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo)
+	userUseCase := usecase.NewUserUseCase(userRepo, authRepo, postRepo, commentRepo, starRepo)
 	userController := controller.NewUserController(userUseCase)
 
-	postUsecase := usecase.NewPostUsecase(postRepo, userRepo)
+	postUsecase := usecase.NewPostUsecase(postRepo, userRepo, commentRepo, starRepo, notificationRepo)
 	postController := controller.NewPostController(postUsecase)
 
-	commentUseCase := usecase.NewCommentUseCase(commentRepo, postRepo, userRepo)
+	commentUseCase := usecase.NewCommentUseCase(commentRepo, postRepo, userRepo, notificationRepo, reactionRepo, commentBroker)
 	commentController := controller.NewCommentController(commentUseCase)
 
+	searchUseCase := usecase.NewSearchUseCase(searchRepo, starRepo)
+	searchController := controller.NewSearchController(searchUseCase)
+
+	tagUseCase := usecase.NewTagUseCase(tagRepo)
+	tagController := controller.NewTagController(tagUseCase)
+
+	languageUseCase := usecase.NewLanguageUseCase()
+	languageController := controller.NewLanguageController(languageUseCase)
+
+	followUseCase := usecase.NewFollowUseCase(followRepo, userRepo)
+	followController := controller.NewFollowController(followUseCase)
+
+	feedUseCase := usecase.NewFeedUseCase(feedRepo)
+	feedController := controller.NewFeedController(feedUseCase)
+
+	notificationUseCase := usecase.NewNotificationUseCase(notificationRepo)
+	notificationController := controller.NewNotificationController(notificationUseCase)
+
 	e := echo.New()
 	v1 := e.Group("/api/v1")
 
@@ -62,22 +88,54 @@ func main() {
 	user.GET("/:userID", userController.Get)
 	user.POST("", userController.Create, authMiddleware.Authenticate)
 	user.PUT("", userController.Update, authMiddleware.Authenticate)
-	user.GET("/:userID/post", userController.GetPosts)
-	user.GET("/:userID/comment", userController.GetComments)
+	user.GET("/:userID/post", userController.GetPosts, authMiddleware.OptionalAuthenticate)
+	user.GET("/:userID/comment", userController.GetComments, authMiddleware.OptionalAuthenticate)
+	user.GET("/:userID/starred", userController.GetStarredPosts, authMiddleware.OptionalAuthenticate)
+	user.PUT("/:userID/follow", followController.Follow, authMiddleware.Authenticate)
+	user.DELETE("/:userID/follow", followController.Unfollow, authMiddleware.Authenticate)
 
 	post := v1.Group("/post")
-	post.GET("", postController.GetAll) // 記事の閲覧はログインの必要なし
+	post.GET("", postController.GetAll, authMiddleware.OptionalAuthenticate) // 記事の閲覧はログインの必要なし
 	post.POST("", postController.Create, authMiddleware.Authenticate)
-	post.GET("/:postID", postController.Get)
+	post.GET("/:postID", postController.Get, authMiddleware.OptionalAuthenticate)
 	post.PUT("/:postID", postController.Update, authMiddleware.Authenticate)
 	post.DELETE("/:postID", postController.Delete, authMiddleware.Authenticate)
+	post.GET("/:postID/revision", postController.GetRevisions, authMiddleware.OptionalAuthenticate)
+	post.GET("/:postID/diff", postController.GetDiff, authMiddleware.OptionalAuthenticate)
+	post.PUT("/:postID/status", postController.ChangeStatus, authMiddleware.Authenticate)
+	post.POST("/:postID/publish", postController.Publish, authMiddleware.Authenticate)
+	post.GET("/:postID/fork", postController.GetForks, authMiddleware.OptionalAuthenticate)
+	post.POST("/:postID/fork", postController.Fork, authMiddleware.Authenticate)
+	post.PUT("/:postID/star", postController.Star, authMiddleware.Authenticate)
+	post.DELETE("/:postID/star", postController.Unstar, authMiddleware.Authenticate)
+	post.GET("/:postID/invitation", postController.GetInvitations, authMiddleware.Authenticate)
+	post.PUT("/:postID/invitation/:userID", postController.Invite, authMiddleware.Authenticate)
+	post.DELETE("/:postID/invitation/:userID", postController.Uninvite, authMiddleware.Authenticate)
 
 	comment := v1.Group("/post/:postID/comment")
-	comment.GET("", commentController.GetByPostID)
+	comment.GET("", commentController.GetByPostID, authMiddleware.OptionalAuthenticate)
 	comment.POST("", commentController.Create, authMiddleware.Authenticate)
-	comment.GET("/:commentID", commentController.Get)
+	comment.GET("/tree", commentController.GetTreeByPostID, authMiddleware.OptionalAuthenticate)
+	comment.GET("/stream", commentController.Stream, authMiddleware.OptionalAuthenticate)
+	comment.GET("/:commentID", commentController.Get, authMiddleware.OptionalAuthenticate)
 	comment.PUT("/:commentID", commentController.Update, authMiddleware.Authenticate)
 	comment.DELETE("/:commentID", commentController.Delete, authMiddleware.Authenticate)
+	comment.PUT("/:commentID/resolve", commentController.Resolve, authMiddleware.Authenticate)
+	comment.DELETE("/:commentID/resolve", commentController.Reopen, authMiddleware.Authenticate)
+	comment.PUT("/:commentID/accept", commentController.Accept, authMiddleware.Authenticate)
+	comment.DELETE("/:commentID/accept", commentController.Unaccept, authMiddleware.Authenticate)
+	comment.POST("/:commentID/apply", commentController.ApplySuggestion, authMiddleware.Authenticate)
+	comment.PUT("/:commentID/reaction/:emoji", commentController.React, authMiddleware.Authenticate)
+	comment.DELETE("/:commentID/reaction/:emoji", commentController.Unreact, authMiddleware.Authenticate)
+
+	v1.GET("/search", searchController.Search, authMiddleware.OptionalAuthenticate)
+	v1.GET("/tag", tagController.GetAll)
+	v1.GET("/language", languageController.GetAll)
+	v1.GET("/feed", feedController.Get, authMiddleware.Authenticate)
+
+	notification := v1.Group("/notification")
+	notification.GET("", notificationController.GetAll, authMiddleware.Authenticate)
+	notification.PUT("/:notificationID/read", notificationController.Read, authMiddleware.Authenticate)
 
 	// ref: https://echo.labstack.com/cookbook/graceful-shutdown
 	// Start server
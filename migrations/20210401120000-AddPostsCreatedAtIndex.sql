@@ -0,0 +1,6 @@
+
+-- +migrate Up
+-- 投稿一覧のページングで(created_at, id)の降順に走査するためのインデックス
+CREATE INDEX posts_created_at_id ON posts (created_at, id);
+-- +migrate Down
+DROP INDEX posts_created_at_id ON posts;
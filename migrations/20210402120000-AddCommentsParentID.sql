@@ -0,0 +1,13 @@
+
+-- +migrate Up
+-- 返信先のコメントは同じ投稿に属するので(parent_id, post_id)で参照する
+-- 返信がついたコメントは削除せずにdeletedを立てた墓標として残す
+ALTER TABLE comments
+    ADD COLUMN parent_id INTEGER AFTER user_id,
+    ADD COLUMN deleted   BOOLEAN NOT NULL DEFAULT FALSE AFTER code,
+    ADD CONSTRAINT comments_parent_id FOREIGN KEY (parent_id, post_id) REFERENCES comments (id, post_id) ON DELETE CASCADE;
+-- +migrate Down
+ALTER TABLE comments
+    DROP FOREIGN KEY comments_parent_id,
+    DROP COLUMN parent_id,
+    DROP COLUMN deleted;
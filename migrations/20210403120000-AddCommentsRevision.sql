@@ -0,0 +1,21 @@
+
+-- +migrate Up
+-- commitコメントに投稿ごとの連番でリビジョン番号を割り当てる
+-- 投稿時のコードがリビジョン1なので，commitコメントは2から始まる
+ALTER TABLE comments
+    ADD COLUMN revision INTEGER AFTER code,
+    ADD UNIQUE KEY comments_revision (post_id, revision);
+-- 既存のcommitコメントには作成順に番号を振る
+UPDATE comments AS c
+    JOIN (
+        SELECT id, post_id, ROW_NUMBER() OVER (PARTITION BY post_id ORDER BY id) + 1 AS revision
+        FROM comments
+        WHERE type = 'commit' AND deleted = FALSE
+    ) AS r ON c.id = r.id AND c.post_id = r.post_id
+    SET c.revision = r.revision;
+-- +migrate Down
+-- post_idの外部キーがcomments_revisionを使っている場合があるので先に代わりのインデックスを作る
+ALTER TABLE comments
+    ADD INDEX comments_post_id (post_id),
+    DROP INDEX comments_revision,
+    DROP COLUMN revision;
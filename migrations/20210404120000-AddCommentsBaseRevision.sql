@@ -0,0 +1,19 @@
+
+-- +migrate Up
+-- highlightコメントの行範囲がどのリビジョンのコードを指しているかを記録する
+ALTER TABLE comments
+    ADD COLUMN base_revision INTEGER AFTER revision;
+-- 既存のhighlightコメントは作成時点で最新だったリビジョンを指しているものとする
+UPDATE comments AS c
+    JOIN (
+        SELECT h.id, COALESCE(MAX(r.revision), 1) AS base_revision
+        FROM comments AS h
+        LEFT JOIN comments AS r
+            ON r.post_id = h.post_id AND r.revision IS NOT NULL AND r.created_at <= h.created_at
+        WHERE h.type = 'highlight'
+        GROUP BY h.id
+    ) AS b ON c.id = b.id
+    SET c.base_revision = b.base_revision;
+-- +migrate Down
+ALTER TABLE comments
+    DROP COLUMN base_revision;
@@ -0,0 +1,11 @@
+
+-- +migrate Up
+-- highlightコメントで行の一部をハイライトするための列を追加する
+-- 0なら行全体をハイライトする
+ALTER TABLE comments
+    ADD COLUMN first_column INTEGER NOT NULL DEFAULT 0 AFTER last_line,
+    ADD COLUMN last_column INTEGER NOT NULL DEFAULT 0 AFTER first_column;
+-- +migrate Down
+ALTER TABLE comments
+    DROP COLUMN first_column,
+    DROP COLUMN last_column;
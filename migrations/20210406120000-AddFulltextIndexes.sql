@@ -0,0 +1,13 @@
+
+-- +migrate Up
+-- 投稿とコメントの全文検索のためのインデックス
+-- 日本語は単語が空白で区切られないのでngramパーサを使う
+ALTER TABLE posts
+    ADD FULLTEXT INDEX posts_fulltext (title, content, code) WITH PARSER ngram;
+ALTER TABLE comments
+    ADD FULLTEXT INDEX comments_fulltext (content) WITH PARSER ngram;
+-- +migrate Down
+ALTER TABLE comments
+    DROP INDEX comments_fulltext;
+ALTER TABLE posts
+    DROP INDEX posts_fulltext;
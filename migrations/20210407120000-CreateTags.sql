@@ -0,0 +1,18 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS tags (
+    id   INTEGER     PRIMARY KEY AUTO_INCREMENT,
+    name VARCHAR(32) NOT NULL,
+    UNIQUE KEY tags_name (name)
+);
+CREATE TABLE IF NOT EXISTS post_tags (
+    post_id INTEGER NOT NULL,
+    tag_id  INTEGER NOT NULL,
+    PRIMARY KEY (post_id, tag_id),
+    INDEX post_tags_tag_id (tag_id),
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags (id) ON DELETE CASCADE
+);
+-- +migrate Down
+DROP TABLE IF EXISTS post_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,38 @@
+
+-- +migrate Up
+-- 言語の表記ゆれをdomain/entity/language.goの正規のIDに揃える
+-- 登録されていない言語はplaintextとして扱う．Downで元に戻せるように正規化する前の表記をpost_languages_backupに残す
+CREATE TABLE IF NOT EXISTS post_languages_backup (
+    post_id  INTEGER      PRIMARY KEY,
+    language VARCHAR(128) NOT NULL,
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE
+);
+INSERT INTO post_languages_backup (post_id, language) SELECT id, language FROM posts;
+UPDATE posts SET updated_at = updated_at, language = CASE
+    WHEN LOWER(TRIM(language)) IN ('c') THEN 'c'
+    WHEN LOWER(TRIM(language)) IN ('cpp', 'c++', 'cxx') THEN 'cpp'
+    WHEN LOWER(TRIM(language)) IN ('csharp', 'c#', 'cs') THEN 'csharp'
+    WHEN LOWER(TRIM(language)) IN ('css') THEN 'css'
+    WHEN LOWER(TRIM(language)) IN ('dart') THEN 'dart'
+    WHEN LOWER(TRIM(language)) IN ('go', 'golang') THEN 'go'
+    WHEN LOWER(TRIM(language)) IN ('haskell', 'hs') THEN 'haskell'
+    WHEN LOWER(TRIM(language)) IN ('html') THEN 'html'
+    WHEN LOWER(TRIM(language)) IN ('java') THEN 'java'
+    WHEN LOWER(TRIM(language)) IN ('javascript', 'js', 'node', 'nodejs') THEN 'javascript'
+    WHEN LOWER(TRIM(language)) IN ('kotlin', 'kt') THEN 'kotlin'
+    WHEN LOWER(TRIM(language)) IN ('php') THEN 'php'
+    WHEN LOWER(TRIM(language)) IN ('plaintext', 'plain text', 'text', 'txt', 'plain') THEN 'plaintext'
+    WHEN LOWER(TRIM(language)) IN ('python', 'py', 'python3') THEN 'python'
+    WHEN LOWER(TRIM(language)) IN ('ruby', 'rb') THEN 'ruby'
+    WHEN LOWER(TRIM(language)) IN ('rust', 'rs') THEN 'rust'
+    WHEN LOWER(TRIM(language)) IN ('scala') THEN 'scala'
+    WHEN LOWER(TRIM(language)) IN ('shell', 'sh', 'bash', 'zsh') THEN 'shell'
+    WHEN LOWER(TRIM(language)) IN ('sql', 'mysql') THEN 'sql'
+    WHEN LOWER(TRIM(language)) IN ('swift') THEN 'swift'
+    WHEN LOWER(TRIM(language)) IN ('typescript', 'ts') THEN 'typescript'
+    ELSE 'plaintext'
+END;
+-- +migrate Down
+-- 正規化した後に更新された投稿も含めて，正規化する前の表記に戻す
+UPDATE posts p JOIN post_languages_backup b ON p.id = b.post_id SET p.language = b.language, p.updated_at = p.updated_at;
+DROP TABLE IF EXISTS post_languages_backup;
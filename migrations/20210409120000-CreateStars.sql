@@ -0,0 +1,13 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS stars (
+    user_id    VARCHAR(128) NOT NULL,
+    post_id    INTEGER      NOT NULL,
+    created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, post_id),
+    INDEX stars_post_id (post_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE
+);
+-- +migrate Down
+DROP TABLE IF EXISTS stars;
@@ -0,0 +1,13 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS follows (
+    follower_id VARCHAR(128) NOT NULL,
+    followee_id VARCHAR(128) NOT NULL,
+    created_at  DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (follower_id, followee_id),
+    INDEX follows_followee_id (followee_id),
+    FOREIGN KEY (follower_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (followee_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +migrate Down
+DROP TABLE IF EXISTS follows;
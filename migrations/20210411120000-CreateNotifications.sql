@@ -0,0 +1,19 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS notifications (
+    id         INTEGER      NOT NULL AUTO_INCREMENT,
+    user_id    VARCHAR(128) NOT NULL,
+    actor_id   VARCHAR(128) NOT NULL,
+    type       ENUM('comment', 'highlight', 'reply', 'mention') NOT NULL,
+    post_id    INTEGER      NOT NULL,
+    comment_id INTEGER      NOT NULL,
+    is_read    BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (id),
+    INDEX notifications_user_id_created_at (user_id, created_at, id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id, post_id) REFERENCES comments (id, post_id) ON DELETE CASCADE
+);
+-- +migrate Down
+DROP TABLE IF EXISTS notifications;
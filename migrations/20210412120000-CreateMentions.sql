@@ -0,0 +1,26 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS mentions (
+    id         INTEGER      NOT NULL AUTO_INCREMENT,
+    post_id    INTEGER      NOT NULL,
+    comment_id INTEGER,
+    user_id    VARCHAR(128) NOT NULL,
+    PRIMARY KEY (id),
+    INDEX mentions_post_id_comment_id (post_id, comment_id),
+    INDEX mentions_comment_id (comment_id),
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id, post_id) REFERENCES comments (id, post_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- 投稿の本文でのメンションはコメントに紐づかないので，通知のcomment_idを省略できるようにする
+ALTER TABLE notifications MODIFY comment_id INTEGER;
+-- comment_idがNULLの通知はコメントの外部キーで投稿に紐づかないので，post_idにも外部キーを張る
+ALTER TABLE notifications
+    ADD INDEX notifications_post_id (post_id),
+    ADD CONSTRAINT notifications_post_id_fk FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE;
+-- +migrate Down
+ALTER TABLE notifications DROP FOREIGN KEY notifications_post_id_fk;
+ALTER TABLE notifications DROP INDEX notifications_post_id;
+DELETE FROM notifications WHERE comment_id IS NULL;
+ALTER TABLE notifications MODIFY comment_id INTEGER NOT NULL;
+DROP TABLE IF EXISTS mentions;
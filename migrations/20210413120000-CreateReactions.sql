@@ -0,0 +1,15 @@
+
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS reactions (
+    user_id    VARCHAR(128) NOT NULL,
+    post_id    INTEGER      NOT NULL,
+    comment_id INTEGER      NOT NULL,
+    emoji      VARCHAR(32)  NOT NULL,
+    created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, comment_id, emoji),
+    INDEX reactions_post_id_comment_id (post_id, comment_id),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (comment_id, post_id) REFERENCES comments (id, post_id) ON DELETE CASCADE
+);
+-- +migrate Down
+DROP TABLE IF EXISTS reactions;
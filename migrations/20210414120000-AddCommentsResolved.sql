@@ -0,0 +1,12 @@
+-- +migrate Up
+-- スレッドの最初のコメントを解決済みにしたユーザと日時を記録する
+-- resolved_atがNULLでなければ解決済みとする
+ALTER TABLE comments
+    ADD COLUMN resolved_by VARCHAR(128) AFTER base_revision,
+    ADD COLUMN resolved_at DATETIME AFTER resolved_by,
+    ADD CONSTRAINT comments_resolved_by FOREIGN KEY (resolved_by) REFERENCES users (id) ON DELETE SET NULL;
+-- +migrate Down
+ALTER TABLE comments
+    DROP FOREIGN KEY comments_resolved_by,
+    DROP COLUMN resolved_by,
+    DROP COLUMN resolved_at;
@@ -0,0 +1,9 @@
+-- +migrate Up
+-- 投稿の状態を記録する．既存の投稿はopenとする
+ALTER TABLE posts
+    ADD COLUMN status ENUM('open', 'in_review', 'resolved', 'archived') NOT NULL DEFAULT 'open' AFTER source,
+    ADD INDEX posts_status_created_at (status, created_at, id);
+-- +migrate Down
+ALTER TABLE posts
+    DROP INDEX posts_status_created_at,
+    DROP COLUMN status;
@@ -0,0 +1,12 @@
+-- +migrate Up
+-- 投稿のオーナーが回答として採用したコメントを記録する
+-- コメントは(id, post_id)で参照するので，削除されたときに両方をNULLにできるようaccepted_post_idも持つ
+ALTER TABLE posts
+    ADD COLUMN accepted_comment_id INTEGER AFTER status,
+    ADD COLUMN accepted_post_id    INTEGER AFTER accepted_comment_id,
+    ADD CONSTRAINT posts_accepted_comment FOREIGN KEY (accepted_comment_id, accepted_post_id) REFERENCES comments (id, post_id) ON DELETE SET NULL;
+-- +migrate Down
+ALTER TABLE posts
+    DROP FOREIGN KEY posts_accepted_comment,
+    DROP COLUMN accepted_comment_id,
+    DROP COLUMN accepted_post_id;
@@ -0,0 +1,16 @@
+-- +migrate Up
+-- 誰でも行範囲の書き換えを提案できるsuggestionコメントを追加する
+-- applied_revisionはsuggestionを適用して作られたリビジョン番号，author_idはsuggestionを適用したcommitで変更を提案したユーザ
+ALTER TABLE comments
+    MODIFY COLUMN type ENUM('highlight', 'commit', 'none', 'suggestion') DEFAULT('none') NOT NULL,
+    ADD COLUMN applied_revision INTEGER AFTER base_revision,
+    ADD COLUMN author_id VARCHAR(128) AFTER applied_revision,
+    ADD CONSTRAINT comments_author_id FOREIGN KEY (author_id) REFERENCES users (id) ON DELETE SET NULL;
+-- +migrate Down
+-- suggestionは行範囲を持たない通常のコメントとして残す
+UPDATE comments SET type = 'none' WHERE type = 'suggestion';
+ALTER TABLE comments
+    DROP FOREIGN KEY comments_author_id,
+    DROP COLUMN author_id,
+    DROP COLUMN applied_revision,
+    MODIFY COLUMN type ENUM('highlight', 'commit', 'none') DEFAULT('none') NOT NULL;
@@ -0,0 +1,20 @@
+-- +migrate Up
+-- 投稿の公開範囲を追加する．既存の投稿は全て公開されたものとして扱う
+ALTER TABLE posts
+    ADD COLUMN visibility ENUM('public', 'unlisted', 'private') NOT NULL DEFAULT 'public' AFTER status,
+    ADD INDEX posts_visibility_created_at (visibility, created_at, id);
+-- 非公開の投稿を閲覧できるように招待されたユーザ
+CREATE TABLE IF NOT EXISTS post_invitations (
+    post_id    INTEGER      NOT NULL,
+    user_id    VARCHAR(128) NOT NULL,
+    created_at DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (post_id, user_id),
+    INDEX post_invitations_user_id (user_id),
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+-- +migrate Down
+DROP TABLE IF EXISTS post_invitations;
+ALTER TABLE posts
+    DROP INDEX posts_visibility_created_at,
+    DROP COLUMN visibility;
@@ -0,0 +1,7 @@
+-- +migrate Up
+-- 下書きの投稿はオーナー以外には公開しない．既存の投稿は全て公開済みとして扱う
+ALTER TABLE posts
+    ADD COLUMN draft BOOLEAN NOT NULL DEFAULT FALSE AFTER visibility;
+-- +migrate Down
+ALTER TABLE posts
+    DROP COLUMN draft;
@@ -0,0 +1,9 @@
+-- +migrate Up
+-- フォークした投稿がフォーク元の投稿を記録する．フォーク元が削除されてもフォークした投稿は残す
+ALTER TABLE posts
+    ADD COLUMN forked_from INTEGER AFTER draft,
+    ADD CONSTRAINT posts_forked_from FOREIGN KEY (forked_from) REFERENCES posts (id) ON DELETE SET NULL;
+-- +migrate Down
+ALTER TABLE posts
+    DROP FOREIGN KEY posts_forked_from,
+    DROP COLUMN forked_from;
@@ -0,0 +1,14 @@
+-- +migrate Up
+-- 複数のファイルからなる投稿のファイルをpositionの順に記録する．先頭のファイルの内容と言語はposts.code, posts.languageにも保存する
+CREATE TABLE IF NOT EXISTS post_files (
+    post_id  INTEGER      NOT NULL,
+    position INTEGER      NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    language VARCHAR(128) NOT NULL,
+    content  TEXT         NOT NULL,
+    PRIMARY KEY (post_id, position),
+    UNIQUE KEY post_files_filename (post_id, filename),
+    FOREIGN KEY (post_id) REFERENCES posts (id) ON DELETE CASCADE
+);
+-- +migrate Down
+DROP TABLE IF EXISTS post_files;
@@ -0,0 +1,7 @@
+-- +migrate Up
+-- highlight, suggestion, commitコメントが指す投稿のファイル名．単一のファイルの投稿では空文字列
+ALTER TABLE comments
+    ADD COLUMN filename VARCHAR(255) NOT NULL DEFAULT '' AFTER type;
+-- +migrate Down
+ALTER TABLE comments
+    DROP COLUMN filename;
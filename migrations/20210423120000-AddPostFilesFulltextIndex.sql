@@ -0,0 +1,8 @@
+-- +migrate Up
+-- 複数のファイルからなる投稿の2つ目以降のファイルも全文検索できるようにする
+-- posts_fulltextと同じく日本語のためにngramパーサを使う
+ALTER TABLE post_files
+    ADD FULLTEXT INDEX post_files_fulltext (content) WITH PARSER ngram;
+-- +migrate Down
+ALTER TABLE post_files
+    DROP INDEX post_files_fulltext;
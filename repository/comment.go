@@ -16,4 +16,7 @@ type Comment interface {
 	Insert(ctx context.Context, comment *entity.Comment) error
 	Update(ctx context.Context, comment *entity.Comment) error
 	Delete(ctx context.Context, comment *entity.Comment) error
+	Resolve(ctx context.Context, postID, commentID int, resolvedBy string) error
+	Reopen(ctx context.Context, postID, commentID int) error
+	ApplySuggestion(ctx context.Context, suggestion, commit *entity.Comment) error
 }
@@ -0,0 +1,19 @@
+//go:generate mockgen -source=$GOFILE -destination=../infra/mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// CommentBroker はコメントの変更イベントを投稿ごとの購読者に配信するためのブローカーです
+// 複数のインスタンスで動かす場合は，インスタンス間で配信できる実装に差し替えます
+type CommentBroker interface {
+	// Publish はイベントをその投稿の購読者全員に配信します
+	Publish(ctx context.Context, event *entity.CommentEvent) error
+	// Subscribe は投稿のイベントを受け取るチャネルを返します
+	// ctxが終了すると購読が解除され，チャネルが閉じられます
+	Subscribe(ctx context.Context, postID int) (<-chan *entity.CommentEvent, error)
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source=$GOFILE -destination=../infra/mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// Feed はフォローしているユーザの活動をまとめたフィードを再構成するためのリポジトリです
+type Feed interface {
+	FindByFollowerID(ctx context.Context, uid string, cursor *entity.FeedCursor, limit int) ([]*entity.FeedItem, error)
+}
@@ -0,0 +1,15 @@
+//go:generate mockgen -source=$GOFILE -destination=../infra/mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// Follow はユーザのフォロー関係に関する永続化のためのリポジトリです
+type Follow interface {
+	Insert(ctx context.Context, follow *entity.Follow) error
+	Delete(ctx context.Context, follow *entity.Follow) error
+}
@@ -0,0 +1,18 @@
+//go:generate mockgen -source=$GOFILE -destination=../infra/mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// Notification はユーザへの通知の永続化と再構成のためのリポジトリです
+type Notification interface {
+	FindByID(ctx context.Context, id int) (*entity.Notification, error)
+	FindByUserID(ctx context.Context, uid string, cursor *entity.Cursor, limit int) ([]*entity.Notification, error)
+	CountUnread(ctx context.Context, uid string) (int, error)
+	Insert(ctx context.Context, notifications []*entity.Notification) error
+	MarkRead(ctx context.Context, notification *entity.Notification) error
+}
@@ -10,10 +10,18 @@ import (
 
 // Post は投稿に関する永続化と再構成のためのリポジトリです
 type Post interface {
-	GetAll(ctx context.Context) ([]*entity.Post, error)
+	GetAll(ctx context.Context, filter *entity.PostFilter, cursor *entity.Cursor, limit int) ([]*entity.Post, error)
 	FindByID(ctx context.Context, postID int) (*entity.Post, error)
-	FindByUserID(ctx context.Context, uid string) ([]*entity.Post, error)
+	FindByUserID(ctx context.Context, uid string, includeHidden bool, cursor *entity.Cursor, limit int) ([]*entity.Post, error)
 	Insert(ctx context.Context, post *entity.Post) error
 	Update(ctx context.Context, post *entity.Post) error
+	UpdateStatus(ctx context.Context, postID int, status string) error
+	Publish(ctx context.Context, postID int) error
+	FindForks(ctx context.Context, postID int, cursor *entity.Cursor, limit int) ([]*entity.Post, error)
+	UpdateAcceptedComment(ctx context.Context, postID, commentID int) error
 	Delete(ctx context.Context, post *entity.Post) error
+	Invite(ctx context.Context, postID int, userID string) error
+	Uninvite(ctx context.Context, postID int, userID string) error
+	FindInvitedUserIDs(ctx context.Context, postID int) ([]string, error)
+	IsInvited(ctx context.Context, postID int, userID string) (bool, error)
 }
@@ -0,0 +1,15 @@
+//go:generate mockgen -source=$GOFILE -destination=../infra/mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// Reaction はコメントへのリアクションに関する永続化と再構成のためのリポジトリです
+type Reaction interface {
+	Insert(ctx context.Context, reaction *entity.Reaction) error
+	Delete(ctx context.Context, reaction *entity.Reaction) error
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -destination=../infra/mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// Search は投稿とコメントの全文検索のためのリポジトリです
+type Search interface {
+	// SearchPosts は投稿のタイトル，本文，コードとコメントの本文からキーワードを検索し，
+	// ヒットした投稿を関連度の高い順にquery.Offset件読み飛ばしてquery.Limit件まで返します
+	SearchPosts(ctx context.Context, query *entity.SearchQuery) ([]*entity.SearchResult, error)
+}
@@ -0,0 +1,16 @@
+//go:generate mockgen -source=$GOFILE -destination=../infra/mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// Star は投稿へのスターに関する永続化と再構成のためのリポジトリです
+type Star interface {
+	Insert(ctx context.Context, star *entity.Star) error
+	Delete(ctx context.Context, star *entity.Star) error
+	FindStarredPostIDs(ctx context.Context, userID string, postIDs []int) ([]int, error)
+}
@@ -0,0 +1,14 @@
+//go:generate mockgen -source=$GOFILE -destination=../infra/mock/mock_$GOFILE -package=mock
+
+package repository
+
+import (
+	"context"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// Tag は投稿につけられたタグを再構成するためのリポジトリです
+type Tag interface {
+	GetAll(ctx context.Context) ([]*entity.Tag, error)
+}
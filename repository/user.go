@@ -11,6 +11,7 @@ import (
 // User はユーザに関する永続化と再構成のためのリポジトリです
 type User interface {
 	FindByID(ctx context.Context, uid string) (user *entity.User, err error)
+	FindProfile(ctx context.Context, uid string) (user *entity.User, err error)
 	Insert(ctx context.Context, user *entity.User) error
 	Update(ctx context.Context, user *entity.User) error
 }
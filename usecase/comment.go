@@ -2,60 +2,130 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/log"
 	"github.com/openhacku-saboten/OmnisCode-backend/repository"
 )
 
 // CommentUseCase はコメントに関するユースケースです
 type CommentUseCase struct {
-	commentRepo repository.Comment
-	postRepo    repository.Post
-	userRepo    repository.User
+	commentRepo      repository.Comment
+	postRepo         repository.Post
+	userRepo         repository.User
+	notificationRepo repository.Notification
+	reactionRepo     repository.Reaction
+	commentBroker    repository.CommentBroker
 }
 
 // NewCommentUseCase はCommentUseCaseのポインタを生成する関数です
-func NewCommentUseCase(comment repository.Comment, post repository.Post, user repository.User) *CommentUseCase {
-	return &CommentUseCase{commentRepo: comment, postRepo: post, userRepo: user}
+func NewCommentUseCase(comment repository.Comment, post repository.Post, user repository.User, notification repository.Notification, reaction repository.Reaction, broker repository.CommentBroker) *CommentUseCase {
+	return &CommentUseCase{commentRepo: comment, postRepo: post, userRepo: user, notificationRepo: notification, reactionRepo: reaction, commentBroker: broker}
 }
 
 // Get は引数のpostIDとcommentIDの両方を満たすコメントを1つ取得します
-func (u *CommentUseCase) Get(ctx context.Context, postID, commentID int) (comment *entity.Comment, err error) {
+// viewerIDのユーザが閲覧できない非公開の投稿のコメントはErrNotFoundを返します．閲覧しているユーザが編集できるかをセットします
+func (u *CommentUseCase) Get(ctx context.Context, viewerID string, postID, commentID int) (comment *entity.Comment, err error) {
+	if _, err := findVisiblePost(ctx, u.postRepo, postID, viewerID); err != nil {
+		return nil, fmt.Errorf("not found post %d in DB: %w", postID, err)
+	}
 	comment, err = u.commentRepo.FindByID(ctx, postID, commentID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to Get comment from DB: %w", err)
 	}
+	personalizeComments(viewerID, []*entity.Comment{comment})
 	return
 }
 
 // GetByPostID は引数のpostIDを満たす投稿にぶら下がるコメントを全て取得します
-func (u *CommentUseCase) GetByPostID(ctx context.Context, postID int) (comments []*entity.Comment, err error) {
+// highlightコメントの行範囲はrevisionで指定したリビジョン(0なら最新のリビジョン)のコードに投影されます
+// resolvedがnilでなければ，スレッドが解決済みかどうかで絞り込みます
+// viewerIDのユーザが閲覧できない非公開の投稿はErrNotFoundを返します．閲覧しているユーザが編集できるかをセットします
+func (u *CommentUseCase) GetByPostID(ctx context.Context, viewerID string, postID, revision int, resolved *bool) (comments []*entity.Comment, err error) {
+	post, err := findVisiblePost(ctx, u.postRepo, postID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("not found post %d in DB: %w", postID, err)
+	}
 	comments, err = u.commentRepo.FindByPostID(ctx, postID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to GetByPostID from DB: %w", err)
 	}
-	return
+	// リビジョンを組み立てるために，絞り込む前の全てのコメントで投影する
+	if err := projectHighlights(post, comments, revision); err != nil {
+		return nil, fmt.Errorf("failed to GetByPostID: %w", err)
+	}
+	personalizeComments(viewerID, comments)
+	if resolved != nil {
+		comments = entity.FilterCommentsByResolved(comments, *resolved)
+	}
+	return entity.PinAcceptedComment(comments, post.AcceptedCommentID), nil
+}
+
+// GetTreeByPostID は引数のpostIDを満たす投稿にぶら下がるコメントを返信のツリーとして取得します
+// highlightコメントの行範囲はrevisionで指定したリビジョン(0なら最新のリビジョン)のコードに投影されます
+// viewerIDのユーザが閲覧できない非公開の投稿はErrNotFoundを返します．閲覧しているユーザが編集できるかをセットします
+func (u *CommentUseCase) GetTreeByPostID(ctx context.Context, viewerID string, postID, revision int) ([]*entity.CommentNode, error) {
+	post, err := findVisiblePost(ctx, u.postRepo, postID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("not found post %d in DB: %w", postID, err)
+	}
+	comments, err := u.commentRepo.FindByPostID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetTreeByPostID from DB: %w", err)
+	}
+	if err := projectHighlights(post, comments, revision); err != nil {
+		return nil, fmt.Errorf("failed to GetTreeByPostID: %w", err)
+	}
+	personalizeComments(viewerID, comments)
+	return entity.NewCommentTree(comments), nil
 }
 
 // Create は引数のcommentエンティティをもとにコメントを1つ生成します
 func (u *CommentUseCase) Create(ctx context.Context, comment *entity.Comment) error {
-	// Postのオーナー以外によるcommitを弾く
-	post, err := u.postRepo.FindByID(ctx, comment.PostID)
+	// Postのオーナー以外によるcommitを弾く．閲覧できない非公開の投稿にはコメントできない
+	post, err := findVisiblePost(ctx, u.postRepo, comment.PostID, comment.UserID)
 	if err != nil {
 		return fmt.Errorf("not found post %d in DB: %w", comment.PostID, err)
 	}
+	// アーカイブされた投稿には新しくコメントできない
+	if post.Status == entity.PostStatusArchived {
+		return entity.ErrPostArchived
+	}
 	if comment.Type == "commit" && comment.UserID != post.UserID {
 		return entity.ErrCannotCommit
 	}
+	// 返信先のコメントは同じ投稿に存在し，削除されていないものに限る
+	var parent *entity.Comment
+	if comment.ParentID != 0 {
+		parent, err = u.commentRepo.FindByID(ctx, comment.PostID, comment.ParentID)
+		if err != nil {
+			return fmt.Errorf("not found parent comment %d in DB: %w", comment.ParentID, err)
+		}
+		if parent.Deleted {
+			return entity.ErrInvalidParentComment
+		}
+	}
+	if err := u.validateFileReference(ctx, post, comment); err != nil {
+		return fmt.Errorf("invalid %s: %w", comment.Type, err)
+	}
+	if comment.Mentions, err = resolveMentions(ctx, u.userRepo, comment.Content); err != nil {
+		return fmt.Errorf("failed to resolve mentions: %w", err)
+	}
 
 	if err := u.commentRepo.Insert(ctx, comment); err != nil {
 		return fmt.Errorf("failed to Insert Comment into DB: %w", err)
 	}
+
+	u.notify(ctx, post, parent, comment)
+	u.publish(ctx, entity.CommentEventCreated, comment)
 	return nil
 }
 
 // Update は引数のCommentエンティティをもとにコメントを1つ更新します
+// コメントした人が閲覧できなくなった非公開の投稿のコメントは更新できません
 func (u *CommentUseCase) Update(ctx context.Context, comment *entity.Comment) error {
 	// Userが存在しない場合は弾く
 	if _, err := u.userRepo.FindByID(ctx, comment.UserID); err != nil {
@@ -63,24 +133,352 @@ func (u *CommentUseCase) Update(ctx context.Context, comment *entity.Comment) er
 	}
 
 	// Postのオーナー以外によるcommitを弾く
-	post, err := u.postRepo.FindByID(ctx, comment.PostID)
+	post, err := findVisiblePost(ctx, u.postRepo, comment.PostID, comment.UserID)
 	if err != nil {
 		return fmt.Errorf("not found post %d in DB: %w", comment.PostID, err)
 	}
 	if comment.Type == "commit" && comment.UserID != post.UserID {
 		return entity.ErrCannotCommit
 	}
+	// 指すリビジョンやファイルが指定されなければ更新前のものを引き継ぐ．ファイル名を持つのは複数のファイルからなる投稿だけ
+	inheritsBase := comment.HasLineRange() && comment.BaseRevision == 0
+	inheritsFile := comment.ReferencesFile() && len(comment.Filename) == 0 && len(post.Files) > 0
+	if inheritsBase || inheritsFile {
+		stored, err := u.commentRepo.FindByID(ctx, comment.PostID, comment.ID)
+		if err != nil {
+			return fmt.Errorf("not found comment %d in DB: %w", comment.ID, err)
+		}
+		if inheritsBase && stored.HasLineRange() {
+			comment.BaseRevision = stored.BaseRevision
+		}
+		if inheritsFile && stored.ReferencesFile() {
+			comment.Filename = stored.Filename
+		}
+	}
+	if err := u.validateFileReference(ctx, post, comment); err != nil {
+		return fmt.Errorf("invalid %s: %w", comment.Type, err)
+	}
+	if comment.Mentions, err = resolveMentions(ctx, u.userRepo, comment.Content); err != nil {
+		return fmt.Errorf("failed to resolve mentions: %w", err)
+	}
+	// 更新前からメンションされていたユーザには改めて通知しない
+	var previousMentions []*entity.Mention
+	if len(comment.Mentions) > 0 {
+		stored, err := u.commentRepo.FindByID(ctx, comment.PostID, comment.ID)
+		if err != nil {
+			return fmt.Errorf("not found comment %d in DB: %w", comment.ID, err)
+		}
+		previousMentions = stored.Mentions
+	}
 
 	if err := u.commentRepo.Update(ctx, comment); err != nil {
 		return fmt.Errorf("failed to Insert Comment into DB: %w", err)
 	}
+	notifyMentions(ctx, u.postRepo, u.notificationRepo, post, service.NewMentionNotifications(comment.UserID, comment.PostID, comment.ID, comment.Mentions, previousMentions))
+	u.publish(ctx, entity.CommentEventUpdated, comment)
 	return nil
 }
 
 // Delete はコメントを削除します
+// 返信がついているコメントとcommitコメントは墓標として残ります
 func (u *CommentUseCase) Delete(ctx context.Context, comment *entity.Comment) error {
 	if err := u.commentRepo.Delete(ctx, comment); err != nil {
 		return fmt.Errorf("failed to Delete Comment into DB: %w", err)
 	}
+	u.publish(ctx, entity.CommentEventDeleted, comment)
+	return nil
+}
+
+// React はユーザがコメントにリアクションをつけます
+// 既に同じリアクションをつけている場合は何もしません．墓標になったコメントや閲覧できない非公開の投稿のコメントにはつけられません
+func (u *CommentUseCase) React(ctx context.Context, reaction *entity.Reaction) error {
+	if !entity.IsReactionEmoji(reaction.Emoji) {
+		return entity.ErrUnknownReaction
+	}
+	if _, err := findVisiblePost(ctx, u.postRepo, reaction.PostID, reaction.UserID); err != nil {
+		return fmt.Errorf("not found post %d in DB: %w", reaction.PostID, err)
+	}
+	comment, err := u.commentRepo.FindByID(ctx, reaction.PostID, reaction.CommentID)
+	if err != nil {
+		return fmt.Errorf("not found comment %d in DB: %w", reaction.CommentID, err)
+	}
+	if comment.Deleted {
+		return entity.NewErrorNotFound("comment")
+	}
+	if err := u.reactionRepo.Insert(ctx, reaction); err != nil {
+		return fmt.Errorf("failed to Insert Reaction into DB: %w", err)
+	}
+	return nil
+}
+
+// Unreact はユーザがコメントにつけたリアクションを外します
+// リアクションをつけていない場合は何もしません．閲覧できない非公開の投稿のコメントからは外せません
+func (u *CommentUseCase) Unreact(ctx context.Context, reaction *entity.Reaction) error {
+	if !entity.IsReactionEmoji(reaction.Emoji) {
+		return entity.ErrUnknownReaction
+	}
+	if _, err := findVisiblePost(ctx, u.postRepo, reaction.PostID, reaction.UserID); err != nil {
+		return fmt.Errorf("not found post %d in DB: %w", reaction.PostID, err)
+	}
+	if _, err := u.commentRepo.FindByID(ctx, reaction.PostID, reaction.CommentID); err != nil {
+		return fmt.Errorf("not found comment %d in DB: %w", reaction.CommentID, err)
+	}
+	if err := u.reactionRepo.Delete(ctx, reaction); err != nil {
+		return fmt.Errorf("failed to Delete Reaction from DB: %w", err)
+	}
+	return nil
+}
+
+// Resolve はスレッドの最初のコメントを解決済みにします
+// 解決済みにできるのは投稿を閲覧できるユーザのうち，投稿のオーナーかコメントした人だけです
+func (u *CommentUseCase) Resolve(ctx context.Context, postID, commentID int, userID string) error {
+	if err := u.authorizeResolution(ctx, postID, commentID, userID); err != nil {
+		return fmt.Errorf("failed to Resolve comment: %w", err)
+	}
+	if err := u.commentRepo.Resolve(ctx, postID, commentID, userID); err != nil {
+		return fmt.Errorf("failed to Resolve comment in DB: %w", err)
+	}
+	u.publishStored(ctx, postID, commentID)
+	return nil
+}
+
+// Reopen は解決済みのスレッドを未解決に戻します
+// 未解決に戻せるのは投稿を閲覧できるユーザのうち，投稿のオーナーかコメントした人だけです
+func (u *CommentUseCase) Reopen(ctx context.Context, postID, commentID int, userID string) error {
+	if err := u.authorizeResolution(ctx, postID, commentID, userID); err != nil {
+		return fmt.Errorf("failed to Reopen comment: %w", err)
+	}
+	if err := u.commentRepo.Reopen(ctx, postID, commentID); err != nil {
+		return fmt.Errorf("failed to Reopen comment in DB: %w", err)
+	}
+	u.publishStored(ctx, postID, commentID)
+	return nil
+}
+
+// authorizeResolution はユーザがスレッドを解決済みにしたり未解決に戻したりできるかを検証します
+// 閲覧できない非公開の投稿のコメントと，削除されたコメントは存在しないものとして扱います
+func (u *CommentUseCase) authorizeResolution(ctx context.Context, postID, commentID int, userID string) error {
+	post, err := findVisiblePost(ctx, u.postRepo, postID, userID)
+	if err != nil {
+		return fmt.Errorf("not found post %d in DB: %w", postID, err)
+	}
+	comment, err := u.commentRepo.FindByID(ctx, postID, commentID)
+	if err != nil {
+		return fmt.Errorf("not found comment %d in DB: %w", commentID, err)
+	}
+	if comment.Deleted {
+		return entity.NewErrorNotFound("comment")
+	}
+	if comment.ParentID != 0 {
+		return entity.ErrCannotResolveReply
+	}
+	if comment.UserID == userID {
+		return nil
+	}
+	if post.UserID != userID {
+		return entity.ErrCannotResolve
+	}
+	return nil
+}
+
+// ApplySuggestion は投稿のオーナーがsuggestionコメントを適用し，提案された行を最新のコードに反映したcommitコメントを作成します
+// commitコメントはsuggestionコメントへの返信になり，変更を提案したユーザがAuthorIDとして記録されます
+func (u *CommentUseCase) ApplySuggestion(ctx context.Context, postID, commentID int, userID string) (*entity.Comment, error) {
+	post, err := u.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("not found post %d in DB: %w", postID, err)
+	}
+	if post.UserID != userID {
+		return nil, entity.ErrCannotApplySuggestion
+	}
+	if post.Status == entity.PostStatusArchived {
+		return nil, entity.ErrPostArchived
+	}
+
+	suggestion, err := u.commentRepo.FindByID(ctx, postID, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("not found comment %d in DB: %w", commentID, err)
+	}
+	if suggestion.Deleted {
+		return nil, entity.NewErrorNotFound("comment")
+	}
+	if suggestion.Type != "suggestion" {
+		return nil, entity.ErrNotSuggestion
+	}
+	if suggestion.AppliedRevision != 0 {
+		return nil, entity.ErrSuggestionApplied
+	}
+
+	comments, err := u.commentRepo.FindByPostID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+	code, err := service.ApplySuggestion(suggestion, entity.NewRevisions(post, comments))
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply suggestion %d: %w", commentID, err)
+	}
+
+	commit := &entity.Comment{
+		UserID:   post.UserID,
+		PostID:   postID,
+		ParentID: suggestion.ID,
+		Type:     "commit",
+		Filename: suggestion.Filename,
+		Code:     code,
+		AuthorID: suggestion.UserID,
+	}
+	if err := u.commentRepo.ApplySuggestion(ctx, suggestion, commit); err != nil {
+		return nil, fmt.Errorf("failed to ApplySuggestion in DB: %w", err)
+	}
+
+	u.notify(ctx, post, suggestion, commit)
+	u.publish(ctx, entity.CommentEventCreated, commit)
+	u.publishStored(ctx, postID, suggestion.ID)
+	return commit, nil
+}
+
+// Accept は投稿のオーナーがコメントを回答として採用します
+// 採用できるコメントは投稿ごとに1つだけで，既に採用しているコメントがあれば置き換えます
+func (u *CommentUseCase) Accept(ctx context.Context, postID, commentID int, userID string) error {
+	if _, err := u.authorizeAcceptance(ctx, postID, userID); err != nil {
+		return fmt.Errorf("failed to Accept comment: %w", err)
+	}
+	comment, err := u.commentRepo.FindByID(ctx, postID, commentID)
+	if err != nil {
+		return fmt.Errorf("not found comment %d in DB: %w", commentID, err)
+	}
+	if comment.Deleted {
+		return entity.NewErrorNotFound("comment")
+	}
+	if err := u.postRepo.UpdateAcceptedComment(ctx, postID, commentID); err != nil {
+		return fmt.Errorf("failed to Accept comment in DB: %w", err)
+	}
+	return nil
+}
+
+// Unaccept は投稿のオーナーがコメントの採用を取り消します
+// そのコメントを採用していない場合は何もしません
+func (u *CommentUseCase) Unaccept(ctx context.Context, postID, commentID int, userID string) error {
+	post, err := u.authorizeAcceptance(ctx, postID, userID)
+	if err != nil {
+		return fmt.Errorf("failed to Unaccept comment: %w", err)
+	}
+	if post.AcceptedCommentID != commentID {
+		return nil
+	}
+	if err := u.postRepo.UpdateAcceptedComment(ctx, postID, 0); err != nil {
+		return fmt.Errorf("failed to Unaccept comment in DB: %w", err)
+	}
+	return nil
+}
+
+// authorizeAcceptance はユーザが投稿のオーナーとしてコメントの採用を変えられるかを検証し，投稿を返します
+func (u *CommentUseCase) authorizeAcceptance(ctx context.Context, postID int, userID string) (*entity.Post, error) {
+	post, err := u.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("not found post %d in DB: %w", postID, err)
+	}
+	if post.UserID != userID {
+		return nil, entity.ErrIsNotAuthor
+	}
+	return post, nil
+}
+
+// Subscribe は投稿のコメントの変更イベントを受け取るチャネルを返します
+// ctxが終了すると購読が解除され，チャネルが閉じられます．viewerIDのユーザが閲覧できない非公開の投稿は購読できません
+func (u *CommentUseCase) Subscribe(ctx context.Context, viewerID string, postID int) (<-chan *entity.CommentEvent, error) {
+	if _, err := findVisiblePost(ctx, u.postRepo, postID, viewerID); err != nil {
+		return nil, fmt.Errorf("not found post %d in DB: %w", postID, err)
+	}
+	events, err := u.commentBroker.Subscribe(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to Subscribe comment events: %w", err)
+	}
+	return events, nil
+}
+
+// publish はコメントの変更イベントを購読者に配信します
+// 配信に失敗してもコメントの変更自体は完了しているので，エラーは記録するだけにする
+func (u *CommentUseCase) publish(ctx context.Context, eventType string, comment *entity.Comment) {
+	if err := u.commentBroker.Publish(ctx, entity.NewCommentEvent(eventType, comment)); err != nil {
+		log.New().Errorf("failed to publish %s event of comment %d: %s", eventType, comment.ID, err.Error())
+	}
+}
+
+// publishStored はDBに保存されているコメントを取得し直して，更新イベントとして配信します
+// 取得や配信に失敗しても変更自体は完了しているので，エラーは記録するだけにする
+func (u *CommentUseCase) publishStored(ctx context.Context, postID, commentID int) {
+	comment, err := u.commentRepo.FindByID(ctx, postID, commentID)
+	if err != nil {
+		log.New().Errorf("failed to get comment %d to publish: %s", commentID, err.Error())
+		return
+	}
+	u.publish(ctx, entity.CommentEventUpdated, comment)
+}
+
+// notify は作成されたコメントについて，投稿のオーナー，返信先のコメントの作者，メンションされたユーザに通知します
+// 投稿を閲覧できないユーザには通知しません
+// 通知に失敗してもコメント自体は作成されているので，エラーは記録するだけにする
+func (u *CommentUseCase) notify(ctx context.Context, post *entity.Post, parent, comment *entity.Comment) {
+	notifications, err := filterVisibleNotifications(ctx, u.postRepo, post,
+		service.NewCommentNotifications(post, parent, comment, entity.MentionUserIDs(comment.Mentions)))
+	if err != nil {
+		log.New().Errorf("failed to notify comment %d: %s", comment.ID, err.Error())
+		return
+	}
+	if len(notifications) == 0 {
+		return
+	}
+	if err := u.notificationRepo.Insert(ctx, notifications); err != nil {
+		log.New().Errorf("failed to notify comment %d: %s", comment.ID, err.Error())
+	}
+}
+
+// projectHighlights は投稿のhighlightコメントの行範囲を指定したリビジョンのコードに投影します
+// commentsは投稿に属する全てのコメントである必要があります
+func projectHighlights(post *entity.Post, comments []*entity.Comment, revision int) error {
+	revisions := entity.NewRevisions(post, comments)
+	target := entity.LatestRevision(revisions)
+	if revision != 0 {
+		var err error
+		if target, err = entity.FindRevision(revisions, revision); err != nil {
+			return err
+		}
+	}
+	service.ProjectHighlights(comments, revisions, target)
 	return nil
 }
+
+// validateFileReference はhighlight, suggestion, commitコメントが指しているファイルがリビジョンに存在するかを検証します
+// highlight, suggestionコメントはBaseRevisionのリビジョンのファイルを指し，範囲がそのコードに収まっているかも検証します
+// BaseRevisionが未設定なら最新のリビジョンを，複数のファイルからなる投稿でFilenameが未設定なら先頭のファイルを指すものとしてセットします
+func (u *CommentUseCase) validateFileReference(ctx context.Context, post *entity.Post, comment *entity.Comment) error {
+	if !comment.ReferencesFile() {
+		return nil
+	}
+	comments, err := u.commentRepo.FindByPostID(ctx, post.ID)
+	if err != nil {
+		// コメントが1つもない場合は投稿時のリビジョンのみ
+		errNF := &entity.ErrNotFound{}
+		if !errors.As(err, errNF) {
+			return fmt.Errorf("failed to get comments: %w", err)
+		}
+	}
+
+	revisions := entity.NewRevisions(post, comments)
+	base := entity.LatestRevision(revisions)
+	if comment.HasLineRange() && comment.BaseRevision != 0 {
+		if base, err = entity.FindRevision(revisions, comment.BaseRevision); err != nil {
+			return err
+		}
+	}
+	if comment.Filename, err = base.ResolveFilename(comment.Filename); err != nil {
+		return err
+	}
+	if !comment.HasLineRange() {
+		return nil
+	}
+	comment.BaseRevision = base.Number
+	code, _ := base.FileCode(comment.Filename)
+	return service.ValidateHighlightRange(comment, code)
+}
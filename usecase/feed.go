@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+// FeedUseCase はフォローしているユーザの活動をまとめたフィードに関するユースケースです
+type FeedUseCase struct {
+	feedRepo repository.Feed
+}
+
+// NewFeedUseCase はFeedUseCaseのポインタを生成する関数です
+func NewFeedUseCase(feedRepo repository.Feed) *FeedUseCase {
+	return &FeedUseCase{feedRepo: feedRepo}
+}
+
+// Get はuidのユーザのフィードをcursorの位置から新しい順に1ページ分取得します
+func (u *FeedUseCase) Get(ctx context.Context, uid string, cursor *entity.FeedCursor, limit int) (*entity.FeedPage, error) {
+	limit = entity.NormalizePageLimit(limit)
+	items, err := u.feedRepo.FindByFollowerID(ctx, uid, cursor, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed FeedUseCase.Get: %w", err)
+	}
+
+	page := &entity.FeedPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = service.EncodeFeedCursor(entity.NewFeedCursor(items[limit-1]))
+	}
+	return page, nil
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+// FollowUseCase はユーザのフォローに関するユースケースです
+type FollowUseCase struct {
+	followRepo repository.Follow
+	userRepo   repository.User
+}
+
+// NewFollowUseCase はFollowUseCaseのポインタを生成する関数です
+func NewFollowUseCase(followRepo repository.Follow, userRepo repository.User) *FollowUseCase {
+	return &FollowUseCase{
+		followRepo: followRepo,
+		userRepo:   userRepo,
+	}
+}
+
+// Follow はFollowerIDのユーザがFolloweeIDのユーザをフォローします
+// 既にフォローしている場合は何もしません
+func (u *FollowUseCase) Follow(ctx context.Context, follow *entity.Follow) error {
+	if err := follow.IsValid(); err != nil {
+		return fmt.Errorf("invalid follow fields: %w", err)
+	}
+	if _, err := u.userRepo.FindByID(ctx, follow.FolloweeID); err != nil {
+		return fmt.Errorf("failed FollowUseCase.Follow: %w", err)
+	}
+	if err := u.followRepo.Insert(ctx, follow); err != nil {
+		return fmt.Errorf("failed FollowUseCase.Follow: %w", err)
+	}
+	return nil
+}
+
+// Unfollow はFollowerIDのユーザがFolloweeIDのユーザのフォローを外します
+// フォローしていない場合は何もしません
+func (u *FollowUseCase) Unfollow(ctx context.Context, follow *entity.Follow) error {
+	if err := follow.IsValid(); err != nil {
+		return fmt.Errorf("invalid follow fields: %w", err)
+	}
+	if _, err := u.userRepo.FindByID(ctx, follow.FolloweeID); err != nil {
+		return fmt.Errorf("failed FollowUseCase.Unfollow: %w", err)
+	}
+	if err := u.followRepo.Delete(ctx, follow); err != nil {
+		return fmt.Errorf("failed FollowUseCase.Unfollow: %w", err)
+	}
+	return nil
+}
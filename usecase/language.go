@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+)
+
+// LanguageUseCase は投稿に使える言語に関するユースケースです
+type LanguageUseCase struct{}
+
+// NewLanguageUseCase はLanguageUseCaseのポインタを生成する関数です
+func NewLanguageUseCase() *LanguageUseCase {
+	return &LanguageUseCase{}
+}
+
+// GetAll は投稿に使える言語を全て取得します
+func (u *LanguageUseCase) GetAll() []*entity.Language {
+	return entity.Languages()
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/log"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+// resolveMentions は本文に含まれるメンションのうち実在するユーザを出現順に返します
+// 存在しないユーザへのメンションと，entity.MaxMentionsを超えるメンションは無視します
+func resolveMentions(ctx context.Context, userRepo repository.User, content string) ([]*entity.Mention, error) {
+	var mentions []*entity.Mention
+	for _, id := range service.ExtractMentions(content) {
+		if len(mentions) >= entity.MaxMentions {
+			break
+		}
+		user, err := userRepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, entity.ErrUserNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to find mentioned user: %w", err)
+		}
+		mentions = append(mentions, &entity.Mention{UserID: user.ID, Name: user.Name})
+	}
+	return mentions, nil
+}
+
+// notifyMentions は新しくメンションされたユーザのうち，postを閲覧できるユーザに通知します
+// 通知に失敗しても本文の保存自体は完了しているので，エラーは記録するだけにする
+func notifyMentions(ctx context.Context, postRepo repository.Post, notificationRepo repository.Notification, post *entity.Post, notifications []*entity.Notification) {
+	if len(notifications) == 0 {
+		return
+	}
+	notifications, err := filterVisibleNotifications(ctx, postRepo, post, notifications)
+	if err != nil {
+		log.New().Errorf("failed to notify mentions: %s", err.Error())
+		return
+	}
+	if len(notifications) == 0 {
+		return
+	}
+	if err := notificationRepo.Insert(ctx, notifications); err != nil {
+		log.New().Errorf("failed to notify mentions: %s", err.Error())
+	}
+}
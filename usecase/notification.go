@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+// NotificationUseCase はユーザへの通知に関するユースケースです
+type NotificationUseCase struct {
+	notificationRepo repository.Notification
+}
+
+// NewNotificationUseCase はNotificationUseCaseのポインタを生成する関数です
+func NewNotificationUseCase(notificationRepo repository.Notification) *NotificationUseCase {
+	return &NotificationUseCase{notificationRepo: notificationRepo}
+}
+
+// GetAll はuidのユーザへの通知をcursorの位置から新しい順に1ページ分，未読の数とともに取得します
+func (u *NotificationUseCase) GetAll(ctx context.Context, uid string, cursor *entity.Cursor, limit int) (*entity.NotificationPage, error) {
+	limit = entity.NormalizePageLimit(limit)
+	notifications, err := u.notificationRepo.FindByUserID(ctx, uid, cursor, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed NotificationUseCase.GetAll: %w", err)
+	}
+	unreadCount, err := u.notificationRepo.CountUnread(ctx, uid)
+	if err != nil {
+		return nil, fmt.Errorf("failed NotificationUseCase.GetAll: %w", err)
+	}
+
+	page := &entity.NotificationPage{Notifications: notifications, UnreadCount: unreadCount}
+	if len(notifications) > limit {
+		page.Notifications = notifications[:limit]
+		page.NextCursor = service.EncodeCursor(entity.NewNotificationCursor(notifications[limit-1]))
+	}
+	return page, nil
+}
+
+// Read はuidのユーザへのidの通知を既読にします
+// 他のユーザへの通知は存在しないものとして扱います
+func (u *NotificationUseCase) Read(ctx context.Context, uid string, id int) error {
+	notification, err := u.notificationRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed NotificationUseCase.Read: %w", err)
+	}
+	if notification.UserID != uid {
+		return entity.NewErrorNotFound("notification")
+	}
+	if notification.Read {
+		return nil
+	}
+	if err := u.notificationRepo.MarkRead(ctx, notification); err != nil {
+		return fmt.Errorf("failed NotificationUseCase.Read: %w", err)
+	}
+	return nil
+}
@@ -2,61 +2,350 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
 	"github.com/openhacku-saboten/OmnisCode-backend/repository"
 )
 
 // PostUsecase は投稿に関するユースケースの構造体です
 type PostUsecase struct {
-	postRepo repository.Post
-	userRepo repository.User
+	postRepo         repository.Post
+	userRepo         repository.User
+	commentRepo      repository.Comment
+	starRepo         repository.Star
+	notificationRepo repository.Notification
 }
 
 // NewPostUsecase は投稿に関するユースケースのポインタを生成します
-func NewPostUsecase(postRepo repository.Post, userRepo repository.User) *PostUsecase {
+func NewPostUsecase(postRepo repository.Post, userRepo repository.User, commentRepo repository.Comment, starRepo repository.Star, notificationRepo repository.Notification) *PostUsecase {
 	return &PostUsecase{
-		postRepo: postRepo,
-		userRepo: userRepo,
+		postRepo:         postRepo,
+		userRepo:         userRepo,
+		commentRepo:      commentRepo,
+		starRepo:         starRepo,
+		notificationRepo: notificationRepo,
 	}
 }
 
-// GetAll は保存されている投稿を全て取得します
-func (p *PostUsecase) GetAll(ctx context.Context) ([]*entity.Post, error) {
-	posts, err := p.postRepo.GetAll(ctx)
+// GetAll は保存されている投稿のうちfilterを満たすものをcursorの位置から新しい順に1ページ分取得します
+// viewerIDは閲覧しているユーザのIDで，空文字列でなければそのユーザがスターをつけているかと編集できるかをセットします
+func (p *PostUsecase) GetAll(ctx context.Context, viewerID string, filter *entity.PostFilter, cursor *entity.Cursor, limit int) (*entity.PostPage, error) {
+	limit = entity.NormalizePageLimit(limit)
+	filter.Tag = entity.NormalizeTagName(filter.Tag)
+	if len(filter.Status) > 0 && !entity.IsPostStatus(filter.Status) {
+		return nil, entity.ErrInvalidPostStatus
+	}
+	// 閲覧しているユーザ自身の下書きは一覧に含める
+	filter.ViewerID = viewerID
+	// 次のページが存在するかを判定するために1件多く取得する
+	posts, err := p.postRepo.GetAll(ctx, filter, cursor, limit+1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to GetAll: %w", err)
 	}
-
-	return posts, nil
+	page := newPostPage(posts, limit)
+	if err := personalizePosts(ctx, p.starRepo, viewerID, page.Posts); err != nil {
+		return nil, fmt.Errorf("failed to GetAll: %w", err)
+	}
+	return page, nil
 }
 
 // Get はpostIDを満たす投稿を1つ取得します
-func (p *PostUsecase) Get(ctx context.Context, postID int) (*entity.Post, error) {
-	post, err := p.postRepo.FindByID(ctx, postID)
+// revisionが0なら最新のリビジョンのコードを，それ以外なら指定した番号のリビジョンのコードをCodeにセットします
+// viewerIDは閲覧しているユーザのIDで，空文字列でなければそのユーザがスターをつけているかと編集できるかをセットします
+// viewerIDのユーザが閲覧できない非公開の投稿はErrNotFoundを返します
+func (p *PostUsecase) Get(ctx context.Context, viewerID string, postID, revision int) (*entity.Post, error) {
+	post, err := findVisiblePost(ctx, p.postRepo, postID, viewerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed PostUsecase.Get: %w", err)
 	}
+
+	revisions, err := p.getRevisions(ctx, post)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.Get: %w", err)
+	}
+	target := entity.LatestRevision(revisions)
+	if revision != 0 {
+		if target, err = entity.FindRevision(revisions, revision); err != nil {
+			return nil, fmt.Errorf("failed PostUsecase.Get: %w", err)
+		}
+	}
+	post.ApplyRevision(target)
+	if err := personalizePosts(ctx, p.starRepo, viewerID, []*entity.Post{post}); err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.Get: %w", err)
+	}
 	return post, nil
 }
 
+// GetRevisions はpostIDを満たす投稿のリビジョンを番号順に全て取得します
+// viewerIDのユーザが閲覧できない非公開の投稿はErrNotFoundを返します
+func (p *PostUsecase) GetRevisions(ctx context.Context, viewerID string, postID int) ([]*entity.Revision, error) {
+	post, err := findVisiblePost(ctx, p.postRepo, postID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetRevisions: %w", err)
+	}
+
+	revisions, err := p.getRevisions(ctx, post)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetRevisions: %w", err)
+	}
+	return revisions, nil
+}
+
+// GetDiff はpostIDを満たす投稿のリビジョンfromからリビジョンtoへのコードの差分を取得します
+// fromが0なら投稿時のリビジョンを，toが0なら最新のリビジョンを対象にします
+// 複数のファイルからなる投稿ではfilenameのファイルの差分を取得し，filenameが空なら先頭のファイルを対象にします
+// viewerIDのユーザが閲覧できない非公開の投稿はErrNotFoundを返します
+func (p *PostUsecase) GetDiff(ctx context.Context, viewerID string, postID int, filename string, from, to int) (*entity.Diff, error) {
+	post, err := findVisiblePost(ctx, p.postRepo, postID, viewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetDiff: %w", err)
+	}
+
+	revisions, err := p.getRevisions(ctx, post)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetDiff: %w", err)
+	}
+	if from == 0 {
+		from = entity.OriginalRevision
+	}
+	fromRev, err := entity.FindRevision(revisions, from)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetDiff: %w", err)
+	}
+	toRev := entity.LatestRevision(revisions)
+	if to != 0 {
+		if toRev, err = entity.FindRevision(revisions, to); err != nil {
+			return nil, fmt.Errorf("failed PostUsecase.GetDiff: %w", err)
+		}
+	}
+
+	fromCode, fromOK := fromRev.FileCode(filename)
+	toCode, toOK := toRev.FileCode(filename)
+	if !fromOK || !toOK {
+		return nil, fmt.Errorf("failed PostUsecase.GetDiff: %w", entity.NewErrorNotFound("file"))
+	}
+	fromName, toName := fmt.Sprintf("revision/%d", fromRev.Number), fmt.Sprintf("revision/%d", toRev.Number)
+	if len(filename) > 0 {
+		fromName, toName = fromName+"/"+filename, toName+"/"+filename
+	}
+
+	hunks, err := service.DiffLines(fromCode, toCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetDiff: %w", err)
+	}
+	return &entity.Diff{
+		PostID:   post.ID,
+		Filename: filename,
+		From:     fromRev.Number,
+		To:       toRev.Number,
+		Unified:  service.FormatUnifiedDiff(fromName, toName, hunks),
+		Hunks:    hunks,
+	}, nil
+}
+
+// getRevisions は投稿とそのcommitコメントからリビジョンの一覧を組み立てます
+func (p *PostUsecase) getRevisions(ctx context.Context, post *entity.Post) ([]*entity.Revision, error) {
+	comments, err := p.commentRepo.FindByPostID(ctx, post.ID)
+	if err != nil {
+		// コメントが1つもない場合は投稿時のリビジョンのみ
+		errNF := &entity.ErrNotFound{}
+		if !errors.As(err, errNF) {
+			return nil, fmt.Errorf("failed to get comments: %w", err)
+		}
+	}
+	return entity.NewRevisions(post, comments), nil
+}
+
 // Create は引数のpostエンティティをもとに投稿を1つ生成します
+// 言語とタグは正規化してから保存し，本文でメンションされたユーザに通知します
+// 下書きの場合は公開するまで通知しません
 func (p *PostUsecase) Create(ctx context.Context, post *entity.Post) error {
+	if err := normalizePost(post); err != nil {
+		return fmt.Errorf("failed Create Post entity: %w", err)
+	}
+	var err error
+	if post.Mentions, err = resolveMentions(ctx, p.userRepo, post.Content); err != nil {
+		return fmt.Errorf("failed Create Post entity: %w", err)
+	}
+
 	if err := p.postRepo.Insert(ctx, post); err != nil {
 		return fmt.Errorf("failed Create Post entity: %w", err)
 	}
+	if post.Draft {
+		return nil
+	}
+	notifyMentions(ctx, p.postRepo, p.notificationRepo, post, service.NewMentionNotifications(post.UserID, post.ID, 0, post.Mentions, nil))
 	return nil
 }
 
 // Update は引数のpostエンティティをもとに投稿を1つ更新します
+// 言語とタグは正規化してから保存し，既存のタグとメンションは全て置き換えます
+// 更新によって新しくメンションされたユーザにだけ通知します．下書きの場合は公開するまで通知しません
+// commitのある投稿のコードはリビジョン1として履歴の起点になるので書き換えられず，
+// 保存されているコードか最新のリビジョンのコード以外が指定されるとErrPostCodeCommittedを返します
 func (p *PostUsecase) Update(ctx context.Context, post *entity.Post) error {
+	if err := normalizePost(post); err != nil {
+		return fmt.Errorf("failed Update Post: %w", err)
+	}
+	var err error
+	if post.Mentions, err = resolveMentions(ctx, p.userRepo, post.Content); err != nil {
+		return fmt.Errorf("failed Update Post: %w", err)
+	}
+	stored, err := p.postRepo.FindByID(ctx, post.ID)
+	if err != nil {
+		return fmt.Errorf("failed Update Post: %w", err)
+	}
+	if stored.UserID != post.UserID {
+		return fmt.Errorf("failed Update Post: %w", entity.ErrIsNotAuthor)
+	}
+	if !post.HasSameCode(stored) {
+		if err := p.keepCommittedCode(ctx, stored, post); err != nil {
+			return fmt.Errorf("failed Update Post: %w", err)
+		}
+	}
+	// 公開範囲が指定されなければ更新前のものを引き継ぐ．通知先もこの公開範囲で絞り込む
+	if len(post.Visibility) == 0 {
+		post.Visibility = stored.Visibility
+	}
+
 	if err := p.postRepo.Update(ctx, post); err != nil {
 		return fmt.Errorf("failed Update Post: %w", err)
 	}
+	if stored.Draft {
+		return nil
+	}
+	notifyMentions(ctx, p.postRepo, p.notificationRepo, post, service.NewMentionNotifications(post.UserID, post.ID, 0, post.Mentions, stored.Mentions))
+	return nil
+}
+
+// keepCommittedCode はcommitのある投稿の更新で，保存されているリビジョン1のコードをpostに引き継ぎます
+// postのコードが最新のリビジョンのコードとも違う場合はErrPostCodeCommittedを返します
+// commitがなければpostのコードをそのまま保存できるので何もしません
+func (p *PostUsecase) keepCommittedCode(ctx context.Context, stored, post *entity.Post) error {
+	revisions, err := p.getRevisions(ctx, stored)
+	if err != nil {
+		return err
+	}
+	if len(revisions) <= 1 {
+		return nil
+	}
+	latest := *stored
+	latest.ApplyRevision(entity.LatestRevision(revisions))
+	if !post.HasSameCode(&latest) {
+		return entity.ErrPostCodeCommitted
+	}
+	post.Code = stored.Code
+	post.Language = stored.Language
+	post.Files = stored.Files
+	return nil
+}
+
+// ChangeStatus は投稿の状態をstatusに変えます
+// 状態を変えられるのは投稿のオーナーだけで，許されている遷移以外はErrInvalidStatusTransitionを返します
+func (p *PostUsecase) ChangeStatus(ctx context.Context, userID string, postID int, status string) error {
+	if !entity.IsPostStatus(status) {
+		return entity.ErrInvalidPostStatus
+	}
+	post, err := p.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed PostUsecase.ChangeStatus: %w", err)
+	}
+	if post.UserID != userID {
+		return entity.ErrIsNotAuthor
+	}
+	if !entity.CanTransitPostStatus(post.Status, status) {
+		return fmt.Errorf("cannot change post status from %s to %s: %w", post.Status, status, entity.ErrInvalidStatusTransition)
+	}
+	if post.Status == status {
+		return nil
+	}
+	if err := p.postRepo.UpdateStatus(ctx, postID, status); err != nil {
+		return fmt.Errorf("failed PostUsecase.ChangeStatus: %w", err)
+	}
 	return nil
 }
 
+// Publish は投稿のオーナーが下書きの投稿を公開し，本文でメンションされたユーザに通知します
+// 既に公開されている場合はErrPostAlreadyPublishedを返します
+func (p *PostUsecase) Publish(ctx context.Context, userID string, postID int) error {
+	post, err := p.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return fmt.Errorf("failed PostUsecase.Publish: %w", err)
+	}
+	if post.UserID != userID {
+		return entity.ErrIsNotAuthor
+	}
+	if !post.Draft {
+		return entity.ErrPostAlreadyPublished
+	}
+	if err := p.postRepo.Publish(ctx, postID); err != nil {
+		return fmt.Errorf("failed PostUsecase.Publish: %w", err)
+	}
+	post.Draft = false
+	notifyMentions(ctx, p.postRepo, p.notificationRepo, post, service.NewMentionNotifications(post.UserID, post.ID, 0, post.Mentions, nil))
+	return nil
+}
+
+// Fork はuserIDのユーザがpostIDの投稿をフォークし，最新のリビジョンのコードとファイルから新しい投稿を作成します
+// フォークした投稿はフォーク元の公開範囲を引き継ぎます．閲覧できない非公開の投稿はフォークできません
+// 本文のメンションはフォーク元の作者によるものなので，メンションされたユーザには通知しません
+func (p *PostUsecase) Fork(ctx context.Context, userID string, postID int) (*entity.Post, error) {
+	original, err := findVisiblePost(ctx, p.postRepo, postID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.Fork: %w", err)
+	}
+	revisions, err := p.getRevisions(ctx, original)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.Fork: %w", err)
+	}
+
+	latest := entity.LatestRevision(revisions)
+	fork := &entity.Post{
+		UserID:     userID,
+		Title:      original.Title,
+		Code:       latest.Code,
+		Language:   original.Language,
+		Files:      latest.Files,
+		Content:    original.Content,
+		Source:     original.Source,
+		Visibility: original.Visibility,
+		ForkedFrom: original.ID,
+		Tags:       original.Tags,
+		Mentions:   original.Mentions,
+	}
+	// 複数のファイルからなる投稿では，最新のリビジョンの先頭のファイルをCodeとLanguageにする
+	if err := normalizePost(fork); err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.Fork: %w", err)
+	}
+	if err := p.postRepo.Insert(ctx, fork); err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.Fork: %w", err)
+	}
+	return fork, nil
+}
+
+// GetForks はpostIDの投稿をフォークした公開されている投稿をcursorの位置から新しい順に1ページ分取得します
+// viewerIDのユーザが閲覧できない非公開の投稿はErrNotFoundを返します
+// viewerIDが空文字列でなければ，そのユーザがスターをつけているかと編集できるかをセットします
+func (p *PostUsecase) GetForks(ctx context.Context, viewerID string, postID int, cursor *entity.Cursor, limit int) (*entity.PostPage, error) {
+	if _, err := findVisiblePost(ctx, p.postRepo, postID, viewerID); err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetForks: %w", err)
+	}
+	limit = entity.NormalizePageLimit(limit)
+	posts, err := p.postRepo.FindForks(ctx, postID, cursor, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetForks: %w", err)
+	}
+	page := newPostPage(posts, limit)
+	if err := personalizePosts(ctx, p.starRepo, viewerID, page.Posts); err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetForks: %w", err)
+	}
+	return page, nil
+}
+
 // Delete は引数のpostエンティティをもとに投稿を削除します．
 func (p *PostUsecase) Delete(ctx context.Context, post *entity.Post) error {
 	if err := p.postRepo.Delete(ctx, post); err != nil {
@@ -64,3 +353,127 @@ func (p *PostUsecase) Delete(ctx context.Context, post *entity.Post) error {
 	}
 	return nil
 }
+
+// Star はユーザが投稿にスターをつけます
+// 既にスターをつけている場合は何もしません．閲覧できない非公開の投稿にはつけられません
+func (p *PostUsecase) Star(ctx context.Context, star *entity.Star) error {
+	if _, err := findVisiblePost(ctx, p.postRepo, star.PostID, star.UserID); err != nil {
+		return fmt.Errorf("failed PostUsecase.Star: %w", err)
+	}
+	if err := p.starRepo.Insert(ctx, star); err != nil {
+		return fmt.Errorf("failed PostUsecase.Star: %w", err)
+	}
+	return nil
+}
+
+// Unstar はユーザが投稿につけたスターを外します
+// スターをつけていない場合は何もしません．閲覧できない非公開の投稿からは外せません
+func (p *PostUsecase) Unstar(ctx context.Context, star *entity.Star) error {
+	if _, err := findVisiblePost(ctx, p.postRepo, star.PostID, star.UserID); err != nil {
+		return fmt.Errorf("failed PostUsecase.Unstar: %w", err)
+	}
+	if err := p.starRepo.Delete(ctx, star); err != nil {
+		return fmt.Errorf("failed PostUsecase.Unstar: %w", err)
+	}
+	return nil
+}
+
+// GetInvitedUserIDs は投稿のオーナーが，投稿に招待しているユーザのIDを招待した順に取得します
+func (p *PostUsecase) GetInvitedUserIDs(ctx context.Context, userID string, postID int) ([]string, error) {
+	if err := p.authorizeInvitation(ctx, userID, postID); err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetInvitedUserIDs: %w", err)
+	}
+	userIDs, err := p.postRepo.FindInvitedUserIDs(ctx, postID)
+	if err != nil {
+		return nil, fmt.Errorf("failed PostUsecase.GetInvitedUserIDs: %w", err)
+	}
+	return userIDs, nil
+}
+
+// Invite は投稿のオーナーがinviteeIDのユーザを投稿に招待します
+// 招待されたユーザは投稿が非公開でも閲覧できます．既に招待している場合は何もしません
+func (p *PostUsecase) Invite(ctx context.Context, userID string, postID int, inviteeID string) error {
+	if err := p.authorizeInvitation(ctx, userID, postID); err != nil {
+		return fmt.Errorf("failed PostUsecase.Invite: %w", err)
+	}
+	if err := p.postRepo.Invite(ctx, postID, inviteeID); err != nil {
+		return fmt.Errorf("failed PostUsecase.Invite: %w", err)
+	}
+	return nil
+}
+
+// Uninvite は投稿のオーナーがinviteeIDのユーザへの招待を取り消します
+// 招待していない場合は何もしません
+func (p *PostUsecase) Uninvite(ctx context.Context, userID string, postID int, inviteeID string) error {
+	if err := p.authorizeInvitation(ctx, userID, postID); err != nil {
+		return fmt.Errorf("failed PostUsecase.Uninvite: %w", err)
+	}
+	if err := p.postRepo.Uninvite(ctx, postID, inviteeID); err != nil {
+		return fmt.Errorf("failed PostUsecase.Uninvite: %w", err)
+	}
+	return nil
+}
+
+// authorizeInvitation はユーザが投稿のオーナーとして招待を管理できるかを検証します
+func (p *PostUsecase) authorizeInvitation(ctx context.Context, userID string, postID int) error {
+	post, err := p.postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return err
+	}
+	if post.UserID != userID {
+		return entity.ErrIsNotAuthor
+	}
+	return nil
+}
+
+// normalizePost は保存する前の投稿の言語を正規のIDに，タグを正規化したものに置き換えます
+// 言語が省略されている場合はコードから推定し，その確信度をLanguageDetectionにセットします
+// 複数のファイルからなる投稿では，ファイルごとに拡張子か内容から言語を求めて正規化してから先頭のファイルをCodeとLanguageにします
+func normalizePost(post *entity.Post) error {
+	post.LanguageDetection = nil
+	for _, file := range post.Files {
+		file.LanguageDetection = nil
+		if len(strings.TrimSpace(file.Language)) == 0 {
+			if file.LanguageDetection = service.DetectFileLanguage(file.Filename, file.Content); file.LanguageDetection != nil {
+				file.Language = file.LanguageDetection.Language
+			}
+		}
+		language, err := entity.NormalizeLanguage(file.Language)
+		if err != nil {
+			return fmt.Errorf("invalid language of file %q: %w", file.Filename, err)
+		}
+		file.Language = language
+	}
+	post.SyncPrimaryFile()
+	if len(post.Files) > 0 {
+		post.LanguageDetection = post.Files[0].LanguageDetection
+	}
+
+	if len(strings.TrimSpace(post.Language)) == 0 && len(post.Code) > 0 {
+		post.LanguageDetection = service.DetectLanguage(post.Code)
+		post.Language = post.LanguageDetection.Language
+	}
+
+	language, err := entity.NormalizeLanguage(post.Language)
+	if err != nil {
+		return err
+	}
+	tags, err := entity.NormalizeTags(post.Tags)
+	if err != nil {
+		return err
+	}
+	post.Language = language
+	post.Tags = tags
+	return nil
+}
+
+// newPostPage はlimit+1件を上限に取得した投稿からPostPageを生成します
+// limit件を超えていれば次のページが存在するので，limit件目を指すカーソルをセットします
+func newPostPage(posts []*entity.Post, limit int) *entity.PostPage {
+	page := &entity.PostPage{Posts: posts}
+	if len(posts) > limit {
+		page.Posts = posts[:limit]
+		page.NextCursor = service.EncodeCursor(entity.NewPostCursor(posts[limit-1]))
+	}
+	return page
+}
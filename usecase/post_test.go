@@ -7,6 +7,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/go-cmp/cmp"
 	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
 	"github.com/openhacku-saboten/OmnisCode-backend/infra/mock"
 )
 
@@ -41,14 +42,21 @@ func TestPost_Get_All_With_Mock(t *testing.T) {
 
 	ctx := context.Background()
 	postMock := mock.NewMockPost(ctrl)
-	postMock.EXPECT().GetAll(ctx).Return(validPosts, nil)
+	postMock.EXPECT().GetAll(ctx, &entity.PostFilter{}, nil, entity.DefaultPageLimit+1).Return(validPosts, nil)
 	userMock := mock.NewMockUser(ctrl)
+	commentMock := mock.NewMockComment(ctrl)
+	starMock := mock.NewMockStar(ctrl)
+	notificationMock := mock.NewMockNotification(ctrl)
 
-	sut := NewPostUsecase(postMock, userMock)
-	posts, err := sut.postRepo.GetAll(ctx)
+	sut := NewPostUsecase(postMock, userMock, commentMock, starMock, notificationMock)
+	page, err := sut.GetAll(ctx, "", &entity.PostFilter{}, nil, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
+	if page.NextCursor != "" {
+		t.Fatalf("NextCursor = %s, want empty", page.NextCursor)
+	}
+	posts := page.Posts
 
 	for idx := range posts {
 		if diff := cmp.Diff(posts[idx], validPosts[idx]); diff != "" {
@@ -57,6 +65,39 @@ func TestPost_Get_All_With_Mock(t *testing.T) {
 	}
 }
 
+func TestPost_GetAll_Next_Cursor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	validPosts := []*entity.Post{
+		{ID: 3, UserID: "testID", CreatedAt: "2021-03-23T11:42:58+09:00"},
+		{ID: 2, UserID: "testID", CreatedAt: "2021-03-23T11:42:57+09:00"},
+		{ID: 1, UserID: "testID", CreatedAt: "2021-03-23T11:42:56+09:00"},
+	}
+	cursor := &entity.Cursor{CreatedAt: "2021-03-23T11:42:59+09:00", ID: 4}
+
+	ctx := context.Background()
+	postMock := mock.NewMockPost(ctrl)
+	postMock.EXPECT().GetAll(ctx, &entity.PostFilter{}, cursor, 3).Return(validPosts, nil)
+	userMock := mock.NewMockUser(ctrl)
+	commentMock := mock.NewMockComment(ctrl)
+	starMock := mock.NewMockStar(ctrl)
+	notificationMock := mock.NewMockNotification(ctrl)
+	sut := NewPostUsecase(postMock, userMock, commentMock, starMock, notificationMock)
+
+	page, err := sut.GetAll(ctx, "", &entity.PostFilter{}, cursor, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(validPosts[:2], page.Posts); diff != "" {
+		t.Fatalf("GetAll: %s", diff)
+	}
+	wantCursor := service.EncodeCursor(entity.NewPostCursor(validPosts[1]))
+	if page.NextCursor != wantCursor {
+		t.Fatalf("NextCursor = %s, want = %s", page.NextCursor, wantCursor)
+	}
+}
+
 func TestPost_Get_With_Mock(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -77,8 +118,11 @@ func TestPost_Get_With_Mock(t *testing.T) {
 	postMock := mock.NewMockPost(ctrl)
 	postMock.EXPECT().FindByID(ctx, 1).Return(validPost, nil)
 	userMock := mock.NewMockUser(ctrl)
+	commentMock := mock.NewMockComment(ctrl)
+	starMock := mock.NewMockStar(ctrl)
+	notificationMock := mock.NewMockNotification(ctrl)
 
-	sut := NewPostUsecase(postMock, userMock)
+	sut := NewPostUsecase(postMock, userMock, commentMock, starMock, notificationMock)
 	post, err := sut.postRepo.FindByID(ctx, 1)
 	if err != nil {
 		t.Fatal(err)
@@ -108,8 +152,11 @@ func TestPost_Create_With_Mock(t *testing.T) {
 	postMock := mock.NewMockPost(ctrl)
 	postMock.EXPECT().Insert(ctx, validPost).Return(nil)
 	userMock := mock.NewMockUser(ctrl)
+	commentMock := mock.NewMockComment(ctrl)
+	starMock := mock.NewMockStar(ctrl)
+	notificationMock := mock.NewMockNotification(ctrl)
 
-	sut := NewPostUsecase(postMock, userMock)
+	sut := NewPostUsecase(postMock, userMock, commentMock, starMock, notificationMock)
 	if err := sut.Create(ctx, validPost); err != nil {
 		t.Fatal(err)
 	}
@@ -133,10 +180,19 @@ func TestPost_Update_With_Mock(t *testing.T) {
 
 	ctx := context.Background()
 	postMock := mock.NewMockPost(ctrl)
+	postMock.EXPECT().FindByID(ctx, 0).Return(&entity.Post{
+		ID:       0,
+		UserID:   "testID",
+		Code:     validPost.Code,
+		Language: "go",
+	}, nil)
 	postMock.EXPECT().Update(ctx, validPost).Return(nil)
 	userMock := mock.NewMockUser(ctrl)
+	commentMock := mock.NewMockComment(ctrl)
+	starMock := mock.NewMockStar(ctrl)
+	notificationMock := mock.NewMockNotification(ctrl)
 
-	sut := NewPostUsecase(postMock, userMock)
+	sut := NewPostUsecase(postMock, userMock, commentMock, starMock, notificationMock)
 	if err := sut.Update(ctx, validPost); err != nil {
 		t.Fatal(err)
 	}
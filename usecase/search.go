@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+// SearchUseCase は投稿の検索に関するユースケースです
+type SearchUseCase struct {
+	searchRepo repository.Search
+	starRepo   repository.Star
+}
+
+// NewSearchUseCase はSearchUseCaseのポインタを生成する関数です
+func NewSearchUseCase(searchRepo repository.Search, starRepo repository.Star) *SearchUseCase {
+	return &SearchUseCase{searchRepo: searchRepo, starRepo: starRepo}
+}
+
+// Search はqueryの条件で投稿を検索し，関連度の高い順に1ページ分取得します
+// Languageには言語のIDの他に表示名や別名も指定できます
+// viewerIDは閲覧しているユーザのIDで，空文字列でなければそのユーザがスターをつけているかと編集できるかをセットします
+func (u *SearchUseCase) Search(ctx context.Context, viewerID string, query *entity.SearchQuery) (*entity.SearchPage, error) {
+	limit := entity.NormalizePageLimit(query.Limit)
+	// 次のページが存在するかを判定するために1件多く取得する
+	q := *query
+	q.Limit = limit + 1
+	// 言語の別名でも絞り込めるように正規のIDに揃える
+	// 登録されていない言語はそのまま渡し，ヒットしない結果にする
+	if lang, ok := entity.FindLanguage(q.Language); ok {
+		q.Language = lang.ID
+	}
+	results, err := u.searchRepo.SearchPosts(ctx, &q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to SearchPosts: %w", err)
+	}
+
+	page := &entity.SearchPage{Results: results}
+	if len(results) > limit {
+		page.Results = results[:limit]
+		page.NextCursor = service.EncodeOffsetCursor(query.Offset + limit)
+	}
+
+	posts := make([]*entity.Post, 0, len(page.Results))
+	for _, result := range page.Results {
+		posts = append(posts, result.Post)
+	}
+	if err := personalizePosts(ctx, u.starRepo, viewerID, posts); err != nil {
+		return nil, fmt.Errorf("failed to SearchPosts: %w", err)
+	}
+	return page, nil
+}
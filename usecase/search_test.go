@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/go-cmp/cmp"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/service"
+	"github.com/openhacku-saboten/OmnisCode-backend/infra"
+	"github.com/openhacku-saboten/OmnisCode-backend/infra/mock"
+)
+
+func TestSearch_Search(t *testing.T) {
+	searchRepo := infra.NewMemorySearchRepository()
+	searchRepo.AddPost(&entity.Post{ID: 1, UserID: "user1", Title: "goroutine leak", Code: "go func() {}", Language: "go", Visibility: entity.PostVisibilityPublic})
+	searchRepo.AddPost(&entity.Post{ID: 2, UserID: "user2", Title: "hello", Code: "print('goroutine')", Language: "python", Visibility: entity.PostVisibilityPublic})
+	searchRepo.AddPost(&entity.Post{ID: 3, UserID: "user1", Title: "channel", Code: "ch := make(chan int)", Language: "go", Visibility: entity.PostVisibilityPublic})
+	searchRepo.AddPost(&entity.Post{ID: 4, UserID: "user2", Title: "unrelated", Code: "x = 1", Language: "python", Visibility: entity.PostVisibilityPublic})
+	// 公開されていない投稿と下書きは検索結果に含まれない
+	searchRepo.AddPost(&entity.Post{ID: 5, UserID: "user1", Title: "goroutine unlisted", Code: "go f()", Language: "go", Visibility: entity.PostVisibilityUnlisted})
+	searchRepo.AddPost(&entity.Post{ID: 6, UserID: "user1", Title: "goroutine private", Code: "go f()", Language: "go", Visibility: entity.PostVisibilityPrivate})
+	searchRepo.AddPost(&entity.Post{ID: 7, UserID: "user1", Title: "goroutine draft", Code: "go f()", Language: "go", Visibility: entity.PostVisibilityPublic, Draft: true})
+	searchRepo.AddPost(&entity.Post{ID: 8, UserID: "user2", Title: "worker", Code: "package main", Language: "go", Visibility: entity.PostVisibilityPublic,
+		Files: []*entity.PostFile{
+			{Filename: "main.go", Language: "go", Content: "package main"},
+			{Filename: "worker.go", Language: "go", Content: "var wg sync.WaitGroup"},
+		}})
+	searchRepo.AddComment(&entity.Comment{ID: 1, PostID: 3, Type: "none", Content: "goroutineで書き直すと良さそう"})
+
+	tests := []struct {
+		name           string
+		query          *entity.SearchQuery
+		wantPostIDs    []int
+		wantNextCursor string
+	}{
+		{
+			name:           "タイトルでヒットした投稿が上位になる",
+			query:          &entity.SearchQuery{Keyword: "goroutine"},
+			wantPostIDs:    []int{1, 3, 2},
+			wantNextCursor: "",
+		},
+		{
+			name:           "言語の別名でも絞り込める",
+			query:          &entity.SearchQuery{Keyword: "goroutine", Language: "golang"},
+			wantPostIDs:    []int{1, 3},
+			wantNextCursor: "",
+		},
+		{
+			name:           "投稿者で絞り込める",
+			query:          &entity.SearchQuery{Keyword: "goroutine", UserID: "user2"},
+			wantPostIDs:    []int{2},
+			wantNextCursor: "",
+		},
+		{
+			name:           "limitを超えると次のページのカーソルを返す",
+			query:          &entity.SearchQuery{Keyword: "goroutine", Limit: 2},
+			wantPostIDs:    []int{1, 3},
+			wantNextCursor: service.EncodeOffsetCursor(2),
+		},
+		{
+			name:           "カーソルの位置から続きを取得できる",
+			query:          &entity.SearchQuery{Keyword: "goroutine", Limit: 2, Offset: 2},
+			wantPostIDs:    []int{2},
+			wantNextCursor: "",
+		},
+		{
+			name:           "2つ目以降のファイルの内容でも検索できる",
+			query:          &entity.SearchQuery{Keyword: "waitgroup"},
+			wantPostIDs:    []int{8},
+			wantNextCursor: "",
+		},
+		{
+			name:           "ヒットしなければ空の結果を返す",
+			query:          &entity.SearchQuery{Keyword: "rust"},
+			wantPostIDs:    []int{},
+			wantNextCursor: "",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			starMock := mock.NewMockStar(ctrl)
+			sut := NewSearchUseCase(searchRepo, starMock)
+			page, err := sut.Search(context.Background(), "", tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotPostIDs := []int{}
+			for _, result := range page.Results {
+				gotPostIDs = append(gotPostIDs, result.Post.ID)
+			}
+			if diff := cmp.Diff(tt.wantPostIDs, gotPostIDs); diff != "" {
+				t.Errorf("PostIDs (-want +got) =\n%s\n", diff)
+			}
+			if page.NextCursor != tt.wantNextCursor {
+				t.Errorf("NextCursor = %s, want = %s", page.NextCursor, tt.wantNextCursor)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+// TagUseCase はタグに関するユースケースです
+type TagUseCase struct {
+	tagRepo repository.Tag
+}
+
+// NewTagUseCase はTagUseCaseのポインタを生成する関数です
+func NewTagUseCase(tagRepo repository.Tag) *TagUseCase {
+	return &TagUseCase{tagRepo: tagRepo}
+}
+
+// GetAll は使われているタグを使用数の多い順に全て取得します
+func (u *TagUseCase) GetAll(ctx context.Context) ([]*entity.Tag, error) {
+	tags, err := u.tagRepo.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to GetAll tags: %w", err)
+	}
+	return tags, nil
+}
@@ -14,21 +14,23 @@ type UserUseCase struct {
 	authRepo    repository.Auth
 	postRepo    repository.Post
 	commentRepo repository.Comment
+	starRepo    repository.Star
 }
 
 // NewUserUseCase はユーザに関するユースケースのポインタを生成します
-func NewUserUseCase(user repository.User, auth repository.Auth, post repository.Post, comment repository.Comment) *UserUseCase {
+func NewUserUseCase(user repository.User, auth repository.Auth, post repository.Post, comment repository.Comment, star repository.Star) *UserUseCase {
 	return &UserUseCase{
 		userRepo:    user,
 		authRepo:    auth,
 		postRepo:    post,
 		commentRepo: comment,
+		starRepo:    star,
 	}
 }
 
-// Get は引数のuidを満たすユーザを1つ取得します
+// Get は引数のuidを満たすユーザのプロフィールをフォロワー数，フォロー数とともに1つ取得します
 func (u *UserUseCase) Get(ctx context.Context, uid string) (user *entity.User, err error) {
-	user, err = u.userRepo.FindByID(ctx, uid)
+	user, err = u.userRepo.FindProfile(ctx, uid)
 	if err != nil {
 		return nil, fmt.Errorf("failed to Get User from DB: %w", err)
 	}
@@ -41,21 +43,45 @@ func (u *UserUseCase) Get(ctx context.Context, uid string) (user *entity.User, e
 }
 
 // GetComments は引数のuidを満たすユーザが行ったコメントを全て取得します
-func (u *UserUseCase) GetComments(ctx context.Context, uid string) ([]*entity.Comment, error) {
+// viewerIDは閲覧しているユーザのIDで，空文字列でなければそのユーザが編集できるかをセットします
+func (u *UserUseCase) GetComments(ctx context.Context, viewerID, uid string) ([]*entity.Comment, error) {
 	comments, err := u.commentRepo.FindByUserID(ctx, uid)
 	if err != nil {
 		return nil, err
 	}
+	personalizeComments(viewerID, comments)
 	return comments, nil
 }
 
-// GetPosts は引数のuidを満たすユーザが行った投稿を全て取得します
-func (u *UserUseCase) GetPosts(ctx context.Context, uid string) ([]*entity.Post, error) {
-	posts, err := u.postRepo.FindByUserID(ctx, uid)
+// GetPosts は引数のuidを満たすユーザが行った投稿をcursorの位置から新しい順に1ページ分取得します
+// 一覧に表示しない限定公開と非公開の投稿は，viewerIDがuidと一致するユーザ本人の場合にだけ含めます
+// viewerIDが空文字列でなければ，そのユーザがスターをつけているかと編集できるかをセットします
+func (u *UserUseCase) GetPosts(ctx context.Context, viewerID, uid string, cursor *entity.Cursor, limit int) (*entity.PostPage, error) {
+	limit = entity.NormalizePageLimit(limit)
+	posts, err := u.postRepo.FindByUserID(ctx, uid, viewerID == uid, cursor, limit+1)
 	if err != nil {
 		return nil, fmt.Errorf("failed UserUseCase.GetPosts: %w", err)
 	}
-	return posts, nil
+	page := newPostPage(posts, limit)
+	if err := personalizePosts(ctx, u.starRepo, viewerID, page.Posts); err != nil {
+		return nil, fmt.Errorf("failed UserUseCase.GetPosts: %w", err)
+	}
+	return page, nil
+}
+
+// GetStarredPosts は引数のuidを満たすユーザがスターをつけた投稿をcursorの位置から新しい順に1ページ分取得します
+// viewerIDが空文字列でなければ，そのユーザがスターをつけているかと編集できるかをセットします
+func (u *UserUseCase) GetStarredPosts(ctx context.Context, viewerID, uid string, cursor *entity.Cursor, limit int) (*entity.PostPage, error) {
+	limit = entity.NormalizePageLimit(limit)
+	posts, err := u.postRepo.GetAll(ctx, &entity.PostFilter{StarredBy: uid}, cursor, limit+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed UserUseCase.GetStarredPosts: %w", err)
+	}
+	page := newPostPage(posts, limit)
+	if err := personalizePosts(ctx, u.starRepo, viewerID, page.Posts); err != nil {
+		return nil, fmt.Errorf("failed UserUseCase.GetStarredPosts: %w", err)
+	}
+	return page, nil
 }
 
 // Create は引数のユーザエンティティをもとにユーザを1つ生成します
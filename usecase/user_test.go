@@ -48,19 +48,22 @@ func TestUsecase_Get_Posts_By_ID(t *testing.T) {
 	authMock.EXPECT().Authenticate(ctx, token).Return(userID, nil)
 	userMock := mock.NewMockUser(ctrl)
 	postMock := mock.NewMockPost(ctrl)
-	postMock.EXPECT().FindByUserID(ctx, userID).Return(validPosts, nil)
+	postMock.EXPECT().FindByUserID(ctx, userID, true, nil, entity.DefaultPageLimit+1).Return(validPosts, nil)
 	commentMock := mock.NewMockComment(ctrl)
+	starMock := mock.NewMockStar(ctrl)
+	starMock.EXPECT().FindStarredPostIDs(ctx, userID, []int{1, 2}).Return([]int{2}, nil)
 
-	sut := NewUserUseCase(userMock, authMock, postMock, commentMock)
+	sut := NewUserUseCase(userMock, authMock, postMock, commentMock, starMock)
 
 	uid, err := sut.authRepo.Authenticate(ctx, token)
 	if err != nil {
 		t.Fatal(err)
 	}
-	posts, err := sut.postRepo.FindByUserID(ctx, uid)
+	page, err := sut.GetPosts(ctx, uid, uid, nil, 0)
 	if err != nil {
 		t.Fatal(err)
 	}
+	posts := page.Posts
 
 	for idx := range posts {
 		if diff := cmp.Diff(posts[idx], validPosts[idx]); diff != "" {
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+// personalizePosts は閲覧しているユーザがそれぞれの投稿にスターをつけているかをStarredに，編集できるかをEditableにセットします
+// 未ログインでviewerIDが空文字列の場合は何もしません
+func personalizePosts(ctx context.Context, starRepo repository.Star, viewerID string, posts []*entity.Post) error {
+	if len(viewerID) == 0 || len(posts) == 0 {
+		return nil
+	}
+
+	postIDs := make([]int, 0, len(posts))
+	for _, post := range posts {
+		postIDs = append(postIDs, post.ID)
+	}
+	starredIDs, err := starRepo.FindStarredPostIDs(ctx, viewerID, postIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find starred posts: %w", err)
+	}
+
+	starred := make(map[int]bool, len(starredIDs))
+	for _, id := range starredIDs {
+		starred[id] = true
+	}
+	for _, post := range posts {
+		s := starred[post.ID]
+		post.Starred = &s
+		e := post.IsEditableBy(viewerID)
+		post.Editable = &e
+	}
+	return nil
+}
+
+// personalizeComments は閲覧しているユーザがそれぞれのコメントを編集できるかをEditableにセットします
+// 未ログインでviewerIDが空文字列の場合は何もしません
+func personalizeComments(viewerID string, comments []*entity.Comment) {
+	if len(viewerID) == 0 {
+		return
+	}
+	for _, comment := range comments {
+		e := comment.IsEditableBy(viewerID)
+		comment.Editable = &e
+	}
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/openhacku-saboten/OmnisCode-backend/domain/entity"
+	"github.com/openhacku-saboten/OmnisCode-backend/repository"
+)
+
+// findVisiblePost はpostIDを満たす投稿のうちviewerIDのユーザが閲覧できるものを取得します
+// 閲覧できない非公開の投稿は存在を知られないように，存在しない投稿と同じくErrNotFoundを返します
+func findVisiblePost(ctx context.Context, postRepo repository.Post, postID int, viewerID string) (*entity.Post, error) {
+	post, err := postRepo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	var invited bool
+	if post.Visibility == entity.PostVisibilityPrivate && len(viewerID) > 0 && post.UserID != viewerID {
+		if invited, err = postRepo.IsInvited(ctx, postID, viewerID); err != nil {
+			return nil, fmt.Errorf("failed to check invitation: %w", err)
+		}
+	}
+	if !post.IsVisibleTo(viewerID, invited) {
+		return nil, entity.NewErrorNotFound("post")
+	}
+	return post, nil
+}
+
+// filterVisibleNotifications はnotificationsのうち宛先のユーザがpostを閲覧できるものだけを返します
+// 非公開の投稿や下書きの存在を知られないように，閲覧できないユーザには通知しません
+func filterVisibleNotifications(ctx context.Context, postRepo repository.Post, post *entity.Post, notifications []*entity.Notification) ([]*entity.Notification, error) {
+	var filtered []*entity.Notification
+	visible := make(map[string]bool)
+	for _, notification := range notifications {
+		ok, checked := visible[notification.UserID]
+		if !checked {
+			var invited bool
+			if post.Visibility == entity.PostVisibilityPrivate && !post.Draft && post.UserID != notification.UserID {
+				var err error
+				if invited, err = postRepo.IsInvited(ctx, post.ID, notification.UserID); err != nil {
+					return nil, fmt.Errorf("failed to check invitation: %w", err)
+				}
+			}
+			ok = post.IsVisibleTo(notification.UserID, invited)
+			visible[notification.UserID] = ok
+		}
+		if ok {
+			filtered = append(filtered, notification)
+		}
+	}
+	return filtered, nil
+}